@@ -3,8 +3,10 @@ package app
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/lino-network/lino/param"
@@ -76,6 +78,11 @@ type LinoBlockchain struct {
 
 	// global param
 	paramHolder param.ParamHolder
+
+	// ante handler, router and header of latest block, used by tx simulation
+	anteHandler     sdk.AnteHandler
+	router          bam.Router
+	lastBlockHeader abci.Header
 }
 
 // NewLinoBlockchain - create a Lino Blockchain instance
@@ -113,8 +120,11 @@ func NewLinoBlockchain(
 	lb.developerManager = developer.NewDeveloperManager(lb.CapKeyDeveloperStore, lb.paramHolder)
 	lb.proposalManager = proposal.NewProposalManager(lb.CapKeyProposalStore, lb.paramHolder)
 
-	lb.Router().
-		AddRoute(types.AccountRouterName, acc.NewHandler(lb.accountManager, lb.globalManager)).
+	lb.router = lb.Router()
+	lb.router.
+		AddRoute(types.AccountRouterName, acc.NewHandler(
+			lb.accountManager, lb.globalManager,
+			lb.checkUsernameSaleObligation, lb.checkAccountCloseObligation)).
 		AddRoute(types.PostRouterName, post.NewHandler(
 			lb.postManager, lb.accountManager, lb.globalManager, lb.developerManager, lb.reputationManager)).
 		AddRoute(types.VoteRouterName, vote.NewHandler(
@@ -123,14 +133,15 @@ func NewLinoBlockchain(
 			lb.developerManager, lb.accountManager, lb.globalManager)).
 		AddRoute(types.ProposalRouterName, proposal.NewHandler(
 			lb.accountManager, lb.proposalManager, lb.postManager, lb.globalManager, lb.voteManager)).
-		AddRoute(types.InfraRouterName, infra.NewHandler(lb.infraManager)).
+		AddRoute(types.InfraRouterName, infra.NewHandler(lb.infraManager, lb.postManager)).
 		AddRoute(types.ValidatorRouterName, val.NewHandler(
 			lb.accountManager, lb.valManager, lb.voteManager, lb.globalManager))
 
 	lb.SetInitChainer(lb.initChainer)
 	lb.SetBeginBlocker(lb.beginBlocker)
 	lb.SetEndBlocker(lb.endBlocker)
-	lb.SetAnteHandler(auth.NewAnteHandler(lb.accountManager, lb.globalManager))
+	lb.anteHandler = auth.NewAnteHandler(lb.accountManager, lb.globalManager)
+	lb.SetAnteHandler(lb.anteHandler)
 	// TODO(Cosmos): mounting multiple stores is broken
 	// https://github.com/cosmos/cosmos-sdk/issues/532
 
@@ -171,6 +182,169 @@ func DefaultTxDecoder(cdc *wire.Codec) sdk.TxDecoder {
 	}
 }
 
+// Query - serves tx simulation and queries evaluated at latest block time on app paths,
+// passes other queries to base app. Base app simulation runs ante handler on check state
+// directly, which increases sequence and consumes capacity of signers.
+func (lb *LinoBlockchain) Query(req abci.RequestQuery) abci.ResponseQuery {
+	var res interface{}
+	switch req.Path {
+	case types.SimulateQueryPath:
+		tx, err := DefaultTxDecoder(lb.cdc)(req.Data)
+		if err != nil {
+			return err.QueryResult()
+		}
+		res = lb.SimulateTx(tx)
+	case types.GrantedAppsQueryPath:
+		ctx, err := lb.queryContext()
+		if err != nil {
+			return err.QueryResult()
+		}
+		grantedApps, err := lb.accountManager.GetGrantedApps(ctx, types.AccountKey(req.Data))
+		if err != nil {
+			return err.QueryResult()
+		}
+		res = grantedApps
+	case types.PreAuthAllowanceQueryPath:
+		ctx, err := lb.queryContext()
+		if err != nil {
+			return err.QueryResult()
+		}
+		users := strings.SplitN(string(req.Data), types.KeySeparator, 2)
+		if len(users) != 2 {
+			return sdk.ErrUnknownRequest("query data must be username and app").QueryResult()
+		}
+		allowance, err := lb.accountManager.GetPreAuthAllowance(
+			ctx, types.AccountKey(users[0]), types.AccountKey(users[1]))
+		if err != nil {
+			return err.QueryResult()
+		}
+		res = allowance
+	case types.TaggedPostsQueryPath:
+		ctx, err := lb.queryContext()
+		if err != nil {
+			return err.QueryResult()
+		}
+		var query post.TaggedPostsQuery
+		if unmarshalErr := lb.cdc.UnmarshalJSON(req.Data, &query); unmarshalErr != nil {
+			return sdk.ErrUnknownRequest(unmarshalErr.Error()).QueryResult()
+		}
+		taggedPosts, err := lb.postManager.GetTaggedPosts(ctx, query.Tag, query.Page, query.PageSize)
+		if err != nil {
+			return err.QueryResult()
+		}
+		res = taggedPosts
+	case types.TrendingTagsQueryPath:
+		ctx, err := lb.queryContext()
+		if err != nil {
+			return err.QueryResult()
+		}
+		var query post.TrendingTagsQuery
+		if unmarshalErr := lb.cdc.UnmarshalJSON(req.Data, &query); unmarshalErr != nil {
+			return sdk.ErrUnknownRequest(unmarshalErr.Error()).QueryResult()
+		}
+		trendingTags, err := lb.postManager.GetTrendingTags(ctx, query.Days, query.Limit)
+		if err != nil {
+			return err.QueryResult()
+		}
+		res = trendingTags
+	default:
+		return lb.BaseApp.Query(req)
+	}
+	bz, marshalErr := lb.cdc.MarshalJSON(res)
+	if marshalErr != nil {
+		return sdk.ErrInternal(marshalErr.Error()).QueryResult()
+	}
+	return abci.ResponseQuery{Code: uint32(sdk.ABCICodeOK), Value: bz}
+}
+
+// queryContext - cache of check state under latest block header,
+// the cache is never written so queries can't change the state
+func (lb *LinoBlockchain) queryContext() (sdk.Context, sdk.Error) {
+	if lb.lastBlockHeader.ChainID == "" {
+		return sdk.Context{}, ErrSimulateNotReady()
+	}
+	ctx, _ := lb.NewContext(true, lb.lastBlockHeader).CacheContext()
+	return ctx, nil
+}
+
+// SimulateTx - run ante handler and msg handlers against a cache of check state
+// under latest block header, the cache is dropped so nothing is committed
+func (lb *LinoBlockchain) SimulateTx(tx sdk.Tx) (result types.SimulateResult) {
+	result.CapacityCost = types.NewCoinFromInt64(0)
+	setResult := func(res sdk.Result) {
+		result.Code = res.Code
+		result.Log = res.Log
+		result.Tags = res.Tags
+	}
+	if lb.lastBlockHeader.ChainID == "" {
+		setResult(ErrSimulateNotReady().Result())
+		return result
+	}
+	// write function of cache context is never called
+	ctx, _ := lb.NewContext(true, lb.lastBlockHeader).CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			setResult(ErrSimulateTxPanic(fmt.Sprintf("%v", r)).Result())
+		}
+	}()
+
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		setResult(sdk.ErrInternal("Tx.GetMsgs() must return at least one message in list").Result())
+		return result
+	}
+	numOfSigners := 0
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			setResult(err.Result())
+			return result
+		}
+		numOfSigners += len(msg.GetSigners())
+	}
+
+	// capacity cost is charged once for each signer by ante handler
+	tpsCapacityRatio, err := lb.globalManager.GetTPSCapacityRatio(ctx)
+	if err != nil {
+		setResult(err.Result())
+		return result
+	}
+	costPerSigner, err := lb.accountManager.GetTransactionCapacityCost(ctx, tpsCapacityRatio)
+	if err != nil {
+		setResult(err.Result())
+		return result
+	}
+	result.CapacityCost = types.RatToCoin(costPerSigner.ToRat().Mul(sdk.NewRat(int64(numOfSigners))))
+
+	newCtx, anteResult, abort := lb.anteHandler(ctx, tx)
+	if abort {
+		setResult(anteResult)
+		return result
+	}
+	if !newCtx.IsZero() {
+		ctx = newCtx
+	}
+
+	logs := make([]string, 0, len(msgs))
+	var tags sdk.Tags
+	for msgIdx, msg := range msgs {
+		handler := lb.router.Route(msg.Type())
+		if handler == nil {
+			setResult(sdk.ErrUnknownRequest("Unrecognized Msg type: " + msg.Type()).Result())
+			return result
+		}
+		msgResult := handler(ctx, msg)
+		tags = append(tags, msgResult.Tags...)
+		if !msgResult.IsOK() {
+			logs = append(logs, fmt.Sprintf("Msg %d failed: %s", msgIdx, msgResult.Log))
+			setResult(sdk.Result{Code: msgResult.Code, Log: strings.Join(logs, "\n"), Tags: tags})
+			return result
+		}
+		logs = append(logs, fmt.Sprintf("Msg %d: %s", msgIdx, msgResult.Log))
+	}
+	setResult(sdk.Result{Code: sdk.ABCICodeOK, Log: strings.Join(logs, "\n"), Tags: tags})
+	return result
+}
+
 // MackCodec - codec for application, used by command line tool and authenticate handler
 func MakeCodec() *wire.Codec {
 	cdc := wire.NewCodec()
@@ -197,6 +371,7 @@ func registerEvent(cdc *wire.Codec) {
 	cdc.RegisterConcrete(acc.ReturnCoinEvent{}, "lino/eventReturn", nil)
 	cdc.RegisterConcrete(param.ChangeParamEvent{}, "lino/eventCpe", nil)
 	cdc.RegisterConcrete(proposal.DecideProposalEvent{}, "lino/eventDpe", nil)
+	cdc.RegisterConcrete(acc.RecoveryEvent{}, "lino/eventRecovery", nil)
 }
 
 // custom logic for lino blockchain initialization
@@ -366,6 +541,7 @@ func (lb *LinoBlockchain) toAppInfra(
 
 // init process for a block, execute time events and fire incompetent validators
 func (lb *LinoBlockchain) beginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	lb.lastBlockHeader = req.Header
 	chainStartTime, err := lb.globalManager.GetChainStartTime(ctx)
 	if err != nil {
 		panic(err)
@@ -436,6 +612,10 @@ func (lb *LinoBlockchain) executeEvents(ctx sdk.Context, eventList []types.Event
 			if err := e.Execute(ctx, lb.accountManager); err != nil {
 				panic(err)
 			}
+		case acc.RecoveryEvent:
+			if err := e.Execute(ctx, lb.accountManager); err != nil {
+				panic(err)
+			}
 		case proposal.DecideProposalEvent:
 			if err := e.Execute(
 				ctx, lb.voteManager, lb.valManager, lb.accountManager, lb.proposalManager,
@@ -443,14 +623,74 @@ func (lb *LinoBlockchain) executeEvents(ctx sdk.Context, eventList []types.Event
 				panic(err)
 			}
 		case param.ChangeParamEvent:
-			if err := e.Execute(ctx, lb.paramHolder); err != nil {
-				panic(err)
+			if err := e.Execute(ctx, lb.paramHolder, proposal.ValidatePatchedParameter); err != nil {
+				if err.Code() != types.CodeParamPatchRejected {
+					panic(err)
+				}
+				// rejected patch is recorded on the passed proposal so it doesn't look applied
+				ctx.Logger().Info("param change rejected", "proposal", e.ProposalID, "err", err.Error())
+				if e.ProposalID != "" {
+					if err := lb.proposalManager.SetParamChangeFailure(ctx, e.ProposalID, err); err != nil {
+						panic(err)
+					}
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// the username sells with everything other modules hold for it,
+// it can't be sold while any obligation is outstanding
+func (lb *LinoBlockchain) checkUsernameSaleObligation(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	reason, err := lb.getAccountObligation(ctx, username)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return acc.ErrUsernameSaleBlocked(username, reason)
+	}
+	return nil
+}
+
+// deposits, inflation and content rewards are paid to the account later,
+// it can't be closed until none of them is outstanding
+func (lb *LinoBlockchain) checkAccountCloseObligation(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	reason, err := lb.getAccountObligation(ctx, username)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return acc.ErrAccountCloseBlocked(username, reason)
+	}
+	return nil
+}
+
+// getAccountObligation - returns the first obligation other modules hold for the account,
+// empty if there is none
+func (lb *LinoBlockchain) getAccountObligation(ctx sdk.Context, username types.AccountKey) (string, sdk.Error) {
+	if lb.valManager.DoesValidatorExist(ctx, username) {
+		return "account is a validator", nil
+	}
+	if lb.voteManager.DoesVoterExist(ctx, username) {
+		return "account is a voter", nil
+	}
+	if lb.developerManager.DoesDeveloperExist(ctx, username) {
+		return "account is a developer", nil
+	}
+	if lb.infraManager.DoesInfraProviderExist(ctx, username) {
+		return "account is an infra provider", nil
+	}
+	hasPendingRewards, err := lb.postManager.HasPendingRewards(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	if hasPendingRewards {
+		return "content reward events are pending", nil
+	}
+	return "", nil
+}
+
 // udpate validator set and renew reputation round
 func (lb *LinoBlockchain) endBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	ABCIValList, err := lb.valManager.GetUpdateValidatorList(ctx)
@@ -492,9 +732,20 @@ func (lb *LinoBlockchain) executeHourlyEvent(ctx sdk.Context) {
 	lb.distributeInflationToValidator(ctx)
 }
 
-// execute daily event, record consumption friction and lino power
+// execute daily event, record consumption friction and lino power,
+// compact post stats and prune tag donation stats
 func (lb *LinoBlockchain) executeDailyEvent(ctx sdk.Context) {
 	lb.globalManager.RecordConsumptionAndLinoStake(ctx)
+	pastDay, err := lb.globalManager.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
+	if err != nil {
+		panic(err)
+	}
+	if err := lb.postManager.CompactPostStats(ctx, pastDay); err != nil {
+		panic(err)
+	}
+	if err := lb.postManager.PruneTagDonationStats(ctx); err != nil {
+		panic(err)
+	}
 }
 
 // execute monthly event, distribute inflation to infra and application
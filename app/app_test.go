@@ -14,16 +14,21 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	cauth "github.com/cosmos/cosmos-sdk/x/auth"
 	abci "github.com/tendermint/tendermint/abci/types"
 	crypto "github.com/tendermint/tendermint/crypto"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/lino-network/lino/param"
+	acc "github.com/lino-network/lino/x/account"
+	accModel "github.com/lino-network/lino/x/account/model"
+	"github.com/lino-network/lino/x/auth"
 	devModel "github.com/lino-network/lino/x/developer/model"
 	globalModel "github.com/lino-network/lino/x/global/model"
 	infraModel "github.com/lino-network/lino/x/infra/model"
 	"github.com/lino-network/lino/x/post"
+	postModel "github.com/lino-network/lino/x/post/model"
 )
 
 var (
@@ -32,6 +37,7 @@ var (
 	addr1 = priv1.PubKey().Address()
 	priv2 = secp256k1.GenPrivKey()
 	addr2 = priv2.PubKey().Address()
+	priv3 = secp256k1.GenPrivKey()
 
 	genesisTotalCoin    = types.NewCoinFromInt64(2100000000 * types.Decimals)
 	coinPerValidator    = types.NewCoinFromInt64(100000000 * types.Decimals)
@@ -58,7 +64,7 @@ func newLinoBlockchain(t *testing.T, numOfValidators int) *LinoBlockchain {
 		Name:           user1,
 		Coin:           coinPerValidator,
 		ResetKey:       priv1.PubKey(),
-		TransactionKey: secp256k1.GenPrivKey().PubKey(),
+		TransactionKey: priv3.PubKey(),
 		AppKey:         secp256k1.GenPrivKey().PubKey(),
 		IsValidator:    true,
 		ValPubKey:      priv2.PubKey(),
@@ -226,6 +232,11 @@ func TestGenesisFromConfig(t *testing.T) {
 			ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 			ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 			ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+			TextProposalDecideSec:  int64(7 * 24 * 3600),
+			TextProposalPassRatio:  sdk.NewRat(50, 100),
+			TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+			TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 		},
 		param.DeveloperParam{
 			DeveloperMinDeposit:            types.NewCoinFromInt64(1000000 * types.Decimals),
@@ -257,11 +268,16 @@ func TestGenesisFromConfig(t *testing.T) {
 			RegisterFee:                  types.NewCoinFromInt64(0),
 			FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(0),
 			MaxNumFrozenMoney:            10,
+			ReferralBonus:                types.NewCoinFromInt64(0),
+			ReferralRewardRate:           sdk.NewRat(5, 100),
+			ReferralRewardPeriodSec:      90 * 24 * 3600,
+			SocialRecoveryDelaySec:       3 * 24 * 3600,
 		},
 		param.PostParam{
 			ReportOrUpvoteIntervalSec: 24 * 3600,
 			PostIntervalSec:           600,
 			MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
+			MaxNumOfRevisions:         10,
 		},
 		param.ReputationParam{
 			BestContentIndexN: 10,
@@ -862,3 +878,207 @@ func TestGlobalTime(t *testing.T) {
 		assert.Equal(t, cs.expectLastBlockTime, lastBlockTime)
 	}
 }
+
+func TestSimulateTx(t *testing.T) {
+	lb := newLinoBlockchain(t, 21)
+	genTx := func(msg sdk.Msg, seq int64) cauth.StdTx {
+		bz, _ := priv3.Sign(cauth.StdSignBytes("Lino", 0, seq, cauth.StdFee{}, []sdk.Msg{msg}, ""))
+		sigs := []cauth.StdSignature{{
+			PubKey:    priv3.PubKey(),
+			Signature: bz,
+			Sequence:  seq}}
+		return cauth.NewStdTx([]sdk.Msg{msg}, cauth.StdFee{}, sigs, "")
+	}
+	ctx := lb.BaseApp.NewContext(true, abci.Header{ChainID: "Lino", Time: time.Unix(0, 0)})
+	tpsCapacityRatio, err := lb.globalManager.GetTPSCapacityRatio(ctx)
+	assert.Nil(t, err)
+	costPerSigner, err := lb.accountManager.GetTransactionCapacityCost(ctx, tpsCapacityRatio)
+	assert.Nil(t, err)
+	savingBefore, err := lb.accountManager.GetSavingFromBank(ctx, types.AccountKey("validator1"))
+	assert.Nil(t, err)
+
+	logger, db := loggerAndDB()
+	notReadyLB := NewLinoBlockchain(logger, db, nil)
+	testCases := []struct {
+		testName       string
+		lb             *LinoBlockchain
+		tx             cauth.StdTx
+		expectCode     sdk.ABCICodeType
+		expectCapacity types.Coin
+	}{
+		{
+			testName:       "no block processed",
+			lb:             notReadyLB,
+			tx:             genTx(acc.NewTransferMsg(user1, "validator1", "1", ""), 0),
+			expectCode:     ErrSimulateNotReady().Result().Code,
+			expectCapacity: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:       "invalid msg",
+			lb:             lb,
+			tx:             genTx(acc.NewTransferMsg(user1, "validator1", "-1", ""), 0),
+			expectCode:     types.ErrInvalidCoins("").Result().Code,
+			expectCapacity: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:       "invalid sequence",
+			lb:             lb,
+			tx:             genTx(acc.NewTransferMsg(user1, "validator1", "1", ""), 1),
+			expectCode:     auth.ErrInvalidSequence("").Result().Code,
+			expectCapacity: costPerSigner,
+		},
+		{
+			testName:       "receiver doesn't exist",
+			lb:             lb,
+			tx:             genTx(acc.NewTransferMsg(user1, "dummy", "1", ""), 0),
+			expectCode:     acc.ErrReceiverNotFound(types.AccountKey("dummy")).Result().Code,
+			expectCapacity: costPerSigner,
+		},
+		{
+			testName:       "transfer succeeds",
+			lb:             lb,
+			tx:             genTx(acc.NewTransferMsg(user1, "validator1", "1", ""), 0),
+			expectCode:     sdk.ABCICodeOK,
+			expectCapacity: costPerSigner,
+		},
+		{
+			testName:       "simulation doesn't increase sequence",
+			lb:             lb,
+			tx:             genTx(acc.NewTransferMsg(user1, "validator1", "1", ""), 0),
+			expectCode:     sdk.ABCICodeOK,
+			expectCapacity: costPerSigner,
+		},
+	}
+	for _, tc := range testCases {
+		res := tc.lb.SimulateTx(tc.tx)
+		if res.Code != tc.expectCode {
+			t.Errorf("%s: diff code, got %v, want %v, log %s", tc.testName, res.Code, tc.expectCode, res.Log)
+		}
+		if !res.CapacityCost.IsEqual(tc.expectCapacity) {
+			t.Errorf("%s: diff capacity cost, got %v, want %v", tc.testName, res.CapacityCost, tc.expectCapacity)
+		}
+	}
+
+	// simulation through abci query doesn't change balance
+	tx := genTx(acc.NewTransferMsg(user1, "validator1", "1", ""), 0)
+	txBytes, marshalErr := lb.cdc.MarshalJSON(tx)
+	assert.Nil(t, marshalErr)
+	queryRes := lb.Query(abci.RequestQuery{Path: types.SimulateQueryPath, Data: txBytes})
+	assert.Equal(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+	var simulateRes types.SimulateResult
+	assert.Nil(t, lb.cdc.UnmarshalJSON(queryRes.Value, &simulateRes))
+	assert.Equal(t, sdk.ABCICodeOK, simulateRes.Code)
+	assert.True(t, simulateRes.CapacityCost.IsEqual(costPerSigner))
+	ctx = lb.BaseApp.NewContext(true, abci.Header{ChainID: "Lino", Time: time.Unix(0, 0)})
+	saving, err := lb.accountManager.GetSavingFromBank(ctx, types.AccountKey("validator1"))
+	assert.Nil(t, err)
+	assert.Equal(t, savingBefore, saving)
+	seq, err := lb.accountManager.GetSequence(ctx, types.AccountKey(user1))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), seq)
+
+	// once tx is committed, same tx can't pass simulation
+	lb.BeginBlock(abci.RequestBeginBlock{
+		Header: abci.Header{ChainID: "Lino", Time: time.Unix(1, 0)}})
+	assert.Equal(t, sdk.ABCICodeOK, lb.Deliver(tx).Code)
+	lb.EndBlock(abci.RequestEndBlock{})
+	lb.Commit()
+	res := lb.SimulateTx(tx)
+	assert.Equal(t, auth.ErrInvalidSequence("").Result().Code, res.Code)
+
+	// other queries are handled by base app
+	queryRes = lb.Query(abci.RequestQuery{Path: "/unknown"})
+	assert.NotEqual(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+}
+
+func TestGrantableMsgTypes(t *testing.T) {
+	MakeCodec()
+	testCases := map[string]bool{
+		"CreatePostMsg":           true,
+		"DonateMsg":               true,
+		"FollowMsg":               true,
+		"UpdateProfileMsg":        true,
+		"TransferMsg":             false,
+		"RecoverMsg":              false,
+		"ChangeTransactionKeyMsg": false,
+		"ListUsernameMsg":         false,
+		"SetGuardiansMsg":         false,
+		"RewardEvent":             false,
+	}
+	for msgType, want := range testCases {
+		if got := types.IsGrantableMsgType(msgType); got != want {
+			t.Errorf("%s: diff grantable, got %v, want %v", msgType, got, want)
+		}
+	}
+}
+
+func TestGrantQueries(t *testing.T) {
+	logger, db := loggerAndDB()
+	notReadyLB := NewLinoBlockchain(logger, db, nil)
+	queryRes := notReadyLB.Query(abci.RequestQuery{Path: types.GrantedAppsQueryPath, Data: []byte(user1)})
+	assert.Equal(t, uint32(ErrSimulateNotReady().Result().Code), queryRes.Code)
+
+	lb := newLinoBlockchain(t, 2)
+	header := abci.Header{ChainID: "Lino", Time: time.Unix(1, 0)}
+	lb.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := lb.BaseApp.NewContext(false, header)
+	err := lb.accountManager.AuthorizePeriodicPermission(
+		ctx, types.AccountKey(user1), types.AccountKey("validator1"), 100, 10, types.NewCoinFromInt64(5))
+	assert.Nil(t, err)
+	lb.EndBlock(abci.RequestEndBlock{})
+	lb.Commit()
+
+	queryRes = lb.Query(abci.RequestQuery{Path: types.GrantedAppsQueryPath, Data: []byte(user1)})
+	assert.Equal(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+	grants := []accModel.GrantPubKey{}
+	assert.Nil(t, lb.cdc.UnmarshalJSON(queryRes.Value, &grants))
+	assert.Equal(t, 1, len(grants))
+	assert.Equal(t, types.AccountKey("validator1"), grants[0].Username)
+
+	queryRes = lb.Query(abci.RequestQuery{
+		Path: types.PreAuthAllowanceQueryPath, Data: []byte(user1 + types.KeySeparator + "validator1")})
+	assert.Equal(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+	var allowance types.Coin
+	assert.Nil(t, lb.cdc.UnmarshalJSON(queryRes.Value, &allowance))
+	assert.Equal(t, types.NewCoinFromInt64(5), allowance)
+
+	queryRes = lb.Query(abci.RequestQuery{Path: types.PreAuthAllowanceQueryPath, Data: []byte(user1)})
+	assert.NotEqual(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+}
+
+func TestTagQueries(t *testing.T) {
+	lb := newLinoBlockchain(t, 1)
+	header := abci.Header{ChainID: "Lino", Time: time.Unix(1, 0)}
+	lb.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := lb.BaseApp.NewContext(false, header)
+	err := lb.postManager.CreatePost(
+		ctx, types.AccountKey(user1), "post1", "", "", "", "", "content", "title",
+		sdk.ZeroRat(), []types.IDToURLMapping{})
+	assert.Nil(t, err)
+	permlink := types.GetPermlink(types.AccountKey(user1), "post1")
+	assert.Nil(t, lb.postManager.SetPostTags(ctx, permlink, []string{"lino"}))
+	assert.Nil(t, lb.postManager.AddDonation(
+		ctx, permlink, "donator", types.NewCoinFromInt64(10), types.DirectDeposit, 0))
+	lb.EndBlock(abci.RequestEndBlock{})
+	lb.Commit()
+
+	query, marshalErr := lb.cdc.MarshalJSON(post.TaggedPostsQuery{Tag: "lino", Page: 1, PageSize: 10})
+	assert.Nil(t, marshalErr)
+	queryRes := lb.Query(abci.RequestQuery{Path: types.TaggedPostsQueryPath, Data: query})
+	assert.Equal(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+	taggedPosts := []postModel.TaggedPost{}
+	assert.Nil(t, lb.cdc.UnmarshalJSON(queryRes.Value, &taggedPosts))
+	assert.Equal(t, []postModel.TaggedPost{{Permlink: permlink, CreatedAt: 1}}, taggedPosts)
+
+	query, marshalErr = lb.cdc.MarshalJSON(post.TrendingTagsQuery{Days: 1, Limit: 10})
+	assert.Nil(t, marshalErr)
+	queryRes = lb.Query(abci.RequestQuery{Path: types.TrendingTagsQueryPath, Data: query})
+	assert.Equal(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+	trendingTags := []post.TrendingTag{}
+	assert.Nil(t, lb.cdc.UnmarshalJSON(queryRes.Value, &trendingTags))
+	assert.Equal(t, []post.TrendingTag{
+		{Tag: "lino", Amount: types.NewCoinFromInt64(10), DonateCount: 1}}, trendingTags)
+
+	queryRes = lb.Query(abci.RequestQuery{Path: types.TrendingTagsQueryPath, Data: []byte("days")})
+	assert.NotEqual(t, uint32(sdk.ABCICodeOK), queryRes.Code)
+}
@@ -12,3 +12,11 @@ import (
 func ErrGenesisFailed(msg string) sdk.Error {
 	return types.NewError(types.CodeGenesisFailed, fmt.Sprintf("genesis failed: %s", msg))
 }
+
+func ErrSimulateNotReady() sdk.Error {
+	return types.NewError(types.CodeSimulateNotReady, "no block has been processed since start")
+}
+
+func ErrSimulateTxPanic(msg string) sdk.Error {
+	return types.NewError(types.CodeSimulateTxPanic, fmt.Sprintf("simulate tx panic: %s", msg))
+}
@@ -187,6 +187,11 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 				ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 				ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+				TextProposalDecideSec:  int64(24 * 7 * 3600),
+				TextProposalPassRatio:  sdk.NewRat(50, 100),
+				TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+				TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 			},
 			param.DeveloperParam{
 				DeveloperMinDeposit:            types.NewCoinFromInt64(1000000 * types.Decimals),
@@ -218,11 +223,16 @@ func LinoBlockchainGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appSt
 				RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
 				FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
 				MaxNumFrozenMoney:            10,
+				ReferralBonus:                types.NewCoinFromInt64(0),
+				ReferralRewardRate:           sdk.NewRat(5, 100),
+				ReferralRewardPeriodSec:      90 * 24 * 3600,
+				SocialRecoveryDelaySec:       3 * 24 * 3600,
 			},
 			param.PostParam{
 				ReportOrUpvoteIntervalSec: 24 * 3600,
 				PostIntervalSec:           600,
 				MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
+				MaxNumOfRevisions:         10,
 			},
 			param.ReputationParam{
 				BestContentIndexN: 10,
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client/core"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	crypto "github.com/tendermint/tendermint/crypto"
 	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -41,6 +43,25 @@ func NewCoreContextFromViper() core.CoreContext {
 
 type CommandTxCallback func(cmd *cobra.Command, args []string) error
 
+// SendTx - sign and build the transaction from the msgs, then simulate it and print
+// the result if simulate flag is set, otherwise broadcast it to Tendermint
+func SendTx(ctx core.CoreContext, msgs []sdk.Msg, cdc *wire.Codec) error {
+	if viper.GetBool(FlagSimulate) {
+		res, err := ctx.SignBuildSimulate(msgs, cdc)
+		if err != nil {
+			return err
+		}
+		return PrintIndent(res)
+	}
+
+	res, err := ctx.SignBuildBroadcast(msgs, cdc)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+	return nil
+}
+
 func PrintIndent(inputs ...interface{}) error {
 	for _, input := range inputs {
 		output, err := json.MarshalIndent(input, "", "  ")
@@ -3,6 +3,8 @@ package core
 import (
 	"fmt"
 
+	"github.com/lino-network/lino/types"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -54,9 +56,35 @@ func (ctx CoreContext) QuerySubspace(cdc *wire.Codec, subspace []byte, storeName
 	return
 }
 
+// Simulate - dry run the transaction bytes against latest state without commit
+func (ctx CoreContext) Simulate(cdc *wire.Codec, tx []byte) (*types.SimulateResult, error) {
+	resRaw, err := ctx.queryPath(types.SimulateQueryPath, tx)
+	if err != nil {
+		return nil, err
+	}
+	res := new(types.SimulateResult)
+	if err := cdc.UnmarshalJSON(resRaw, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryApp - query app on the abci query path, result is decoded into res
+func (ctx CoreContext) QueryApp(cdc *wire.Codec, path string, data []byte, res interface{}) error {
+	resRaw, err := ctx.queryPath(path, data)
+	if err != nil {
+		return err
+	}
+	return cdc.UnmarshalJSON(resRaw, res)
+}
+
 // Query from Tendermint with the provided storename and path
 func (ctx CoreContext) query(key cmn.HexBytes, storeName, endPath string) (res []byte, err error) {
-	path := fmt.Sprintf("/store/%s/%s", storeName, endPath)
+	return ctx.queryPath(fmt.Sprintf("/store/%s/%s", storeName, endPath), key)
+}
+
+// Query from Tendermint with the provided abci query path
+func (ctx CoreContext) queryPath(path string, key cmn.HexBytes) (res []byte, err error) {
 	node, err := ctx.GetNode()
 	if err != nil {
 		return res, err
@@ -123,6 +151,16 @@ func (ctx CoreContext) SignBuildBroadcast(
 	return ctx.BroadcastTx(txBytes)
 }
 
+// sign and build the transaction from the msg, then simulate it without broadcast
+func (ctx CoreContext) SignBuildSimulate(
+	msgs []sdk.Msg, cdc *wire.Codec) (*types.SimulateResult, error) {
+	txBytes, err := ctx.SignAndBuild(msgs, cdc)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Simulate(cdc, txBytes)
+}
+
 // get passphrase from std input
 func (ctx CoreContext) GetPassphraseFromStdin(name string) (pass string, err error) {
 	buf := client.BufferStdin()
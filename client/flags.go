@@ -13,6 +13,7 @@ const (
 	FlagFee       = "fee"
 	FlagPrivKey   = "priv-key"
 	FlagPubKey    = "pub-key"
+	FlagSimulate  = "simulate"
 
 	// Account
 	FlagIsFollow = "is-follow"
@@ -23,6 +24,20 @@ const (
 	FlagAmount   = "amount"
 	FlagMemo     = "memo"
 
+	// Social recovery
+	FlagGuardians      = "guardians"
+	FlagThreshold      = "threshold"
+	FlagResetKey       = "reset-key"
+	FlagTransactionKey = "transaction-key"
+	FlagAppKey         = "app-key"
+
+	// Username sale
+	FlagUsername     = "username"
+	FlagPayee        = "payee"
+	FlagPrice        = "price"
+	FlagClearGrants  = "clear-grants"
+	FlagClearFollows = "clear-follows"
+
 	// Developer
 	FlagDeveloper   = "developer"
 	FlagDeposit     = "deposit"
@@ -34,10 +49,15 @@ const (
 	FlagSeconds     = "seconds"
 	FlagPermission  = "permission"
 	FlagGrantAmount = "grant-amount"
+	FlagScopes      = "scopes"
+	FlagAll         = "all"
+	FlagPeriod      = "period"
 
 	// Infra
-	FlagProvider = "provider"
-	FlagUsage    = "usage"
+	FlagProvider   = "provider"
+	FlagUsage      = "usage"
+	FlagContentRef = "content-ref"
+	FlagAvailable  = "available"
 
 	// Post
 	FlagDonator                 = "donator"
@@ -52,12 +72,29 @@ const (
 	FlagSourceAuthor            = "source-author"
 	FlagSourcePostID            = "source-post-ID"
 	FlagRedistributionSplitRate = "redistribution-split-rate"
+	FlagReplyPermission         = "reply-permission"
+	FlagIsBlocked               = "is-blocked"
+	FlagTags                    = "tags"
+	FlagDays                    = "days"
+	FlagLimit                   = "limit"
+	FlagBeneficiaries           = "beneficiaries"
+	FlagPaywallType             = "paywall-type"
+	FlagUnlockPrice             = "unlock-price"
+	FlagContentRefs             = "content-refs"
+	FlagDonations               = "donations"
 
 	// Vote
 	FlagVoter      = "voter"
 	FlagProposalID = "proposal-id"
 	FlagResult     = "result"
 	FlagLink       = "link"
+
+	// Proposal
+	FlagStatus       = "status"
+	FlagProposalType = "proposal-type"
+	FlagCreator      = "creator"
+	FlagPage         = "page"
+	FlagPageSize     = "page-size"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -83,6 +120,7 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagPrivKey, "", "Private key to sign the transaction")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
+		c.Flags().Bool(FlagSimulate, false, "Simulate the tx and report result and capacity cost without broadcast")
 	}
 	return cmds
 }
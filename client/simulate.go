@@ -0,0 +1,37 @@
+package client
+
+import (
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// SimulateTxCmd - dry run a signed transaction without broadcast
+func SimulateTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate <tx-file>",
+		Short: "Simulate a signed transaction in json, report result and capacity cost without commit",
+		Args:  cobra.ExactArgs(1),
+		RunE:  simulateTx(cdc),
+	}
+	return cmd
+}
+
+// simulate the transaction read from file against latest state
+func simulateTx(cdc *wire.Codec) CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := NewCoreContextFromViper()
+		txBytes, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		res, err := ctx.Simulate(cdc, txBytes)
+		if err != nil {
+			return err
+		}
+		return PrintIndent(res)
+	}
+}
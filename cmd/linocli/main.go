@@ -59,8 +59,18 @@ func main() {
 		tendermintCmd,
 		lcd.ServeCommand(cdc),
 	)
+	txCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Transaction subcommands",
+	}
+	txCmd.AddCommand(
+		client.GetCommands(
+			client.SimulateTxCmd(cdc),
+		)...)
+
 	linocliCmd.AddCommand(
 		advancedCmd,
+		txCmd,
 		client.LineBreak,
 	)
 
@@ -71,6 +81,24 @@ func main() {
 	linocliCmd.AddCommand(
 		client.PostCommands(
 			acccmd.RecoverTxCmd(cdc),
+			acccmd.ChangeAppKeyTxCmd(cdc),
+			acccmd.ChangeTransactionKeyTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			acccmd.SetGuardiansTxCmd(cdc),
+			acccmd.ApproveRecoveryTxCmd(cdc),
+			acccmd.CancelRecoveryTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			acccmd.ListUsernameTxCmd(cdc),
+			acccmd.CancelUsernameListingTxCmd(cdc),
+			acccmd.BuyUsernameTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			acccmd.CloseAccountTxCmd(cdc),
 		)...)
 	linocliCmd.AddCommand(
 		client.PostCommands(
@@ -100,6 +128,18 @@ func main() {
 		client.PostCommands(
 			postcmd.DonateTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			postcmd.UpdateReplyBlocklistTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			postcmd.UnlockPostTxCmd(cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			postcmd.BatchDonateTxCmd(cdc),
+		)...)
 	linocliCmd.AddCommand(
 		client.PostCommands(
 			validatorcmd.DepositValidatorTxCmd(cdc),
@@ -139,12 +179,24 @@ func main() {
 		)...)
 	linocliCmd.AddCommand(
 		client.GetCommands(
-			proposalcmd.GetOngoingProposalCmd(types.VoteKVStoreKey, cdc),
+			proposalcmd.GetOngoingProposalCmd(types.ProposalKVStoreKey, cdc),
 		)...)
 
 	linocliCmd.AddCommand(
 		client.GetCommands(
-			proposalcmd.GetExpiredProposalCmd(types.VoteKVStoreKey, cdc),
+			proposalcmd.GetExpiredProposalCmd(types.ProposalKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			proposalcmd.GetTextProposalsCmd(types.ProposalKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			proposalcmd.ListProposalsCmd(types.ProposalKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			proposalcmd.GetProposalTallyCmd(types.ProposalKVStoreKey, cdc),
 		)...)
 	linocliCmd.AddCommand(
 		client.PostCommands(
@@ -160,6 +212,10 @@ func main() {
 		client.PostCommands(
 			infracmd.ProviderReportTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			infracmd.ContentAttestationTxCmd(cdc),
+		)...)
 	linocliCmd.AddCommand(
 		client.PostCommands(
 			developercmd.DeveloperRegisterTxCmd(cdc),
@@ -184,6 +240,10 @@ func main() {
 		client.PostCommands(
 			developercmd.DeveloperUpdateTxCmd(cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.PostCommands(
+			developercmd.UpdateGrantScopesTxCmd(cdc),
+		)...)
 
 	linocliCmd.AddCommand(
 		client.GetCommands(
@@ -197,6 +257,16 @@ func main() {
 		client.GetCommands(
 			acccmd.GetAccountsCmd(types.AccountKVStoreKey, cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			acccmd.GetGrantCmd(types.AccountKVStoreKey, cdc),
+			acccmd.GetGrantsCmd(types.AccountKVStoreKey, cdc),
+			acccmd.GetPreAuthAllowanceCmd(types.AccountKVStoreKey, cdc),
+			acccmd.GetReferralsCmd(types.AccountKVStoreKey, cdc),
+			acccmd.GetRecoveryCmd(types.AccountKVStoreKey, cdc),
+			acccmd.GetUsernameListingCmd(types.AccountKVStoreKey, cdc),
+			acccmd.GetProfileCmd(types.AccountKVStoreKey, types.ReputationKVStoreKey, cdc),
+		)...)
 	linocliCmd.AddCommand(
 		client.GetCommands(
 			postcmd.GetPostCmd(types.PostKVStoreKey, cdc),
@@ -205,6 +275,42 @@ func main() {
 		client.GetCommands(
 			postcmd.GetPostsCmd(types.PostKVStoreKey, cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetPostCensorshipCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetPostRevisionsCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetTaggedPostsCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetTrendingTagsCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetPostAccessCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetReportQueueCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetPostReportStatCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetPostStatsCmd(types.PostKVStoreKey, cdc),
+		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			postcmd.GetAuthorStatsCmd(types.PostKVStoreKey, cdc),
+		)...)
 
 	linocliCmd.AddCommand(
 		client.GetCommands(
@@ -214,6 +320,10 @@ func main() {
 		client.GetCommands(
 			infracmd.GetInfraProvidersCmd(types.InfraKVStoreKey, cdc),
 		)...)
+	linocliCmd.AddCommand(
+		client.GetCommands(
+			infracmd.GetContentAttestationsCmd(types.InfraKVStoreKey, cdc),
+		)...)
 
 	linocliCmd.AddCommand(
 		client.GetCommands(
@@ -197,3 +197,23 @@ func ErrFailedToMarshalAccountParam(err error) sdk.Error {
 func ErrFailedToMarshalReputationParam(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToMarshalReputationParam, fmt.Sprintf("failed to marshal reputation param: %s", err.Error()))
 }
+
+// ErrParamNameNotFound - error when parameter name is unknown.
+func ErrParamNameNotFound(paramName string) sdk.Error {
+	return types.NewError(types.CodeParamNameNotFound, fmt.Sprintf("param %v not found", paramName))
+}
+
+// ErrParamFieldNotFound - error when parameter field is unknown.
+func ErrParamFieldNotFound(field string) sdk.Error {
+	return types.NewError(types.CodeParamFieldNotFound, fmt.Sprintf("param field %v not found", field))
+}
+
+// ErrInvalidParamFieldValue - error when parameter field value can't be parsed.
+func ErrInvalidParamFieldValue(field, value string) sdk.Error {
+	return types.NewError(types.CodeInvalidParamFieldValue, fmt.Sprintf("invalid value %v for param field %v", value, field))
+}
+
+// ErrParamPatchRejected - error when parameter patch is invalid on latest parameter at execution time.
+func ErrParamPatchRejected(paramName string, err sdk.Error) sdk.Error {
+	return types.NewError(types.CodeParamPatchRejected, fmt.Sprintf("patch on param %v rejected: %v", paramName, err.Data()))
+}
@@ -1,16 +1,24 @@
 package param
 
 import (
+	"github.com/lino-network/lino/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// ChangeParamEvent - change parameter event
+// ChangeParamEvent - change parameter event, proposal id is empty for
+// events registered before it was recorded
 type ChangeParamEvent struct {
-	Param Parameter `json:"param"`
+	Param      Parameter         `json:"param"`
+	ProposalID types.ProposalKey `json:"proposal_id"`
 }
 
-// Execute - execute change parameter event
-func (cpe ChangeParamEvent) Execute(ctx sdk.Context, ph ParamHolder) sdk.Error {
+// ParamValidator - check a complete parameter before a patch on it takes effect
+type ParamValidator func(parameter Parameter) sdk.Error
+
+// Execute - execute change parameter event, a fields patch is applied on the
+// latest parameter and rejected without any change if the result doesn't pass validate
+func (cpe ChangeParamEvent) Execute(ctx sdk.Context, ph ParamHolder, validate ParamValidator) sdk.Error {
 	parameter := cpe.Param
 	switch parameter := parameter.(type) {
 	case GlobalAllocationParam:
@@ -33,6 +41,23 @@ func (cpe ChangeParamEvent) Execute(ctx sdk.Context, ph ParamHolder) sdk.Error {
 		return ph.setAccountParam(ctx, &parameter)
 	case PostParam:
 		return ph.setPostParam(ctx, &parameter)
+	case CoinDayParam:
+		return ph.setCoinDayParam(ctx, &parameter)
+	case ReputationParam:
+		return ph.setReputationParam(ctx, &parameter)
+	case ParamFieldsPatch:
+		current, err := ph.GetParam(ctx, parameter.ParamName)
+		if err != nil {
+			return err
+		}
+		patched, err := ApplyParamFields(current, parameter.Fields)
+		if err == nil {
+			err = validate(patched)
+		}
+		if err != nil {
+			return ErrParamPatchRejected(parameter.ParamName, err)
+		}
+		return ChangeParamEvent{Param: patched, ProposalID: cpe.ProposalID}.Execute(ctx, ph, validate)
 	default:
 		return ErrInvalidaParameter()
 	}
@@ -0,0 +1,225 @@
+package param
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lino-network/lino/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// parameter names used by field level parameter change
+const (
+	EvaluateOfContentValueParamName  = "evaluate_of_content_value_param"
+	GlobalAllocationParamName        = "global_allocation_param"
+	InfraInternalAllocationParamName = "infra_internal_allocation_param"
+	VoteParamName                    = "vote_param"
+	ProposalParamName                = "proposal_param"
+	DeveloperParamName               = "developer_param"
+	ValidatorParamName               = "validator_param"
+	CoinDayParamName                 = "coin_day_param"
+	BandwidthParamName               = "bandwidth_param"
+	AccountParamName                 = "account_param"
+	PostParamName                    = "post_param"
+	ReputationParamName              = "reputation_param"
+)
+
+var (
+	coinType = reflect.TypeOf(types.Coin{})
+	ratType  = reflect.TypeOf(sdk.Rat{})
+)
+
+// ParamField - new value of a single parameter field, keyed by json name
+type ParamField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParamFieldsPatch - partial change on a parameter, only listed fields are changed.
+// Coin fields take LNO decimal string, rat fields take decimal string.
+type ParamFieldsPatch struct {
+	ParamName string       `json:"param_name"`
+	Fields    []ParamField `json:"fields"`
+}
+
+// NewParamFieldsPatch - create a patch from field map, fields are sorted by name
+func NewParamFieldsPatch(paramName string, fields map[string]string) ParamFieldsPatch {
+	patch := ParamFieldsPatch{ParamName: paramName, Fields: []ParamField{}}
+	for name, value := range fields {
+		patch.Fields = append(patch.Fields, ParamField{Name: name, Value: value})
+	}
+	sort.Slice(patch.Fields, func(i, j int) bool {
+		return patch.Fields[i].Name < patch.Fields[j].Name
+	})
+	return patch
+}
+
+// GetEmptyParam - get zero value parameter with given name
+func GetEmptyParam(paramName string) (Parameter, sdk.Error) {
+	switch paramName {
+	case EvaluateOfContentValueParamName:
+		return EvaluateOfContentValueParam{}, nil
+	case GlobalAllocationParamName:
+		return GlobalAllocationParam{}, nil
+	case InfraInternalAllocationParamName:
+		return InfraInternalAllocationParam{}, nil
+	case VoteParamName:
+		return VoteParam{}, nil
+	case ProposalParamName:
+		return ProposalParam{}, nil
+	case DeveloperParamName:
+		return DeveloperParam{}, nil
+	case ValidatorParamName:
+		return ValidatorParam{}, nil
+	case CoinDayParamName:
+		return CoinDayParam{}, nil
+	case BandwidthParamName:
+		return BandwidthParam{}, nil
+	case AccountParamName:
+		return AccountParam{}, nil
+	case PostParamName:
+		return PostParam{}, nil
+	case ReputationParamName:
+		return ReputationParam{}, nil
+	default:
+		return nil, ErrParamNameNotFound(paramName)
+	}
+}
+
+// GetParam - get current parameter with given name
+func (ph ParamHolder) GetParam(ctx sdk.Context, paramName string) (Parameter, sdk.Error) {
+	switch paramName {
+	case EvaluateOfContentValueParamName:
+		p, err := ph.GetEvaluateOfContentValueParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case GlobalAllocationParamName:
+		p, err := ph.GetGlobalAllocationParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case InfraInternalAllocationParamName:
+		p, err := ph.GetInfraInternalAllocationParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case VoteParamName:
+		p, err := ph.GetVoteParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case ProposalParamName:
+		p, err := ph.GetProposalParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case DeveloperParamName:
+		p, err := ph.GetDeveloperParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case ValidatorParamName:
+		p, err := ph.GetValidatorParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case CoinDayParamName:
+		p, err := ph.GetCoinDayParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case BandwidthParamName:
+		p, err := ph.GetBandwidthParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case AccountParamName:
+		p, err := ph.GetAccountParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case PostParamName:
+		p, err := ph.GetPostParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	case ReputationParamName:
+		p, err := ph.GetReputationParam(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return *p, nil
+	default:
+		return nil, ErrParamNameNotFound(paramName)
+	}
+}
+
+// ApplyParamFields - return a copy of parameter with given fields changed
+func ApplyParamFields(parameter Parameter, fields []ParamField) (Parameter, sdk.Error) {
+	if parameter == nil || reflect.TypeOf(parameter).Kind() != reflect.Struct {
+		return nil, ErrInvalidaParameter()
+	}
+	patched := reflect.New(reflect.TypeOf(parameter)).Elem()
+	patched.Set(reflect.ValueOf(parameter))
+	for _, field := range fields {
+		fieldValue, found := getFieldByJSONName(patched, field.Name)
+		if !found {
+			return nil, ErrParamFieldNotFound(field.Name)
+		}
+		if err := setFieldValue(fieldValue, field); err != nil {
+			return nil, err
+		}
+	}
+	return patched.Interface(), nil
+}
+
+func getFieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func setFieldValue(v reflect.Value, field ParamField) sdk.Error {
+	switch {
+	case v.Type() == coinType:
+		rat, err := sdk.NewRatFromDecimal(field.Value, types.NewRatFromDecimalPrecision)
+		if err != nil || rat.LT(sdk.ZeroRat()) || rat.GT(types.UpperBoundRat) {
+			return ErrInvalidParamFieldValue(field.Name, field.Value)
+		}
+		v.Set(reflect.ValueOf(types.RatToCoin(rat.Mul(sdk.NewRat(types.Decimals, 1)))))
+	case v.Type() == ratType:
+		rat, err := sdk.NewRatFromDecimal(field.Value, types.NewRatFromDecimalPrecision)
+		if err != nil {
+			return ErrInvalidParamFieldValue(field.Name, field.Value)
+		}
+		v.Set(reflect.ValueOf(rat))
+	case v.Kind() == reflect.Int64 || v.Kind() == reflect.Int:
+		i, err := strconv.ParseInt(field.Value, 10, v.Type().Bits())
+		if err != nil {
+			return ErrInvalidParamFieldValue(field.Name, field.Value)
+		}
+		v.SetInt(i)
+	default:
+		return ErrInvalidParamFieldValue(field.Name, field.Value)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package param
+
+import (
+	"testing"
+
+	"github.com/lino-network/lino/types"
+	"github.com/stretchr/testify/assert"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestApplyParamFields(t *testing.T) {
+	proposalParam := ProposalParam{
+		ChangeParamDecideSec:  int64(7 * 24 * 3600),
+		ChangeParamMinDeposit: types.NewCoinFromInt64(100000 * types.Decimals),
+		ChangeParamPassRatio:  sdk.NewRat(70, 100),
+	}
+
+	testCases := []struct {
+		testName    string
+		parameter   Parameter
+		fields      []ParamField
+		expectParam Parameter
+		expectErr   sdk.Error
+	}{
+		{
+			testName:  "change int64 field",
+			parameter: proposalParam,
+			fields:    []ParamField{{Name: "change_param_decide_second", Value: "100"}},
+			expectParam: ProposalParam{
+				ChangeParamDecideSec:  100,
+				ChangeParamMinDeposit: types.NewCoinFromInt64(100000 * types.Decimals),
+				ChangeParamPassRatio:  sdk.NewRat(70, 100),
+			},
+			expectErr: nil,
+		},
+		{
+			testName:  "change coin and rat fields",
+			parameter: proposalParam,
+			fields: []ParamField{
+				{Name: "change_param_min_deposit", Value: "0.5"},
+				{Name: "change_param_pass_ratio", Value: "0.8"},
+			},
+			expectParam: ProposalParam{
+				ChangeParamDecideSec:  int64(7 * 24 * 3600),
+				ChangeParamMinDeposit: types.NewCoinFromInt64(types.Decimals / 2),
+				ChangeParamPassRatio:  sdk.NewRat(8, 10),
+			},
+			expectErr: nil,
+		},
+		{
+			testName:    "change int field",
+			parameter:   ReputationParam{BestContentIndexN: 10},
+			fields:      []ParamField{{Name: "best_content_index_n", Value: "20"}},
+			expectParam: ReputationParam{BestContentIndexN: 20},
+			expectErr:   nil,
+		},
+		{
+			testName:    "unknown field",
+			parameter:   proposalParam,
+			fields:      []ParamField{{Name: "ChangeParamDecideSec", Value: "100"}},
+			expectParam: nil,
+			expectErr:   ErrParamFieldNotFound("ChangeParamDecideSec"),
+		},
+		{
+			testName:    "invalid int value",
+			parameter:   proposalParam,
+			fields:      []ParamField{{Name: "change_param_decide_second", Value: "a"}},
+			expectParam: nil,
+			expectErr:   ErrInvalidParamFieldValue("change_param_decide_second", "a"),
+		},
+		{
+			testName:    "invalid rat value",
+			parameter:   proposalParam,
+			fields:      []ParamField{{Name: "change_param_pass_ratio", Value: "0.123456"}},
+			expectParam: nil,
+			expectErr:   ErrInvalidParamFieldValue("change_param_pass_ratio", "0.123456"),
+		},
+		{
+			testName:    "negative coin value",
+			parameter:   proposalParam,
+			fields:      []ParamField{{Name: "change_param_min_deposit", Value: "-1"}},
+			expectParam: nil,
+			expectErr:   ErrInvalidParamFieldValue("change_param_min_deposit", "-1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		patched, err := ApplyParamFields(tc.parameter, tc.fields)
+		if !assert.Equal(t, tc.expectErr, err) {
+			t.Errorf("%s: diff err, got %v, want %v", tc.testName, err, tc.expectErr)
+		}
+		if !assert.Equal(t, tc.expectParam, patched) {
+			t.Errorf("%s: diff param, got %v, want %v", tc.testName, patched, tc.expectParam)
+		}
+	}
+	// original parameter should not be changed
+	assert.Equal(t, sdk.NewRat(70, 100), proposalParam.ChangeParamPassRatio)
+	assert.Equal(t, int64(7*24*3600), proposalParam.ChangeParamDecideSec)
+}
+
+func TestChangeParamFieldsEvent(t *testing.T) {
+	ph := NewParamHolder(TestKVStoreKey)
+	ctx := getContext()
+	err := ph.InitParam(ctx)
+	assert.Nil(t, err)
+
+	postParam, err := ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+
+	acceptAll := func(parameter Parameter) sdk.Error { return nil }
+	patch := NewParamFieldsPatch(PostParamName, map[string]string{"post_interval_sec": "300"})
+	err = ChangeParamEvent{Param: patch}.Execute(ctx, ph, acceptAll)
+	assert.Nil(t, err)
+
+	expectParam := *postParam
+	expectParam.PostIntervalSec = 300
+	postParam, err = ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, expectParam, *postParam)
+
+	// patch on top of other change should keep the other change
+	expectParam.ReportOrUpvoteIntervalSec = 100
+	err = ChangeParamEvent{Param: expectParam}.Execute(ctx, ph, acceptAll)
+	assert.Nil(t, err)
+	patch = NewParamFieldsPatch(PostParamName, map[string]string{"max_report_reputation": "1"})
+	err = ChangeParamEvent{Param: patch}.Execute(ctx, ph, acceptAll)
+	assert.Nil(t, err)
+	expectParam.MaxReportReputation = types.NewCoinFromInt64(types.Decimals)
+	postParam, err = ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, expectParam, *postParam)
+
+	// patch fails validation against latest parameter is rejected without change
+	rejectAll := func(parameter Parameter) sdk.Error { return ErrInvalidaParameter() }
+	patch = NewParamFieldsPatch(PostParamName, map[string]string{"post_interval_sec": "600"})
+	err = ChangeParamEvent{Param: patch}.Execute(ctx, ph, rejectAll)
+	assert.Equal(t, ErrParamPatchRejected(PostParamName, ErrInvalidaParameter()), err)
+	postParam, err = ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, expectParam, *postParam)
+
+	patch = NewParamFieldsPatch("unknown_param", map[string]string{"post_interval_sec": "300"})
+	err = ChangeParamEvent{Param: patch}.Execute(ctx, ph, acceptAll)
+	assert.Equal(t, ErrParamNameNotFound("unknown_param"), err)
+}
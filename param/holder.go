@@ -69,6 +69,7 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		ReportOrUpvoteIntervalSec: 24 * 3600,
 		PostIntervalSec:           600,
 		MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
+		MaxNumOfRevisions:         10,
 	}
 	if err := ph.setPostParam(ctx, postParam); err != nil {
 		return err
@@ -138,6 +139,11 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalPassRatio:  sdk.NewRat(50, 100),
+		TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 	}
 	if err := ph.setProposalParam(ctx, proposalParam); err != nil {
 		return err
@@ -164,6 +170,10 @@ func (ph ParamHolder) InitParam(ctx sdk.Context) error {
 		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
 		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
 		MaxNumFrozenMoney:            10,
+		ReferralBonus:                types.NewCoinFromInt64(0),
+		ReferralRewardRate:           sdk.NewRat(5, 100),
+		ReferralRewardPeriodSec:      90 * 24 * 3600,
+		SocialRecoveryDelaySec:       3 * 24 * 3600,
 	}
 	if err := ph.setAccountParam(ctx, accountParam); err != nil {
 		return err
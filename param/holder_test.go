@@ -154,6 +154,11 @@ func TestProposalParam(t *testing.T) {
 		ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalPassRatio:  sdk.NewRat(50, 100),
+		TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 	}
 	err := ph.setProposalParam(ctx, &parameter)
 	assert.Nil(t, err)
@@ -201,6 +206,10 @@ func TestAccountParam(t *testing.T) {
 		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
 		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
 		MaxNumFrozenMoney:            10,
+		ReferralBonus:                types.NewCoinFromInt64(0),
+		ReferralRewardRate:           sdk.NewRat(5, 100),
+		ReferralRewardPeriodSec:      90 * 24 * 3600,
+		SocialRecoveryDelaySec:       3 * 24 * 3600,
 	}
 	err := ph.setAccountParam(ctx, &parameter)
 	assert.Nil(t, err)
@@ -280,6 +289,11 @@ func TestInitParam(t *testing.T) {
 		ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalPassRatio:  sdk.NewRat(50, 100),
+		TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 	}
 
 	coinDayParam := CoinDayParam{
@@ -295,11 +309,16 @@ func TestInitParam(t *testing.T) {
 		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
 		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
 		MaxNumFrozenMoney:            10,
+		ReferralBonus:                types.NewCoinFromInt64(0),
+		ReferralRewardRate:           sdk.NewRat(5, 100),
+		ReferralRewardPeriodSec:      90 * 24 * 3600,
+		SocialRecoveryDelaySec:       3 * 24 * 3600,
 	}
 	postParam := PostParam{
 		ReportOrUpvoteIntervalSec: int64(24 * 3600),
 		PostIntervalSec:           int64(600),
 		MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
+		MaxNumOfRevisions:         10,
 	}
 	checkStorage(t, ctx, ph, globalAllocationParam, infraInternalAllocationParam,
 		evaluateOfContentValueParam, developerParam, validatorParam, voteParam,
@@ -373,6 +392,11 @@ func TestInitParamFromConfig(t *testing.T) {
 		ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalPassRatio:  sdk.NewRat(50, 100),
+		TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 	}
 
 	coinDayParam := CoinDayParam{
@@ -388,11 +412,16 @@ func TestInitParamFromConfig(t *testing.T) {
 		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
 		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
 		MaxNumFrozenMoney:            10,
+		ReferralBonus:                types.NewCoinFromInt64(0),
+		ReferralRewardRate:           sdk.NewRat(5, 100),
+		ReferralRewardPeriodSec:      90 * 24 * 3600,
+		SocialRecoveryDelaySec:       3 * 24 * 3600,
 	}
 	postParam := PostParam{
 		ReportOrUpvoteIntervalSec: int64(24 * 3600),
 		PostIntervalSec:           int64(600),
 		MaxReportReputation:       types.NewCoinFromInt64(100 * types.Decimals),
+		MaxNumOfRevisions:         10,
 	}
 	repParam := ReputationParam{
 		BestContentIndexN: 10,
@@ -509,3 +538,28 @@ func TestUpdateGlobalGrowthRate(t *testing.T) {
 		assert.Equal(t, globalParam.GlobalGrowthRate, tc.expectGrowthRate)
 	}
 }
+
+func TestChangeParamEvent(t *testing.T) {
+	ph := NewParamHolder(TestKVStoreKey)
+	ctx := getContext()
+	err := ph.InitParam(ctx)
+	assert.Nil(t, err)
+
+	coinDayParam := CoinDayParam{
+		SecondsToRecoverCoinDay: int64(24 * 3600),
+	}
+	err = ChangeParamEvent{Param: coinDayParam}.Execute(ctx, ph, nil)
+	assert.Nil(t, err)
+	coinDayParamPtr, err := ph.GetCoinDayParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, coinDayParam, *coinDayParamPtr, "Coin day param should be equal")
+
+	reputationParam := ReputationParam{
+		BestContentIndexN: 20,
+	}
+	err = ChangeParamEvent{Param: reputationParam}.Execute(ctx, ph, nil)
+	assert.Nil(t, err)
+	reputationParamPtr, err := ph.GetReputationParam(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, reputationParam, *reputationParamPtr, "Reputation param should be equal")
+}
@@ -68,6 +68,10 @@ type VoteParam struct {
 // ProtocolUpgradeMinDeposit - minimum deposit to propose protocol upgrade proposal
 // ProtocolUpgradePassRatio - upvote and downvote ratio for protocol upgrade proposal
 // ProtocolUpgradePassVotes - minimum voting power required to pass protocol upgrade proposal
+// TextProposalDecideSec - seconds after text proposal created till expired
+// TextProposalMinDeposit - minimum deposit to propose text proposal
+// TextProposalPassRatio - upvote and downvote ratio for text proposal
+// TextProposalPassVotes - minimum voting power required to pass text proposal
 type ProposalParam struct {
 	ContentCensorshipDecideSec  int64      `json:"content_censorship_decide_second"`
 	ContentCensorshipMinDeposit types.Coin `json:"content_censorship_min_deposit"`
@@ -82,6 +86,10 @@ type ProposalParam struct {
 	ProtocolUpgradeMinDeposit   types.Coin `json:"protocol_upgrade_min_deposit"`
 	ProtocolUpgradePassRatio    sdk.Rat    `json:"protocol_upgrade_pass_ratio"`
 	ProtocolUpgradePassVotes    types.Coin `json:"protocol_upgrade_pass_votes"`
+	TextProposalDecideSec       int64      `json:"text_proposal_decide_second"`
+	TextProposalMinDeposit      types.Coin `json:"text_proposal_min_deposit"`
+	TextProposalPassRatio       sdk.Rat    `json:"text_proposal_pass_ratio"`
+	TextProposalPassVotes       types.Coin `json:"text_proposal_pass_votes"`
 }
 
 // DeveloperParam - developer parameters
@@ -140,20 +148,30 @@ type BandwidthParam struct {
 // RegisterFee - register fee need to pay to developer inflation pool for each account registration
 // FirstDepositFullCoinDayLimit - when register account, some of coin day of register fee to newly open account will be fully charged
 // MaxNumFrozenMoney - the upper limit for each person's ongoing frozen money
+// ReferralBonus - flat bonus paid to referrer from developer inflation pool for each registration, capped by register fee
+// ReferralRewardRate - share of referee's content reward paid to referrer
+// ReferralRewardPeriodSec - referrer gets the share of content reward within this period after registration
+// SocialRecoveryDelaySec - keys approved by guardians take effect after this delay, the owner can cancel in between
 type AccountParam struct {
 	MinimumBalance               types.Coin `json:"minimum_balance"`
 	RegisterFee                  types.Coin `json:"register_fee"`
 	FirstDepositFullCoinDayLimit types.Coin `json:"first_deposit_full_coin_day_limit"`
 	MaxNumFrozenMoney            int64      `json:"max_num_frozen_money"`
+	ReferralBonus                types.Coin `json:"referral_bonus"`
+	ReferralRewardRate           sdk.Rat    `json:"referral_reward_rate"`
+	ReferralRewardPeriodSec      int64      `json:"referral_reward_period_second"`
+	SocialRecoveryDelaySec       int64      `json:"social_recovery_delay_second"`
 }
 
 // PostParam - post parameters
 // ReportOrUpvoteIntervalSec - report interval second
 // PostIntervalSec - post interval second
+// MaxNumOfRevisions - the upper limit of revisions stored for each post
 type PostParam struct {
 	ReportOrUpvoteIntervalSec int64      `json:"report_or_upvote_interval_second"`
 	PostIntervalSec           int64      `json:"post_interval_sec"`
 	MaxReportReputation       types.Coin `json:"max_report_reputation"`
+	MaxNumOfRevisions         int64      `json:"max_num_of_revisions"`
 }
 
 // BestContentIndexN - hard cap of how many content can be indexed every round.
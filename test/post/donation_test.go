@@ -48,5 +48,6 @@ func TestNormalDonation(t *testing.T) {
 	test.CheckBalance(t, newPostUser, lb, types.NewCoinFromInt64(9900000+4750000))
 	test.SignCheckDeliver(
 		t, lb, claimMsg, 2, true, newPostUserTransactionPriv, baseTime+test.ConsumptionFreezingPeriodSec+1)
-	test.CheckBalance(t, newPostUser, lb, types.NewCoinFromInt64(614051839181))
+	// referrer of the poster gets 5% of the reward
+	test.CheckBalance(t, newPostUser, lb, types.NewCoinFromInt64(583349979722))
 }
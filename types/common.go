@@ -21,6 +21,21 @@ type ProposalResult int
 // indicates proposal type
 type ProposalType int
 
+// indicates who is allowed to reply a post
+type ReplyPermission int
+
+// indicates how a post is paywalled
+type PaywallType int
+
+// indicates the reason of a report
+type ReportReason int
+
+// indicates how off-chain content is referenced
+type ContentRefType int
+
+// indicates number of days covered by a post stat bucket
+type StatPeriod int64
+
 // indicates donation type
 type DonationType int
 
@@ -41,6 +56,21 @@ type IDToURLMapping struct {
 	URL        string `json:"url"`
 }
 
+// Beneficiary - account shares donations of a post by weight, weight is a decimal string
+type Beneficiary struct {
+	Username AccountKey `json:"username"`
+	Weight   string     `json:"weight"`
+}
+
+// ContentReference - typed reference to off-chain content of a post, Ref is
+// IPFS CID or hex encoded sha256 digest depends on RefType
+type ContentReference struct {
+	RefType  ContentRefType `json:"ref_type"`
+	Ref      string         `json:"ref"`
+	Size     int64          `json:"size"`
+	MimeType string         `json:"mime_type"`
+}
+
 // PenaltyList - get validator who doesn't vote for proposal
 type PenaltyList struct {
 	PenaltyList []AccountKey `json:"penalty_list"`
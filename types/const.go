@@ -26,6 +26,18 @@ const (
 	DeveloperRouterName = "developer"
 	ProposalRouterName  = "proposal"
 
+	// SimulateQueryPath - abci query path to dry run a tx without commit
+	SimulateQueryPath = "/app/simulate"
+	// GrantedAppsQueryPath - abci query path to list unexpired grants of the username in query data
+	GrantedAppsQueryPath = "/app/grantedApps"
+	// PreAuthAllowanceQueryPath - abci query path to get preauth allowance in current period,
+	// query data is "username" + separator + "app"
+	PreAuthAllowanceQueryPath = "/app/preAuthAllowance"
+	// TaggedPostsQueryPath - abci query path to get a page of posts with a tag at latest block
+	TaggedPostsQueryPath = "/app/taggedPosts"
+	// TrendingTagsQueryPath - abci query path to get tags received most donations in recent days
+	TrendingTagsQueryPath = "/app/trendingTags"
+
 	// Different permission level for msg
 	UnknownPermission          = Permission(0)
 	AppPermission              = Permission(1)
@@ -43,6 +55,35 @@ const (
 	ChangeParam       = ProposalType(0)
 	ContentCensorship = ProposalType(1)
 	ProtocolUpgrade   = ProposalType(2)
+	TextProposal      = ProposalType(3)
+	ContentRestore    = ProposalType(4)
+
+	// Different reply permissions of a post
+	ReplyFromAll      = ReplyPermission(0)
+	ReplyFromFollower = ReplyPermission(1)
+	ReplyFromNobody   = ReplyPermission(2)
+
+	// Different paywall types of a post
+	NoPaywall     = PaywallType(0)
+	OneTimeUnlock = PaywallType(1)
+	PayPerView    = PaywallType(2)
+
+	// Different report reasons, unspecified is used by upvote and report without reason
+	UnspecifiedReport = ReportReason(0)
+	SpamReport        = ReportReason(1)
+	CopyrightReport   = ReportReason(2)
+	AbuseReport       = ReportReason(3)
+	IllegalReport     = ReportReason(4)
+
+	// Different content reference types
+	IPFSContentRef   = ContentRefType(0)
+	SHA256ContentRef = ContentRefType(1)
+
+	// Different post stat bucket periods, a month is 4 weeks
+	// so weekly buckets are never split when compacted
+	DailyStat   = StatPeriod(1)
+	WeeklyStat  = StatPeriod(7)
+	MonthlyStat = StatPeriod(28)
 
 	// Different donation types
 	DirectDeposit = DonationType(0)
@@ -63,6 +104,11 @@ const (
 	ProposalReturnCoin   = TransferDetailType(11)
 	GenesisCoin          = TransferDetailType(12)
 	ClaimInterest        = TransferDetailType(13)
+	DonationRefund       = TransferDetailType(14)
+	ReferralReward       = TransferDetailType(15)
+	SocialRecovery       = TransferDetailType(16)
+	UsernameSaleIn       = TransferDetailType(17)
+	AccountCloseIn       = TransferDetailType(18)
 
 	// Different possible outcomes
 	TransferOut      = TransferDetailType(20)
@@ -73,6 +119,8 @@ const (
 	DeveloperDeposit = TransferDetailType(25)
 	InfraDeposit     = TransferDetailType(26)
 	ProposalDeposit  = TransferDetailType(27)
+	UsernameSaleOut  = TransferDetailType(28)
+	AccountCloseOut  = TransferDetailType(29)
 
 	// punishment type
 	UnknownPunish      = PunishType(0)
@@ -117,6 +165,9 @@ const (
 	// MinutesPerDay - as defined by a julian year of 365.25 days
 	MinutesPerDay = 60 * 24
 
+	// SecondsPerDay - number of seconds in a day
+	SecondsPerDay = MinutesPerDay * 60
+
 	// PrecisionFactor - all decimals will around to allow at most 7 decimals
 	PrecisionFactor = 10000000
 
@@ -138,6 +189,66 @@ const (
 	// MaximumNumOfLinks - maximum number of links per post
 	MaximumNumOfLinks = 10
 
+	// MaximumNumOfTags - maximum number of tags per post
+	MaximumNumOfTags = 5
+
+	// MaximumLengthOfTag - maximum length of post tag
+	MaximumLengthOfTag = 32
+
+	// MaximumNumOfBeneficiaries - maximum number of beneficiaries per post
+	MaximumNumOfBeneficiaries = 10
+
+	// MaximumNumOfDonationsInBatch - maximum number of donations in a batch donate msg
+	MaximumNumOfDonationsInBatch = 20
+
+	// MaximumNumOfContentRefs - maximum number of content references per post
+	MaximumNumOfContentRefs = 10
+
+	// MaximumLengthOfContentRef - maximum length of content reference
+	MaximumLengthOfContentRef = 100
+
+	// MaximumLengthOfMimeType - maximum length of content reference MIME type
+	MaximumLengthOfMimeType = 100
+
+	// DailyStatRetentionDays - daily post stat buckets older than this are compacted into weekly buckets
+	DailyStatRetentionDays = 28
+
+	// WeeklyStatRetentionDays - weekly post stat buckets older than this are compacted into monthly buckets
+	WeeklyStatRetentionDays = 84
+
+	// MonthlyStatRetentionDays - monthly post stat buckets older than this are removed
+	MonthlyStatRetentionDays = 364
+
+	// TagDonationStatRetentionDays - daily tag donation statistics older than this are removed
+	TagDonationStatRetentionDays = 28
+
+	// MaximumNumOfGrantScopes - maximum number of msg types an app permission grant can be scoped to
+	MaximumNumOfGrantScopes = 20
+
+	// MaximumLengthOfGrantScope - maximum length of msg type in grant scopes
+	MaximumLengthOfGrantScope = 50
+
+	// MaximumNumOfGuardians - maximum number of guardians an account can designate
+	MaximumNumOfGuardians = 10
+
+	// MinimumSocialRecoveryDelaySec - owner has at least this long to cancel a recovery approved by guardians
+	MinimumSocialRecoveryDelaySec = 24 * 3600
+
+	// MaximumLengthOfDisplayName - maximum length of account profile display name
+	MaximumLengthOfDisplayName = 50
+
+	// MaximumLengthOfAvatar - maximum length of account profile avatar reference
+	MaximumLengthOfAvatar = 200
+
+	// MaximumLengthOfBio - maximum length of account profile bio
+	MaximumLengthOfBio = 500
+
+	// MaximumLengthOfProfileWebsite - maximum length of account profile website
+	MaximumLengthOfProfileWebsite = 100
+
+	// MaximumNumOfVerifiedLinks - maximum number of verified links in account profile
+	MaximumNumOfVerifiedLinks = 10
+
 	// MaximumLengthOfDeveloperWebsite - maximum length of developer website
 	MaximumLengthOfDeveloperWebsite = 100
 
@@ -150,6 +261,12 @@ const (
 	// MaximumLengthOfProposalReason - maximum length of proposal reason
 	MaximumLengthOfProposalReason = 1000
 
+	// MaximumLengthOfProposalTitle - maximum length of text proposal title
+	MaximumLengthOfProposalTitle = 100
+
+	// MaximumLengthOfProposalDescription - maximum length of text proposal description
+	MaximumLengthOfProposalDescription = 10000
+
 	// InitAccountWithFullCoinDayMemo - init account with full coin day memo
 	InitAccountWithFullCoinDayMemo = "open account deposit"
 
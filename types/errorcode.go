@@ -30,7 +30,9 @@ const (
 	CodeUnverifiedBytes      sdk.CodeType = 155
 
 	// ABCI Response Codes
-	CodeGenesisFailed sdk.CodeType = 200
+	CodeGenesisFailed    sdk.CodeType = 200
+	CodeSimulateNotReady sdk.CodeType = 201
+	CodeSimulateTxPanic  sdk.CodeType = 202
 
 	// // Lino register handler errors reserve 300 ~ 309.
 	// CodeAccRegisterFailed sdk.CodeType = 302
@@ -100,6 +102,36 @@ const (
 	CodeGetLastPostAt                        sdk.CodeType = 360
 	CodeUpdateLastPostAt                     sdk.CodeType = 361
 	CodeFrozenMoneyListTooLong               sdk.CodeType = 362
+	CodeGrantScopeMismatch                   sdk.CodeType = 363
+	CodeFailedToMarshalReferral              sdk.CodeType = 364
+	CodeFailedToUnmarshalReferral            sdk.CodeType = 365
+	CodeFailedToMarshalGuardians             sdk.CodeType = 366
+	CodeFailedToUnmarshalGuardians           sdk.CodeType = 367
+	CodeFailedToMarshalRecoveryRequest       sdk.CodeType = 368
+	CodeFailedToUnmarshalRecoveryRequest     sdk.CodeType = 369
+	CodeInvalidGuardians                     sdk.CodeType = 370
+	CodeNotGuardian                          sdk.CodeType = 371
+	CodeRecoveryRequestNotFound              sdk.CodeType = 372
+	CodeRecoveryInProgress                   sdk.CodeType = 373
+	CodeRecoveryAlreadyApproved              sdk.CodeType = 374
+	CodeInvalidNewKey                        sdk.CodeType = 375
+	CodeFailedToMarshalUsernameListing       sdk.CodeType = 376
+	CodeFailedToUnmarshalUsernameListing     sdk.CodeType = 377
+	CodeUsernameListingNotFound              sdk.CodeType = 378
+	CodeUsernameSaleBlocked                  sdk.CodeType = 379
+	CodeUsernameListingPriceMismatch         sdk.CodeType = 380
+	CodeInvalidUsernameListing               sdk.CodeType = 381
+	CodeFailedToUnmarshalFollowerMeta        sdk.CodeType = 382
+	CodeFailedToUnmarshalFollowingMeta       sdk.CodeType = 383
+	CodeInvalidProfile                       sdk.CodeType = 384
+	CodeFailedToMarshalProfile               sdk.CodeType = 385
+	CodeFailedToUnmarshalProfile             sdk.CodeType = 386
+	CodeUsernameRetired                      sdk.CodeType = 387
+	CodeAccountCloseBlocked                  sdk.CodeType = 388
+	CodeInvalidAccountClose                  sdk.CodeType = 389
+	CodeFailedToMarshalRetiredAccount        sdk.CodeType = 390
+	CodeFailedToUnmarshalRetiredAccount      sdk.CodeType = 391
+	CodeGrantPubKeyConflict                  sdk.CodeType = 392
 
 	// Lino post errors reserve 400 ~ 499
 	CodePostMetaNotFound                     sdk.CodeType = 400
@@ -143,6 +175,54 @@ const (
 	CodeCreatePostSourceInvalid              sdk.CodeType = 438
 	CodeGetSourcePost                        sdk.CodeType = 439
 	CodePostTooOften                         sdk.CodeType = 440
+	CodeCensoredPostNotFound                 sdk.CodeType = 441
+	CodeFailedToMarshalCensoredPost          sdk.CodeType = 442
+	CodeFailedToUnmarshalCensoredPost        sdk.CodeType = 443
+	CodeFailedToMarshalBlockedReplier        sdk.CodeType = 444
+	CodeInvalidReplyPermission               sdk.CodeType = 445
+	CodeReplyNotAllowed                      sdk.CodeType = 446
+	CodeCannotBlockSelf                      sdk.CodeType = 447
+	CodePostRevisionNotFound                 sdk.CodeType = 448
+	CodeFailedToMarshalPostRevision          sdk.CodeType = 449
+	CodeFailedToUnmarshalPostRevision        sdk.CodeType = 450
+	CodeTooManyTags                          sdk.CodeType = 451
+	CodeInvalidTag                           sdk.CodeType = 452
+	CodeFailedToMarshalTaggedPost            sdk.CodeType = 453
+	CodeFailedToUnmarshalTaggedPost          sdk.CodeType = 454
+	CodeFailedToMarshalTagDonationStat       sdk.CodeType = 455
+	CodeFailedToUnmarshalTagDonationStat     sdk.CodeType = 456
+	CodeTagDonationStatNotFound              sdk.CodeType = 457
+	CodeTooManyBeneficiaries                 sdk.CodeType = 458
+	CodeInvalidBeneficiary                   sdk.CodeType = 459
+	CodeInvalidBeneficiaryWeights            sdk.CodeType = 460
+	CodePostPaywallNotFound                  sdk.CodeType = 461
+	CodeFailedToMarshalPostPaywall           sdk.CodeType = 462
+	CodeFailedToUnmarshalPostPaywall         sdk.CodeType = 463
+	CodePostUnlockNotFound                   sdk.CodeType = 464
+	CodeFailedToMarshalPostUnlock            sdk.CodeType = 465
+	CodeFailedToUnmarshalPostUnlock          sdk.CodeType = 466
+	CodeInvalidPaywall                       sdk.CodeType = 467
+	CodePostNotPaywalled                     sdk.CodeType = 468
+	CodeUnlockPriceMismatch                  sdk.CodeType = 469
+	CodePostAlreadyUnlocked                  sdk.CodeType = 470
+	CodeCannotUnlockOwnPost                  sdk.CodeType = 471
+	CodeInvalidReportReason                  sdk.CodeType = 472
+	CodePostReportStatNotFound               sdk.CodeType = 473
+	CodeFailedToMarshalPostReportStat        sdk.CodeType = 474
+	CodeFailedToUnmarshalPostReportStat      sdk.CodeType = 475
+	CodeTooManyContentRefs                   sdk.CodeType = 476
+	CodeInvalidContentRef                    sdk.CodeType = 477
+	CodeNoDonationInBatch                    sdk.CodeType = 478
+	CodeTooManyDonationsInBatch              sdk.CodeType = 479
+	CodeDuplicateDonationInBatch             sdk.CodeType = 480
+	CodeFailedToMarshalPendingReward         sdk.CodeType = 481
+	CodeFailedToUnmarshalPendingReward       sdk.CodeType = 482
+	CodePostStatNotFound                     sdk.CodeType = 483
+	CodeFailedToMarshalPostStat              sdk.CodeType = 484
+	CodeFailedToUnmarshalPostStat            sdk.CodeType = 485
+	CodeFailedToMarshalStatIndex             sdk.CodeType = 486
+	CodeFailedToUnmarshalStatIndex           sdk.CodeType = 487
+	CodePostDeletedByAuthor                  sdk.CodeType = 488
 
 	// Lino validator errors reserve 500 ~ 599
 	CodeValidatorNotFound              sdk.CodeType = 500
@@ -199,13 +279,18 @@ const (
 	CodeVoteAlreadyExist               sdk.CodeType = 713
 
 	// Lino infra errors reserve 800 ~ 899
-	CodeInfraProviderNotFound              sdk.CodeType = 800
-	CodeInfraProviderListNotFound          sdk.CodeType = 801
-	CodeFailedToMarshalInfraProvider       sdk.CodeType = 802
-	CodeFailedToMarshalInfraProviderList   sdk.CodeType = 803
-	CodeFailedToUnmarshalInfraProvider     sdk.CodeType = 804
-	CodeFailedToUnmarshalInfraProviderList sdk.CodeType = 805
-	CodeInvalidUsage                       sdk.CodeType = 806
+	CodeInfraProviderNotFound               sdk.CodeType = 800
+	CodeInfraProviderListNotFound           sdk.CodeType = 801
+	CodeFailedToMarshalInfraProvider        sdk.CodeType = 802
+	CodeFailedToMarshalInfraProviderList    sdk.CodeType = 803
+	CodeFailedToUnmarshalInfraProvider      sdk.CodeType = 804
+	CodeFailedToUnmarshalInfraProviderList  sdk.CodeType = 805
+	CodeInvalidUsage                        sdk.CodeType = 806
+	CodeContentAttestationNotFound          sdk.CodeType = 807
+	CodeFailedToMarshalContentAttestation   sdk.CodeType = 808
+	CodeFailedToUnmarshalContentAttestation sdk.CodeType = 809
+	CodeContentRefNotFound                  sdk.CodeType = 810
+	CodeInvalidContentAttestation           sdk.CodeType = 811
 
 	// Lino developer errors reserve 900 ~ 999
 	CodeDeveloperListNotFound          sdk.CodeType = 900
@@ -221,6 +306,8 @@ const (
 	CodeInvalidWebsite                 sdk.CodeType = 910
 	CodeInvalidDescription             sdk.CodeType = 911
 	CodeInvalidAppMetadata             sdk.CodeType = 912
+	CodeInvalidGrantScopes             sdk.CodeType = 913
+	CodeInvalidPreAuthPeriod           sdk.CodeType = 914
 
 	// Param errors reserve 1000 ~ 1099
 	CodeParamHolderGenesisError                       sdk.CodeType = 1000
@@ -261,6 +348,10 @@ const (
 	CodeFailedToMarshalReputationParam                sdk.CodeType = 1035
 	CodeFailedToUnmarshalReputationParam              sdk.CodeType = 1036
 	CodeReputationParamNotFound                       sdk.CodeType = 1037
+	CodeParamNameNotFound                             sdk.CodeType = 1038
+	CodeParamFieldNotFound                            sdk.CodeType = 1039
+	CodeInvalidParamFieldValue                        sdk.CodeType = 1040
+	CodeParamPatchRejected                            sdk.CodeType = 1041
 
 	// Proposal errors reserve 1100 ~ 1199
 	CodeOngoingProposalNotFound         sdk.CodeType = 1100
@@ -281,4 +372,9 @@ const (
 	CodeInvalidLink                     sdk.CodeType = 1115
 	CodeIllegalParameter                sdk.CodeType = 1116
 	CodeReasonTooLong                   sdk.CodeType = 1117
+	CodeInvalidProposalTitle            sdk.CodeType = 1118
+	CodeProposalDescriptionTooLong      sdk.CodeType = 1119
+	CodeDiscussionPostNotFound          sdk.CodeType = 1120
+	CodeRestorePostNotCensored          sdk.CodeType = 1121
+	CodeRestorePostIsDeleted            sdk.CodeType = 1122
 )
@@ -2,6 +2,9 @@ package types
 
 // nolint
 import (
+	"reflect"
+	"sync"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 )
@@ -17,3 +20,38 @@ type Msg interface {
 func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterInterface((*Msg)(nil), nil)
 }
+
+// GetMsgType - name of the concrete msg type such as "CreatePostMsg",
+// used to scope the messages an app can sign for a user
+func GetMsgType(msg sdk.Msg) string {
+	return reflect.TypeOf(msg).Name()
+}
+
+// SimulateResult - outcome of running a tx against a cached context without commit
+type SimulateResult struct {
+	Code         sdk.ABCICodeType `json:"code"`
+	Log          string           `json:"log"`
+	Tags         sdk.Tags         `json:"tags"`
+	CapacityCost Coin             `json:"capacity_cost"`
+}
+
+// msgPermissions - permission of msg types registered by modules
+var msgPermissions = map[string]Permission{}
+var msgPermissionsLock sync.RWMutex
+
+// RegisterMsg - register msg on wire codec and record the permission it's signed with
+func RegisterMsg(cdc *wire.Codec, msg Msg, name string) {
+	cdc.RegisterConcrete(msg, name, nil)
+	msgPermissionsLock.Lock()
+	defer msgPermissionsLock.Unlock()
+	msgPermissions[GetMsgType(msg)] = msg.GetPermission()
+}
+
+// IsGrantableMsgType - returns true if msg type is registered and can be signed by a granted key,
+// which is a msg requiring app or preauthorization permission
+func IsGrantableMsgType(msgType string) bool {
+	msgPermissionsLock.RLock()
+	defer msgPermissionsLock.RUnlock()
+	permission, ok := msgPermissions[msgType]
+	return ok && (permission == AppPermission || permission == PreAuthorizationPermission)
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	acc "github.com/lino-network/lino/x/account"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// ChangeAppKeyTxCmd - replace app key with a newly generated key, signed by transaction key
+func ChangeAppKeyTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "change-app-key",
+		Short: "Create a change app key tx",
+		RunE:  sendChangeAppKeyTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	return cmd
+}
+
+// ChangeTransactionKeyTxCmd - replace transaction key with a newly generated key, signed by reset key
+func ChangeTransactionKeyTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "change-transaction-key",
+		Short: "Create a change transaction key tx",
+		RunE:  sendChangeTransactionKeyTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	return cmd
+}
+
+// send change app key transaction to the blockchain
+func sendChangeAppKeyTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		name := viper.GetString(client.FlagUser)
+
+		appPriv := secp256k1.GenPrivKey()
+		fmt.Println("new app private key is:", strings.ToUpper(hex.EncodeToString(appPriv.Bytes())))
+
+		// create the message
+		msg := acc.NewChangeAppKeyMsg(name, appPriv.PubKey())
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
+
+// send change transaction key transaction to the blockchain
+func sendChangeTransactionKeyTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		name := viper.GetString(client.FlagUser)
+
+		transactionPriv := secp256k1.GenPrivKey()
+		fmt.Println("new transaction private key is:", strings.ToUpper(hex.EncodeToString(transactionPriv.Bytes())))
+
+		// create the message
+		msg := acc.NewChangeTransactionKeyMsg(name, transactionPriv.PubKey())
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	acc "github.com/lino-network/lino/x/account"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// CloseAccountTxCmd - close account and sweep remaining balance to receiver
+func CloseAccountTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close-account",
+		Short: "Close account, the username is retired and can't be registered again",
+		RunE:  sendCloseAccountTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "account to close")
+	cmd.Flags().String(client.FlagReceiver, "", "account receives the remaining balance")
+	return cmd
+}
+
+// send close account transaction to the blockchain
+func sendCloseAccountTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := acc.NewCloseAccountMsg(viper.GetString(client.FlagUser), viper.GetString(client.FlagReceiver))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/lino-network/lino/client"
 
 	"github.com/cosmos/cosmos-sdk/wire"
@@ -41,14 +39,7 @@ func sendFollowTx(cdc *wire.Codec) client.CommandTxCallback {
 			msg = acc.NewUnfollowMsg(follower, followee)
 		}
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
@@ -5,12 +5,17 @@ import (
 	"fmt"
 
 	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/client/core"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/account/model"
 
+	acc "github.com/lino-network/lino/x/account"
+	rep "github.com/lino-network/lino/x/reputation"
+
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // GetBankCmd returns a query bank that will display the
@@ -55,6 +60,107 @@ func GetAccountsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 }
 
+// GetGrantCmd returns a query command that will display the permission,
+// expiry and scopes a user granted to an app, for periodic preauth the amount
+// is the allowance remaining in current period
+func GetGrantCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "grant <username> <app>",
+		Short: "Query permission granted to an app",
+		RunE:  cmdr.getGrantCmd,
+	}
+	cmd.Flags().String(client.FlagPermission, "app", "granted permission, app or preauth")
+	return cmd
+}
+
+// GetGrantsCmd returns a query command that will display all unexpired
+// permissions a user granted to apps
+func GetGrantsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "grants <username>",
+		Short: "Query all permissions granted by user",
+		RunE:  cmdr.getGrantsCmd,
+	}
+}
+
+// GetPreAuthAllowanceCmd returns a query command that will display the amount
+// an app can still spend for a user with preauthorization in current period
+func GetPreAuthAllowanceCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "allowance <username> <app>",
+		Short: "Query preauthorization allowance of an app",
+		RunE:  cmdr.getPreAuthAllowanceCmd,
+	}
+}
+
+// GetReferralsCmd returns a query command that will display accounts
+// registered by a referrer and the lifetime reward from each of them
+func GetReferralsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "referrals <username>",
+		Short: "Query accounts registered by referrer",
+		RunE:  cmdr.getReferralsCmd,
+	}
+}
+
+// GetRecoveryCmd - query guardians and pending social recovery of an account
+func GetRecoveryCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "recovery <username>",
+		Short: "Query guardians and pending social recovery",
+		RunE:  cmdr.getRecoveryCmd,
+	}
+}
+
+// GetUsernameListingCmd - query sale listing of a username
+func GetUsernameListingCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "listing <username>",
+		Short: "Query sale listing of a username",
+		RunE:  cmdr.getUsernameListingCmd,
+	}
+}
+
+// GetProfileCmd - query account info, meta, profile, bank summary, reputation
+// and follow counts in one response
+func GetProfileCmd(storeName, reputationStoreName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "profile <username>",
+		Short: "Query account profile with bank summary, reputation and follow counts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdr.getProfileCmd(reputationStoreName, args)
+		},
+	}
+}
+
 type commander struct {
 	storeName string
 	cdc       *wire.Codec
@@ -150,3 +256,280 @@ func (c commander) getAccountsCmd(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+func (c commander) getGrantCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide a username and an app name")
+	}
+
+	res, err := ctx.Query(model.GetAccountInfoKey(types.AccountKey(args[1])), c.storeName)
+	if err != nil {
+		return err
+	}
+	appInfo := new(model.AccountInfo)
+	if err := c.cdc.UnmarshalJSON(res, appInfo); err != nil {
+		return err
+	}
+	// app permission is granted to app key and preauth permission is granted to transaction key
+	grantKey := appInfo.AppKey
+	switch viper.GetString(client.FlagPermission) {
+	case "app":
+	case "preauth":
+		grantKey = appInfo.TransactionKey
+	default:
+		return errors.New("only app and preauth permission are allowed")
+	}
+
+	res, err = ctx.Query(model.GetGrantPubKeyKey(types.AccountKey(args[0]), grantKey), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return errors.Errorf("%s has no permission granted to %s", args[0], args[1])
+	}
+	grantPubKey := new(model.GrantPubKey)
+	if err := c.cdc.UnmarshalJSON(res, grantPubKey); err != nil {
+		return err
+	}
+	// allowance is rolled to the period of latest block time
+	node, err := ctx.GetNode()
+	if err != nil {
+		return err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return err
+	}
+	acc.RollPreAuthPeriod(grantPubKey, status.SyncInfo.LatestBlockTime.Unix())
+	return client.PrintIndent(grantPubKey)
+}
+
+func (c commander) getGrantsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide a username")
+	}
+
+	// grants expired at latest block time are skipped by the app
+	grants := []model.GrantPubKey{}
+	if err := ctx.QueryApp(c.cdc, types.GrantedAppsQueryPath, []byte(args[0]), &grants); err != nil {
+		return err
+	}
+	return client.PrintIndent(grants)
+}
+
+func (c commander) getPreAuthAllowanceCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide a username and an app name")
+	}
+
+	var allowance types.Coin
+	if err := ctx.QueryApp(
+		c.cdc, types.PreAuthAllowanceQueryPath,
+		[]byte(args[0]+types.KeySeparator+args[1]), &allowance); err != nil {
+		return err
+	}
+	return client.PrintIndent(allowance)
+}
+
+func (c commander) getReferralsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide a username")
+	}
+
+	resKVs, err := ctx.QuerySubspace(c.cdc, model.GetReferralPrefix(types.AccountKey(args[0])), c.storeName)
+	if err != nil {
+		return err
+	}
+	referrals := []model.Referral{}
+	for _, KV := range resKVs {
+		var referral model.Referral
+		if err := c.cdc.UnmarshalJSON(KV.Value, &referral); err != nil {
+			return err
+		}
+		referrals = append(referrals, referral)
+	}
+	return client.PrintIndent(referrals)
+}
+
+func (c commander) getRecoveryCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide a username")
+	}
+	username := types.AccountKey(args[0])
+
+	recovery := struct {
+		Guardians *model.Guardians       `json:"guardians"`
+		Request   *model.RecoveryRequest `json:"request"`
+	}{}
+	res, err := ctx.Query(model.GetGuardiansKey(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) != 0 {
+		recovery.Guardians = new(model.Guardians)
+		if err := c.cdc.UnmarshalJSON(res, recovery.Guardians); err != nil {
+			return err
+		}
+	}
+	res, err = ctx.Query(model.GetRecoveryRequestKey(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) != 0 {
+		recovery.Request = new(model.RecoveryRequest)
+		if err := c.cdc.UnmarshalJSON(res, recovery.Request); err != nil {
+			return err
+		}
+	}
+	return client.PrintIndent(recovery)
+}
+
+func (c commander) getUsernameListingCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide a username")
+	}
+
+	res, err := ctx.Query(model.GetUsernameListingKey(types.AccountKey(args[0])), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return fmt.Errorf("username %v isn't for sale", args[0])
+	}
+	listing := new(model.UsernameListing)
+	if err := c.cdc.UnmarshalJSON(res, listing); err != nil {
+		return err
+	}
+	return client.PrintIndent(listing)
+}
+
+// bankSummary - balance overview of an account shown in profile
+type bankSummary struct {
+	Saving      types.Coin `json:"saving"`
+	CoinDay     types.Coin `json:"coin_day"`
+	Frozen      types.Coin `json:"frozen"`
+	NumOfTx     int64      `json:"number_of_transaction"`
+	NumOfReward int64      `json:"number_of_reward"`
+}
+
+// queryReader - reads raw store through ABCI queries, the first error is kept
+// since store readers can't return it
+type queryReader struct {
+	ctx       core.CoreContext
+	storeName string
+	err       error
+}
+
+func (r *queryReader) Get(key []byte) []byte {
+	if r.err != nil {
+		return nil
+	}
+	res, err := r.ctx.Query(key, r.storeName)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+func (c commander) getProfileCmd(reputationStoreName string, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide a username")
+	}
+	username := types.AccountKey(args[0])
+
+	res, err := ctx.Query(model.GetAccountInfoKey(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return fmt.Errorf("account %v doesn't exist", username)
+	}
+	info := new(model.AccountInfo)
+	if err := c.cdc.UnmarshalJSON(res, info); err != nil {
+		return err
+	}
+
+	res, err = ctx.Query(model.GetAccountMetaKey(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	meta := new(model.AccountMeta)
+	if err := c.cdc.UnmarshalJSON(res, meta); err != nil {
+		return err
+	}
+
+	var profile *model.Profile
+	res, err = ctx.Query(model.GetProfileKey(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) != 0 {
+		profile = new(model.Profile)
+		if err := c.cdc.UnmarshalJSON(res, profile); err != nil {
+			return err
+		}
+	}
+
+	res, err = ctx.Query(model.GetAccountBankKey(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	bank := new(model.AccountBank)
+	if err := c.cdc.UnmarshalJSON(res, bank); err != nil {
+		return err
+	}
+	frozen := types.NewCoinFromInt64(0)
+	for _, frozenMoney := range bank.FrozenMoneyList {
+		frozen = frozen.Plus(frozenMoney.Amount)
+	}
+
+	followers, err := ctx.QuerySubspace(c.cdc, model.GetFollowerPrefix(username), c.storeName)
+	if err != nil {
+		return err
+	}
+	followings, err := ctx.QuerySubspace(c.cdc, model.GetFollowingPrefix(username), c.storeName)
+	if err != nil {
+		return err
+	}
+
+	reader := &queryReader{ctx: ctx, storeName: reputationStoreName}
+	reputation := rep.GetReputationFromStore(reader, username)
+	if reader.err != nil {
+		return reader.err
+	}
+
+	return client.PrintIndent(struct {
+		Info           *model.AccountInfo `json:"info"`
+		Meta           *model.AccountMeta `json:"meta"`
+		Profile        *model.Profile     `json:"profile"`
+		Bank           bankSummary        `json:"bank"`
+		Reputation     types.Coin         `json:"reputation"`
+		FollowerCount  int                `json:"follower_count"`
+		FollowingCount int                `json:"following_count"`
+	}{
+		Info:    info,
+		Meta:    meta,
+		Profile: profile,
+		Bank: bankSummary{
+			Saving:      bank.Saving,
+			CoinDay:     bank.CoinDay,
+			Frozen:      frozen,
+			NumOfTx:     bank.NumOfTx,
+			NumOfReward: bank.NumOfReward,
+		},
+		Reputation:     reputation,
+		FollowerCount:  len(followers),
+		FollowingCount: len(followings),
+	})
+}
@@ -43,13 +43,7 @@ func sendRecoverTx(cdc *wire.Codec) client.CommandTxCallback {
 		// create the message
 		msg := acc.NewRecoverMsg(name, resetPriv.PubKey(), transactionPriv.PubKey(), appPriv.PubKey())
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
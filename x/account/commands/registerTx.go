@@ -54,15 +54,8 @@ func sendRegisterTx(cdc *wire.Codec) client.CommandTxCallback {
 			referrer, name, types.LNO(amount),
 			resetPriv.PubKey(), transactionPriv.PubKey(), appPriv.PubKey())
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
 
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"encoding/hex"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	acc "github.com/lino-network/lino/x/account"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+)
+
+// SetGuardiansTxCmd - designate guardians for social recovery
+func SetGuardiansTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-guardians",
+		Short: "Designate guardians who can recover the account",
+		RunE:  sendSetGuardiansTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	cmd.Flags().StringSlice(client.FlagGuardians, nil, "comma separated guardian usernames, empty to disable social recovery")
+	cmd.Flags().Int64(client.FlagThreshold, 0, "number of guardian approvals needed to recover the account")
+	return cmd
+}
+
+// ApproveRecoveryTxCmd - guardian approves new keys of an account
+func ApproveRecoveryTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve-recovery",
+		Short: "Approve new keys of an account as guardian",
+		RunE:  sendApproveRecoveryTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "guardian of this transaction")
+	cmd.Flags().String(client.FlagReceiver, "", "account to recover")
+	cmd.Flags().String(client.FlagResetKey, "", "hex encoded new reset public key")
+	cmd.Flags().String(client.FlagTransactionKey, "", "hex encoded new transaction public key")
+	cmd.Flags().String(client.FlagAppKey, "", "hex encoded new app public key")
+	return cmd
+}
+
+// CancelRecoveryTxCmd - cancel pending social recovery
+func CancelRecoveryTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-recovery",
+		Short: "Cancel pending social recovery of the account",
+		RunE:  sendCancelRecoveryTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	return cmd
+}
+
+// send set guardians transaction to the blockchain
+func sendSetGuardiansTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := acc.NewSetGuardiansMsg(
+			viper.GetString(client.FlagUser), viper.GetStringSlice(client.FlagGuardians),
+			viper.GetInt64(client.FlagThreshold))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
+
+// send approve recovery transaction to the blockchain
+func sendApproveRecoveryTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		resetPubKey, err := pubKeyFromFlag(client.FlagResetKey)
+		if err != nil {
+			return err
+		}
+		transactionPubKey, err := pubKeyFromFlag(client.FlagTransactionKey)
+		if err != nil {
+			return err
+		}
+		appPubKey, err := pubKeyFromFlag(client.FlagAppKey)
+		if err != nil {
+			return err
+		}
+		msg := acc.NewApproveRecoveryMsg(
+			viper.GetString(client.FlagUser), viper.GetString(client.FlagReceiver),
+			resetPubKey, transactionPubKey, appPubKey)
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
+
+// send cancel recovery transaction to the blockchain
+func sendCancelRecoveryTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := acc.NewCancelRecoveryMsg(viper.GetString(client.FlagUser))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
+
+// decode hex encoded public key in flag
+func pubKeyFromFlag(flag string) (crypto.PubKey, error) {
+	pubKeyBytes, err := hex.DecodeString(viper.GetString(flag))
+	if err != nil {
+		return nil, err
+	}
+	return cryptoAmino.PubKeyFromBytes(pubKeyBytes)
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	acc "github.com/lino-network/lino/x/account"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// ListUsernameTxCmd - list username for sale
+func ListUsernameTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-username",
+		Short: "List username for sale",
+		RunE:  sendListUsernameTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "username to sell")
+	cmd.Flags().String(client.FlagPayee, "", "account receives the payment")
+	cmd.Flags().String(client.FlagPrice, "", "price in LNO")
+	cmd.Flags().Bool(client.FlagClearGrants, false, "revoke all app permissions once sold")
+	cmd.Flags().Bool(client.FlagClearFollows, false, "remove all follow relations once sold")
+	return cmd
+}
+
+// CancelUsernameListingTxCmd - take username off sale
+func CancelUsernameListingTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-username-listing",
+		Short: "Take username off sale",
+		RunE:  sendCancelUsernameListingTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	return cmd
+}
+
+// BuyUsernameTxCmd - buy listed username with newly generated keys
+func BuyUsernameTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "buy-username",
+		Short: "Buy listed username",
+		RunE:  sendBuyUsernameTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "buyer of this transaction")
+	cmd.Flags().String(client.FlagUsername, "", "username to buy")
+	cmd.Flags().String(client.FlagPrice, "", "listing price in LNO")
+	return cmd
+}
+
+// send list username transaction to the blockchain
+func sendListUsernameTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := acc.NewListUsernameMsg(
+			viper.GetString(client.FlagUser), viper.GetString(client.FlagPayee),
+			types.LNO(viper.GetString(client.FlagPrice)),
+			viper.GetBool(client.FlagClearGrants), viper.GetBool(client.FlagClearFollows))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
+
+// send cancel username listing transaction to the blockchain
+func sendCancelUsernameListingTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := acc.NewCancelUsernameListingMsg(viper.GetString(client.FlagUser))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
+
+// send buy username transaction to the blockchain
+func sendBuyUsernameTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+
+		resetPriv := secp256k1.GenPrivKey()
+		transactionPriv := secp256k1.GenPrivKey()
+		appPriv := secp256k1.GenPrivKey()
+		fmt.Println("new reset private key is:", strings.ToUpper(hex.EncodeToString(resetPriv.Bytes())))
+		fmt.Println("new transaction private key is:", strings.ToUpper(hex.EncodeToString(transactionPriv.Bytes())))
+		fmt.Println("new app private key is:", strings.ToUpper(hex.EncodeToString(appPriv.Bytes())))
+
+		msg := acc.NewBuyUsernameMsg(
+			viper.GetString(client.FlagUser), viper.GetString(client.FlagUsername),
+			types.LNO(viper.GetString(client.FlagPrice)),
+			resetPriv.PubKey(), transactionPriv.PubKey(), appPriv.PubKey())
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
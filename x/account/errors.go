@@ -204,3 +204,85 @@ func ErrInvalidMemo() sdk.Error {
 func ErrInvalidJSONMeta() sdk.Error {
 	return types.NewError(types.CodeInvalidJSONMeta, fmt.Sprintf("invalid account JSON meta"))
 }
+
+// ErrGrantScopeMismatch - error when msg type is out of scopes granted to the app
+func ErrGrantScopeMismatch(owner types.AccountKey, msgType string) sdk.Error {
+	return types.NewError(
+		types.CodeGrantScopeMismatch,
+		fmt.Sprintf("grant user %v isn't allowed to sign %v", owner, msgType))
+}
+
+// ErrInvalidGuardians - error when guardians or threshold is invalid
+func ErrInvalidGuardians(msg string) sdk.Error {
+	return types.NewError(types.CodeInvalidGuardians, fmt.Sprintf("invalid guardians: %s", msg))
+}
+
+// ErrNotGuardian - error when approver isn't guardian of the account
+func ErrNotGuardian(guardian, username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeNotGuardian, fmt.Sprintf("%v isn't guardian of %v", guardian, username))
+}
+
+// ErrRecoveryRequestNotFound - error when account has no pending recovery request
+func ErrRecoveryRequestNotFound(username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeRecoveryRequestNotFound, fmt.Sprintf("recovery request of %v not found", username))
+}
+
+// ErrRecoveryInProgress - error when recovery of the account is already scheduled
+func ErrRecoveryInProgress(username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeRecoveryInProgress, fmt.Sprintf("recovery of %v is in progress", username))
+}
+
+// ErrRecoveryAlreadyApproved - error when guardian approves the same recovery twice
+func ErrRecoveryAlreadyApproved(guardian types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeRecoveryAlreadyApproved, fmt.Sprintf("guardian %v already approved the recovery", guardian))
+}
+
+// ErrInvalidNewKey - error when new public key is invalid
+func ErrInvalidNewKey(msg string) sdk.Error {
+	return types.NewError(types.CodeInvalidNewKey, fmt.Sprintf("invalid new key: %s", msg))
+}
+
+// ErrUsernameListingNotFound - error when username isn't for sale
+func ErrUsernameListingNotFound(username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeUsernameListingNotFound, fmt.Sprintf("username %v isn't for sale", username))
+}
+
+// ErrUsernameSaleBlocked - error when account has obligations that block the sale
+func ErrUsernameSaleBlocked(username types.AccountKey, reason string) sdk.Error {
+	return types.NewError(types.CodeUsernameSaleBlocked, fmt.Sprintf("username %v can't be sold: %s", username, reason))
+}
+
+// ErrUsernameListingPriceMismatch - error when buyer's price doesn't match the listing
+func ErrUsernameListingPriceMismatch(username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeUsernameListingPriceMismatch, fmt.Sprintf("price of username %v doesn't match the listing", username))
+}
+
+// ErrInvalidUsernameListing - error when username listing is invalid
+func ErrInvalidUsernameListing(msg string) sdk.Error {
+	return types.NewError(types.CodeInvalidUsernameListing, fmt.Sprintf("invalid username listing: %s", msg))
+}
+
+// ErrInvalidProfile - error when account profile is invalid
+func ErrInvalidProfile(msg string) sdk.Error {
+	return types.NewError(types.CodeInvalidProfile, fmt.Sprintf("invalid profile: %s", msg))
+}
+
+// ErrUsernameRetired - error when username belongs to a closed account
+func ErrUsernameRetired(username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeUsernameRetired, fmt.Sprintf("username %v is retired", username))
+}
+
+// ErrAccountCloseBlocked - error when account has obligations that block closing it
+func ErrAccountCloseBlocked(username types.AccountKey, reason string) sdk.Error {
+	return types.NewError(types.CodeAccountCloseBlocked, fmt.Sprintf("account %v can't be closed: %s", username, reason))
+}
+
+// ErrInvalidAccountClose - error when close account msg is invalid
+func ErrInvalidAccountClose(msg string) sdk.Error {
+	return types.NewError(types.CodeInvalidAccountClose, fmt.Sprintf("invalid account close: %s", msg))
+}
+
+// ErrGrantPubKeyConflict - error when grant can't be moved to a key already granted by the user
+func ErrGrantPubKeyConflict(grantor types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeGrantPubKeyConflict, fmt.Sprintf("%v already has grant to the new key", grantor))
+}
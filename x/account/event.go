@@ -26,6 +26,29 @@ func (event ReturnCoinEvent) Execute(ctx sdk.Context, am AccountManager) sdk.Err
 	return nil
 }
 
+// RecoveryEvent - replace keys of the account with the recovery request approved by guardians
+type RecoveryEvent struct {
+	Username  types.AccountKey `json:"username"`
+	ExecuteAt int64            `json:"execute_at"`
+}
+
+// Execute - execute social recovery events. The account may be closed or its recovery
+// request changed after the event is registered, such event is dropped instead of
+// halting the chain
+func (event RecoveryEvent) Execute(ctx sdk.Context, am AccountManager) sdk.Error {
+	if !am.DoesAccountExist(ctx, event.Username) {
+		ctx.Logger().Info("drop recovery event of missing account", "username", event.Username)
+		return nil
+	}
+	cacheCtx, write := ctx.CacheContext()
+	if err := am.ExecuteRecovery(cacheCtx, event.Username, event.ExecuteAt); err != nil {
+		ctx.Logger().Info("drop recovery event", "username", event.Username, "err", err.Error())
+		return nil
+	}
+	write()
+	return nil
+}
+
 // CreateCoinReturnEvents - create coin return events
 func CreateCoinReturnEvents(
 	username types.AccountKey, times int64, interval int64, coin types.Coin,
@@ -10,8 +10,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// ObligationCheck - checks obligations the account holds in other modules,
+// such as voter or validator deposit, which block the username sale or closing the account
+type ObligationCheck func(ctx sdk.Context, username types.AccountKey) sdk.Error
+
 // NewHandler - Handle all "account" type messages.
-func NewHandler(am AccountManager, gm global.GlobalManager) sdk.Handler {
+func NewHandler(
+	am AccountManager, gm global.GlobalManager, saleCheck, closeCheck ObligationCheck) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case FollowMsg:
@@ -28,6 +33,26 @@ func NewHandler(am AccountManager, gm global.GlobalManager) sdk.Handler {
 			return handleRegisterMsg(ctx, am, gm, msg)
 		case UpdateAccountMsg:
 			return handleUpdateAccountMsg(ctx, am, msg)
+		case SetGuardiansMsg:
+			return handleSetGuardiansMsg(ctx, am, msg)
+		case ApproveRecoveryMsg:
+			return handleApproveRecoveryMsg(ctx, am, gm, msg)
+		case CancelRecoveryMsg:
+			return handleCancelRecoveryMsg(ctx, am, gm, msg)
+		case ChangeAppKeyMsg:
+			return handleChangeAppKeyMsg(ctx, am, msg)
+		case ChangeTransactionKeyMsg:
+			return handleChangeTransactionKeyMsg(ctx, am, msg)
+		case ListUsernameMsg:
+			return handleListUsernameMsg(ctx, am, saleCheck, msg)
+		case CancelUsernameListingMsg:
+			return handleCancelUsernameListingMsg(ctx, am, msg)
+		case BuyUsernameMsg:
+			return handleBuyUsernameMsg(ctx, am, saleCheck, msg)
+		case UpdateProfileMsg:
+			return handleUpdateProfileMsg(ctx, am, msg)
+		case CloseAccountMsg:
+			return handleCloseAccountMsg(ctx, am, closeCheck, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized account msg type: %v", reflect.TypeOf(msg).Name())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -155,6 +180,23 @@ func handleRegisterMsg(ctx sdk.Context, am AccountManager, gm global.GlobalManag
 		msg.NewAppPubKey, coin.Minus(accParams.RegisterFee)); err != nil {
 		return err.Result()
 	}
+	// referrer gets a flat bonus from developer inflation pool, the bonus is capped
+	// by the register fee just added so registrations can't drain the pool
+	bonus := accParams.ReferralBonus
+	if bonus.IsGT(accParams.RegisterFee) {
+		bonus = accParams.RegisterFee
+	}
+	if bonus.IsPositive() {
+		bonus, err = gm.WithdrawFromDeveloperInflationPool(ctx, bonus)
+		if err != nil {
+			return err.Result()
+		}
+	}
+	if bonus.IsPositive() {
+		if err := am.AddReferralReward(ctx, msg.Referrer, msg.NewUser, bonus); err != nil {
+			return err.Result()
+		}
+	}
 	return sdk.Result{}
 }
 
@@ -168,3 +210,155 @@ func handleUpdateAccountMsg(ctx sdk.Context, am AccountManager, msg UpdateAccoun
 	}
 	return sdk.Result{}
 }
+
+// Handle SetGuardiansMsg
+func handleSetGuardiansMsg(ctx sdk.Context, am AccountManager, msg SetGuardiansMsg) sdk.Result {
+	if err := am.SetGuardians(ctx, msg.Username, msg.Guardians, msg.Threshold); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle ApproveRecoveryMsg
+func handleApproveRecoveryMsg(
+	ctx sdk.Context, am AccountManager, gm global.GlobalManager, msg ApproveRecoveryMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	executeAt, err := am.ApproveRecovery(
+		ctx, msg.Username, msg.Guardian, msg.NewResetPubKey,
+		msg.NewTransactionPubKey, msg.NewAppPubKey)
+	if err != nil {
+		return err.Result()
+	}
+	// threshold is reached, new keys take effect after the delay
+	if executeAt != 0 {
+		if err := gm.RegisterRecoveryEvent(
+			ctx, executeAt, RecoveryEvent{Username: msg.Username, ExecuteAt: executeAt}); err != nil {
+			return err.Result()
+		}
+	}
+	return sdk.Result{}
+}
+
+// Handle CancelRecoveryMsg
+func handleCancelRecoveryMsg(
+	ctx sdk.Context, am AccountManager, gm global.GlobalManager, msg CancelRecoveryMsg) sdk.Result {
+	request, err := am.CancelRecovery(ctx, msg.Username)
+	if err != nil {
+		return err.Result()
+	}
+	if request.ExecuteAt != 0 {
+		if _, err := gm.RemoveTimeEvents(ctx, request.ExecuteAt, func(event types.Event) bool {
+			recoveryEvent, ok := event.(RecoveryEvent)
+			return ok && recoveryEvent.Username == msg.Username
+		}); err != nil {
+			return err.Result()
+		}
+	}
+	return sdk.Result{}
+}
+
+// Handle ChangeAppKeyMsg
+func handleChangeAppKeyMsg(ctx sdk.Context, am AccountManager, msg ChangeAppKeyMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if err := am.ChangeAppKey(ctx, msg.Username, msg.NewAppPubKey); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle ChangeTransactionKeyMsg
+func handleChangeTransactionKeyMsg(ctx sdk.Context, am AccountManager, msg ChangeTransactionKeyMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if err := am.ChangeTransactionKey(ctx, msg.Username, msg.NewTransactionPubKey); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle ListUsernameMsg
+func handleListUsernameMsg(
+	ctx sdk.Context, am AccountManager, saleCheck ObligationCheck, msg ListUsernameMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if saleCheck != nil {
+		if err := saleCheck(ctx, msg.Username); err != nil {
+			return err.Result()
+		}
+	}
+	price, err := types.LinoToCoin(msg.Price)
+	if err != nil {
+		return err.Result()
+	}
+	if err := am.ListUsername(
+		ctx, msg.Username, msg.Payee, price, msg.ClearGrants, msg.ClearFollows); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle CancelUsernameListingMsg
+func handleCancelUsernameListingMsg(ctx sdk.Context, am AccountManager, msg CancelUsernameListingMsg) sdk.Result {
+	if err := am.CancelUsernameListing(ctx, msg.Username); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle BuyUsernameMsg
+func handleBuyUsernameMsg(
+	ctx sdk.Context, am AccountManager, saleCheck ObligationCheck, msg BuyUsernameMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if !am.DoesAccountExist(ctx, msg.Buyer) {
+		return ErrAccountNotFound(msg.Buyer).Result()
+	}
+	// obligations may be created after the listing
+	if saleCheck != nil {
+		if err := saleCheck(ctx, msg.Username); err != nil {
+			return err.Result()
+		}
+	}
+	price, err := types.LinoToCoin(msg.Price)
+	if err != nil {
+		return err.Result()
+	}
+	if err := am.BuyUsername(
+		ctx, msg.Buyer, msg.Username, price, msg.NewResetPubKey,
+		msg.NewTransactionPubKey, msg.NewAppPubKey); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle UpdateProfileMsg
+func handleUpdateProfileMsg(ctx sdk.Context, am AccountManager, msg UpdateProfileMsg) sdk.Result {
+	if err := am.UpdateProfile(ctx, msg.Username, msg.Profile); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle CloseAccountMsg
+func handleCloseAccountMsg(
+	ctx sdk.Context, am AccountManager, closeCheck ObligationCheck, msg CloseAccountMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if closeCheck != nil {
+		if err := closeCheck(ctx, msg.Username); err != nil {
+			return err.Result()
+		}
+	}
+	if err := am.CloseAccount(ctx, msg.Username, msg.Receiver); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
@@ -3,6 +3,7 @@ package account
 import (
 	"testing"
 
+	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/account/model"
 
@@ -22,7 +23,7 @@ var (
 
 func TestFollow(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	// create two test users
 	createTestAccount(ctx, am, "user1")
@@ -42,7 +43,7 @@ func TestFollow(t *testing.T) {
 
 func TestFollowUserNotExist(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	// create test user
 	createTestAccount(ctx, am, "user1")
@@ -63,7 +64,7 @@ func TestFollowUserNotExist(t *testing.T) {
 
 func TestFollowAgain(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	// create two test users
 	createTestAccount(ctx, am, "user1")
@@ -87,7 +88,7 @@ func TestFollowAgain(t *testing.T) {
 
 func TestUnfollow(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	// create two test users
 	createTestAccount(ctx, am, "user1")
@@ -112,7 +113,7 @@ func TestUnfollow(t *testing.T) {
 
 func TestUnfollowUserNotExist(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 	// create test user
 	createTestAccount(ctx, am, "user1")
 
@@ -129,7 +130,7 @@ func TestUnfollowUserNotExist(t *testing.T) {
 
 func TestInvalidUnfollow(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 	// create test user
 	createTestAccount(ctx, am, "user1")
 	createTestAccount(ctx, am, "user2")
@@ -160,7 +161,7 @@ func TestInvalidUnfollow(t *testing.T) {
 
 func TestTransferNormal(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	accParam, _ := am.paramHolder.GetAccountParam(ctx)
 	// create two test users with initial deposit of 100 LNO.
@@ -207,7 +208,7 @@ func TestTransferNormal(t *testing.T) {
 
 func TestSenderCoinNotEnough(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 	accParam, _ := am.paramHolder.GetAccountParam(ctx)
 
 	// create two test users
@@ -227,7 +228,7 @@ func TestSenderCoinNotEnough(t *testing.T) {
 
 func TestReceiverUsernameIncorrect(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	// create two test users
 	createTestAccount(ctx, am, "user1")
@@ -242,7 +243,7 @@ func TestReceiverUsernameIncorrect(t *testing.T) {
 
 func TestHandleAccountRecover(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 	accParam, _ := am.paramHolder.GetAccountParam(ctx)
 	user1 := "user1"
 
@@ -275,6 +276,7 @@ func TestHandleAccountRecover(t *testing.T) {
 			ResetKey:       tc.newResetKey,
 			TransactionKey: tc.newTransactionKey,
 			AppKey:         tc.newAppKey,
+			Referrer:       accountReferrer,
 		}
 		checkAccountInfo(t, ctx, testName, types.AccountKey(tc.user), accInfo)
 
@@ -291,7 +293,7 @@ func TestHandleRegister(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
 	accParam, _ := am.paramHolder.GetAccountParam(ctx)
 
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 	referrer := "referrer"
 
 	createTestAccount(ctx, am, referrer)
@@ -457,9 +459,43 @@ func TestHandleRegister(t *testing.T) {
 	}
 }
 
+func TestHandleRegisterWithReferralBonus(t *testing.T) {
+	ctx, am, gm := setupTest(t, 1)
+	accParam, _ := am.paramHolder.GetAccountParam(ctx)
+	accParam.ReferralBonus = types.NewCoinFromInt64(40000)
+	assert.Nil(t, param.ChangeParamEvent{Param: *accParam}.Execute(ctx, am.paramHolder, nil))
+
+	handler := NewHandler(am, gm, nil, nil)
+	referrer := types.AccountKey("referrer")
+	createTestAccount(ctx, am, string(referrer))
+	am.AddSavingCoin(ctx, referrer, c100, "", "", types.TransferIn)
+	_, err := gm.GetDeveloperMonthlyInflation(ctx)
+	assert.Nil(t, err)
+
+	result := handler(ctx, NewRegisterMsg(
+		string(referrer), "user1", "1",
+		secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+	))
+	assert.Equal(t, sdk.Result{}, result)
+
+	// register fee is added to developer inflation pool and the bonus is paid from the pool
+	developerInflation, err := gm.GetDeveloperMonthlyInflation(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, accParam.RegisterFee.Minus(types.NewCoinFromInt64(40000)), developerInflation)
+	saving, err := am.GetSavingFromBank(ctx, referrer)
+	assert.Nil(t, err)
+	assert.Equal(t, accParam.RegisterFee.Plus(types.NewCoinFromInt64(99*types.Decimals+40000)), saving)
+	referrals, err := am.GetReferrals(ctx, referrer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(referrals))
+	assert.Equal(t, types.NewCoinFromInt64(40000), referrals[0].TotalReward)
+}
+
 func TestHandleUpdateAccountMsg(t *testing.T) {
 	ctx, am, gm := setupTest(t, 1)
-	handler := NewHandler(am, gm)
+	handler := NewHandler(am, gm, nil, nil)
 
 	createTestAccount(ctx, am, "accKey")
 
@@ -486,3 +522,202 @@ func TestHandleUpdateAccountMsg(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleSocialRecovery(t *testing.T) {
+	ctx, am, gm := setupTest(t, 1)
+	handler := NewHandler(am, gm, nil, nil)
+	accParam, _ := am.paramHolder.GetAccountParam(ctx)
+	user1 := "user1"
+	guardian1 := "guardian1"
+	guardian2 := "guardian2"
+
+	createTestAccount(ctx, am, user1)
+	createTestAccount(ctx, am, guardian1)
+	createTestAccount(ctx, am, guardian2)
+
+	result := handler(ctx, NewSetGuardiansMsg(user1, []string{guardian1, guardian2}, 2))
+	assert.Equal(t, sdk.Result{}, result)
+
+	newResetKey := secp256k1.GenPrivKey().PubKey()
+	newTransactionKey := secp256k1.GenPrivKey().PubKey()
+	newAppKey := secp256k1.GenPrivKey().PubKey()
+	executeAt := ctx.BlockHeader().Time.Unix() + accParam.SocialRecoveryDelaySec
+	recoveryEvent := RecoveryEvent{Username: types.AccountKey(user1), ExecuteAt: executeAt}
+
+	result = handler(ctx, NewApproveRecoveryMsg(guardian1, user1, newResetKey, newTransactionKey, newAppKey))
+	assert.Equal(t, sdk.Result{}, result)
+	assert.Nil(t, gm.GetTimeEventListAtTime(ctx, executeAt))
+
+	result = handler(ctx, NewApproveRecoveryMsg(guardian2, user1, newResetKey, newTransactionKey, newAppKey))
+	assert.Equal(t, sdk.Result{}, result)
+	assert.Equal(t, []types.Event{recoveryEvent}, gm.GetTimeEventListAtTime(ctx, executeAt).Events)
+
+	// cancel removes the scheduled event
+	result = handler(ctx, NewCancelRecoveryMsg(user1))
+	assert.Equal(t, sdk.Result{}, result)
+	assert.Nil(t, gm.GetTimeEventListAtTime(ctx, executeAt))
+	result = handler(ctx, NewCancelRecoveryMsg(user1))
+	assert.Equal(t, ErrRecoveryRequestNotFound(types.AccountKey(user1)).Result(), result)
+
+	result = handler(ctx, NewApproveRecoveryMsg(guardian1, user1, newResetKey, newTransactionKey, newAppKey))
+	assert.Equal(t, sdk.Result{}, result)
+	result = handler(ctx, NewApproveRecoveryMsg(guardian2, user1, newResetKey, newTransactionKey, newAppKey))
+	assert.Equal(t, sdk.Result{}, result)
+
+	err := recoveryEvent.Execute(ctx, am)
+	assert.Nil(t, err)
+	accInfo := model.AccountInfo{
+		Username:       types.AccountKey(user1),
+		CreatedAt:      ctx.BlockHeader().Time.Unix(),
+		ResetKey:       newResetKey,
+		TransactionKey: newTransactionKey,
+		AppKey:         newAppKey,
+		Referrer:       accountReferrer,
+	}
+	checkAccountInfo(t, ctx, "recovered by guardians", types.AccountKey(user1), accInfo)
+
+	// recovery event of missing account is dropped
+	err = RecoveryEvent{Username: types.AccountKey("nobody"), ExecuteAt: executeAt}.Execute(ctx, am)
+	assert.Nil(t, err)
+}
+
+func TestHandleChangeKey(t *testing.T) {
+	ctx, am, gm := setupTest(t, 1)
+	handler := NewHandler(am, gm, nil, nil)
+	user1 := "user1"
+	resetPriv, txPriv, _ := createTestAccount(ctx, am, user1)
+
+	newAppKey := secp256k1.GenPrivKey().PubKey()
+	result := handler(ctx, NewChangeAppKeyMsg(user1, newAppKey))
+	assert.Equal(t, sdk.Result{}, result)
+	accInfo := model.AccountInfo{
+		Username:       types.AccountKey(user1),
+		CreatedAt:      ctx.BlockHeader().Time.Unix(),
+		ResetKey:       resetPriv.PubKey(),
+		TransactionKey: txPriv.PubKey(),
+		AppKey:         newAppKey,
+		Referrer:       accountReferrer,
+	}
+	checkAccountInfo(t, ctx, "change app key", types.AccountKey(user1), accInfo)
+
+	newTransactionKey := secp256k1.GenPrivKey().PubKey()
+	result = handler(ctx, NewChangeTransactionKeyMsg(user1, newTransactionKey))
+	assert.Equal(t, sdk.Result{}, result)
+	accInfo.TransactionKey = newTransactionKey
+	checkAccountInfo(t, ctx, "change transaction key", types.AccountKey(user1), accInfo)
+
+	result = handler(ctx, NewChangeAppKeyMsg("nobody", newAppKey))
+	assert.Equal(t, ErrAccountNotFound(types.AccountKey("nobody")).Result(), result)
+}
+
+func TestHandleUsernameSale(t *testing.T) {
+	ctx, am, gm := setupTest(t, 1)
+	accParam, _ := am.paramHolder.GetAccountParam(ctx)
+	seller := "seller"
+	payee := "payee"
+	buyer := "buyer"
+	createTestAccount(ctx, am, seller)
+	createTestAccount(ctx, am, payee)
+	createTestAccount(ctx, am, buyer)
+	err := am.AddSavingCoin(ctx, types.AccountKey(buyer), types.NewCoinFromInt64(100*types.Decimals), "", "", types.TransferIn)
+	assert.Nil(t, err)
+
+	blocked := true
+	handler := NewHandler(am, gm, func(ctx sdk.Context, username types.AccountKey) sdk.Error {
+		if blocked {
+			return ErrUsernameSaleBlocked(username, "account is a voter")
+		}
+		return nil
+	}, nil)
+	saleBlockedResult := ErrUsernameSaleBlocked(types.AccountKey(seller), "account is a voter").Result()
+
+	result := handler(ctx, NewListUsernameMsg(seller, payee, types.LNO("100"), false, false))
+	assert.Equal(t, saleBlockedResult, result)
+	blocked = false
+	result = handler(ctx, NewListUsernameMsg(seller, payee, types.LNO("100"), false, false))
+	assert.Equal(t, sdk.Result{}, result)
+
+	newResetKey := secp256k1.GenPrivKey().PubKey()
+	newTransactionKey := secp256k1.GenPrivKey().PubKey()
+	newAppKey := secp256k1.GenPrivKey().PubKey()
+	// obligation created after listing blocks the sale
+	blocked = true
+	result = handler(ctx, NewBuyUsernameMsg(buyer, seller, types.LNO("100"), newResetKey, newTransactionKey, newAppKey))
+	assert.Equal(t, saleBlockedResult, result)
+	blocked = false
+	result = handler(ctx, NewBuyUsernameMsg(buyer, seller, types.LNO("100"), newResetKey, newTransactionKey, newAppKey))
+	assert.Equal(t, sdk.Result{}, result)
+
+	accInfo := model.AccountInfo{
+		Username:       types.AccountKey(seller),
+		CreatedAt:      ctx.BlockHeader().Time.Unix(),
+		ResetKey:       newResetKey,
+		TransactionKey: newTransactionKey,
+		AppKey:         newAppKey,
+		Referrer:       accountReferrer,
+	}
+	checkAccountInfo(t, ctx, "buy username", types.AccountKey(seller), accInfo)
+	saving, err := am.GetSavingFromBank(ctx, types.AccountKey(payee))
+	assert.Nil(t, err)
+	// payee gets the price and the swept saving of the seller
+	assert.True(t, saving.IsEqual(
+		accParam.RegisterFee.Plus(types.NewCoinFromInt64(100*types.Decimals)).Plus(accParam.RegisterFee)))
+
+	result = handler(ctx, NewCancelUsernameListingMsg(seller))
+	assert.Equal(t, ErrUsernameListingNotFound(types.AccountKey(seller)).Result(), result)
+}
+
+func TestHandleUpdateProfile(t *testing.T) {
+	ctx, am, gm := setupTest(t, 1)
+	handler := NewHandler(am, gm, nil, nil)
+	createTestAccount(ctx, am, "user1")
+	profile := model.Profile{
+		DisplayName: "User One",
+		VerifiedLinks: []model.VerifiedLink{
+			{Platform: "twitter", URL: "https://twitter.com/user1"},
+		},
+	}
+
+	result := handler(ctx, NewUpdateProfileMsg("invalid", profile))
+	assert.Equal(t, ErrAccountNotFound("invalid").Result(), result)
+
+	result = handler(ctx, NewUpdateProfileMsg("user1", profile))
+	assert.Equal(t, sdk.Result{}, result)
+	storedProfile, err := am.GetProfile(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, profile, *storedProfile)
+
+	// update replaces the whole profile
+	result = handler(ctx, NewUpdateProfileMsg("user1", model.Profile{Bio: "bio"}))
+	assert.Equal(t, sdk.Result{}, result)
+	storedProfile, err = am.GetProfile(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, model.Profile{Bio: "bio"}, *storedProfile)
+}
+
+func TestHandleCloseAccount(t *testing.T) {
+	ctx, am, gm := setupTest(t, 1)
+	blocked := true
+	handler := NewHandler(am, gm, nil, func(ctx sdk.Context, username types.AccountKey) sdk.Error {
+		if blocked {
+			return ErrAccountCloseBlocked(username, "account is a voter")
+		}
+		return nil
+	})
+	createTestAccount(ctx, am, "user1")
+	createTestAccount(ctx, am, "user2")
+
+	result := handler(ctx, NewCloseAccountMsg("invalid", "user2"))
+	assert.Equal(t, ErrAccountNotFound("invalid").Result(), result)
+	result = handler(ctx, NewCloseAccountMsg("user1", "user2"))
+	assert.Equal(t, ErrAccountCloseBlocked(user1, "account is a voter").Result(), result)
+	assert.True(t, am.DoesAccountExist(ctx, user1))
+
+	blocked = false
+	result = handler(ctx, NewCloseAccountMsg("user1", "invalid"))
+	assert.Equal(t, ErrAccountNotFound("invalid").Result(), result)
+	result = handler(ctx, NewCloseAccountMsg("user1", "user2"))
+	assert.Equal(t, sdk.Result{}, result)
+	assert.False(t, am.DoesAccountExist(ctx, user1))
+	assert.True(t, am.IsUsernameRetired(ctx, user1))
+}
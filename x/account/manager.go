@@ -1,6 +1,7 @@
 package account
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
@@ -39,6 +40,9 @@ func (accManager AccountManager) CreateAccount(
 	if accManager.DoesAccountExist(ctx, username) {
 		return ErrAccountAlreadyExists(username)
 	}
+	if accManager.storage.IsUsernameRetired(ctx, username) {
+		return ErrUsernameRetired(username)
+	}
 	accParams, err := accManager.paramHolder.GetAccountParam(ctx)
 	if err != nil {
 		return err
@@ -63,10 +67,21 @@ func (accManager AccountManager) CreateAccount(
 		ResetKey:       resetKey,
 		TransactionKey: transactionKey,
 		AppKey:         appKey,
+		Referrer:       referrer,
 	}
 	if err := accManager.storage.SetInfo(ctx, username, accountInfo); err != nil {
 		return err
 	}
+	if referrer != username && accManager.DoesAccountExist(ctx, referrer) {
+		referral := &model.Referral{
+			Referee:     username,
+			CreatedAt:   ctx.BlockHeader().Time.Unix(),
+			TotalReward: types.NewCoinFromInt64(0),
+		}
+		if err := accManager.storage.SetReferral(ctx, referrer, referral); err != nil {
+			return err
+		}
+	}
 
 	accountMeta := &model.AccountMeta{
 		LastActivityAt:       ctx.BlockHeader().Time.Unix(),
@@ -255,17 +270,12 @@ func (accManager AccountManager) MinusSavingCoin(
 	// update pending coin day queue, remove expired transaction
 	accManager.updateTXFromPendingCoinDayQueue(ctx, accountBank, pendingCoinDayQueue)
 
-	coinDayParams, err := accManager.paramHolder.GetCoinDayParam(ctx)
-	if err != nil {
-		return err
-	}
-
 	for len(pendingCoinDayQueue.PendingCoinDays) > 0 {
 		lengthOfQueue := len(pendingCoinDayQueue.PendingCoinDays)
 		pendingCoinDay := pendingCoinDayQueue.PendingCoinDays[lengthOfQueue-1]
 		recoverRatio := sdk.NewRat(
 			pendingCoinDayQueue.LastUpdatedAt-pendingCoinDay.StartTime,
-			coinDayParams.SecondsToRecoverCoinDay)
+			pendingCoinDay.EndTime-pendingCoinDay.StartTime)
 		if coin.IsGTE(pendingCoinDay.Coin) {
 			// if withdraw money is much more than last pending transaction, remove last transaction
 			coin = coin.Minus(pendingCoinDay.Coin)
@@ -302,6 +312,23 @@ func (accManager AccountManager) MinusSavingCoin(
 	return nil
 }
 
+// CheckSavingCoinEnough - check if user can minus coin from saving without going below minimum balance
+func (accManager AccountManager) CheckSavingCoinEnough(
+	ctx sdk.Context, username types.AccountKey, coin types.Coin) sdk.Error {
+	accountBank, err := accManager.storage.GetBankFromAccountKey(ctx, username)
+	if err != nil {
+		return err
+	}
+	accountParams, err := accManager.paramHolder.GetAccountParam(ctx)
+	if err != nil {
+		return err
+	}
+	if coin.IsPositive() && !accountBank.Saving.Minus(coin).IsGTE(accountParams.MinimumBalance) {
+		return ErrAccountSavingCoinNotEnough()
+	}
+	return nil
+}
+
 // MinusSavingCoin - minus coin from balance, remove most charged coin day coin
 func (accManager AccountManager) MinusSavingCoinWithFullCoinDay(
 	ctx sdk.Context, username types.AccountKey, coin types.Coin, to types.AccountKey,
@@ -345,10 +372,6 @@ func (accManager AccountManager) MinusSavingCoinWithFullCoinDay(
 	}
 	// update pending coin day queue, remove expired transaction
 	accManager.updateTXFromPendingCoinDayQueue(ctx, accountBank, pendingCoinDayQueue)
-	coinDayParams, err := accManager.paramHolder.GetCoinDayParam(ctx)
-	if err != nil {
-		return err
-	}
 	if accountBank.CoinDay.IsGTE(coin) {
 		accountBank.CoinDay = accountBank.CoinDay.Minus(coin)
 	} else {
@@ -359,7 +382,7 @@ func (accManager AccountManager) MinusSavingCoinWithFullCoinDay(
 			pendingCoinDay := pendingCoinDayQueue.PendingCoinDays[0]
 			recoverRatio := sdk.NewRat(
 				pendingCoinDayQueue.LastUpdatedAt-pendingCoinDay.StartTime,
-				coinDayParams.SecondsToRecoverCoinDay)
+				pendingCoinDay.EndTime-pendingCoinDay.StartTime)
 			if coin.IsGTE(pendingCoinDay.Coin) {
 				// if withdraw money is much than first pending transaction, remove first transaction
 				coin = coin.Minus(pendingCoinDay.Coin)
@@ -430,6 +453,21 @@ func (accManager AccountManager) UpdateJSONMeta(
 	return accManager.storage.SetMeta(ctx, username, accountMeta)
 }
 
+// UpdateProfile - replace structured profile of the account
+func (accManager AccountManager) UpdateProfile(
+	ctx sdk.Context, username types.AccountKey, profile model.Profile) sdk.Error {
+	if !accManager.DoesAccountExist(ctx, username) {
+		return ErrAccountNotFound(username)
+	}
+	return accManager.storage.SetProfile(ctx, username, &profile)
+}
+
+// GetProfile - get structured profile of the account, nil if it isn't set
+func (accManager AccountManager) GetProfile(
+	ctx sdk.Context, username types.AccountKey) (*model.Profile, sdk.Error) {
+	return accManager.storage.GetProfile(ctx, username)
+}
+
 // GetResetKey - get reset public key
 func (accManager AccountManager) GetResetKey(
 	ctx sdk.Context, username types.AccountKey) (crypto.PubKey, sdk.Error) {
@@ -609,6 +647,63 @@ func (accManager AccountManager) AddIncomeAndReward(
 	return nil
 }
 
+// ShareRewardWithReferrer - referrer gets a share of user's content reward within referral
+// reward period after registration, returns the reward left to user
+func (accManager AccountManager) ShareRewardWithReferrer(
+	ctx sdk.Context, username types.AccountKey, reward types.Coin) (types.Coin, sdk.Error) {
+	accInfo, err := accManager.storage.GetInfo(ctx, username)
+	if err != nil {
+		return reward, err
+	}
+	if accInfo.Referrer == username || !accManager.DoesAccountExist(ctx, accInfo.Referrer) {
+		return reward, nil
+	}
+	accParams, err := accManager.paramHolder.GetAccountParam(ctx)
+	if err != nil {
+		return reward, err
+	}
+	if accInfo.CreatedAt+accParams.ReferralRewardPeriodSec < ctx.BlockHeader().Time.Unix() {
+		return reward, nil
+	}
+	share := types.RatToCoin(reward.ToRat().Mul(accParams.ReferralRewardRate))
+	if share.IsZero() {
+		return reward, nil
+	}
+	if err := accManager.AddReferralReward(ctx, accInfo.Referrer, username, share); err != nil {
+		return reward, err
+	}
+	return reward.Minus(share), nil
+}
+
+// AddReferralReward - add referral reward to referrer's saving and
+// accumulate the lifetime value of the referee
+func (accManager AccountManager) AddReferralReward(
+	ctx sdk.Context, referrer types.AccountKey, referee types.AccountKey, reward types.Coin) sdk.Error {
+	referral, err := accManager.storage.GetReferral(ctx, referrer, referee)
+	if err != nil {
+		return err
+	}
+	if referral == nil {
+		referral = &model.Referral{
+			Referee:     referee,
+			CreatedAt:   ctx.BlockHeader().Time.Unix(),
+			TotalReward: types.NewCoinFromInt64(0),
+		}
+	}
+	if err := accManager.AddSavingCoin(
+		ctx, referrer, reward, referee, "", types.ReferralReward); err != nil {
+		return err
+	}
+	referral.TotalReward = referral.TotalReward.Plus(reward)
+	return accManager.storage.SetReferral(ctx, referrer, referral)
+}
+
+// GetReferrals - get all accounts registered by referrer and their lifetime value
+func (accManager AccountManager) GetReferrals(
+	ctx sdk.Context, referrer types.AccountKey) ([]model.Referral, sdk.Error) {
+	return accManager.storage.GetReferrals(ctx, referrer)
+}
+
 // AddRewardHistory - add reward detail to user reward history
 func (accManager AccountManager) AddRewardHistory(
 	ctx sdk.Context, username types.AccountKey, numOfReward int64,
@@ -778,8 +873,7 @@ func (accManager AccountManager) CheckUserTPSCapacity(
 			accountMeta.TransactionCapacity.Plus(increaseCapacity)
 	}
 	// based on current tps, calculate current transaction cost
-	currentTxCost := types.RatToCoin(
-		bandwidthParams.CapacityUsagePerTransaction.ToRat().Mul(tpsCapacityRatio))
+	currentTxCost := getTransactionCapacityCost(bandwidthParams, tpsCapacityRatio)
 	// check if user current capacity is enough or not
 	if currentTxCost.IsGT(accountMeta.TransactionCapacity) {
 		return ErrAccountTPSCapacityNotEnough(me)
@@ -792,6 +886,22 @@ func (accManager AccountManager) CheckUserTPSCapacity(
 	return nil
 }
 
+// GetTransactionCapacityCost - get capacity cost of one signer under current tps
+func (accManager AccountManager) GetTransactionCapacityCost(
+	ctx sdk.Context, tpsCapacityRatio sdk.Rat) (types.Coin, sdk.Error) {
+	bandwidthParams, err := accManager.paramHolder.GetBandwidthParam(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return getTransactionCapacityCost(bandwidthParams, tpsCapacityRatio), nil
+}
+
+func getTransactionCapacityCost(
+	bandwidthParams *param.BandwidthParam, tpsCapacityRatio sdk.Rat) types.Coin {
+	return types.RatToCoin(
+		bandwidthParams.CapacityUsagePerTransaction.ToRat().Mul(tpsCapacityRatio))
+}
+
 // UpdateDonationRelationship - increase donation relationship times by 1
 func (accManager AccountManager) UpdateDonationRelationship(
 	ctx sdk.Context, me, other types.AccountKey) sdk.Error {
@@ -814,34 +924,129 @@ func (accManager AccountManager) UpdateDonationRelationship(
 // AuthorizePermission - userA authorize permission to userB (currently only support auth to a developer)
 func (accManager AccountManager) AuthorizePermission(
 	ctx sdk.Context, me types.AccountKey, authorizedUser types.AccountKey,
-	validityPeriod int64, grantLevel types.Permission, amount types.Coin) sdk.Error {
+	validityPeriod int64, grantLevel types.Permission, amount types.Coin, scopes []string) sdk.Error {
 	d := time.Duration(validityPeriod) * time.Second
 	newGrantPubKey := model.GrantPubKey{
-		Username:   authorizedUser,
-		Permission: grantLevel,
-		CreatedAt:  ctx.BlockHeader().Time.Unix(),
-		ExpiresAt:  ctx.BlockHeader().Time.Add(d).Unix(),
-		Amount:     amount,
+		Username:    authorizedUser,
+		Permission:  grantLevel,
+		CreatedAt:   ctx.BlockHeader().Time.Unix(),
+		ExpiresAt:   ctx.BlockHeader().Time.Add(d).Unix(),
+		Amount:      amount,
+		Scopes:      scopes,
+		PeriodLimit: types.NewCoinFromInt64(0),
+	}
+	grantKey, err := accManager.getGrantKeyOfApp(ctx, authorizedUser, grantLevel)
+	if err != nil {
+		return err
 	}
+	return accManager.storage.SetGrantPubKey(ctx, me, grantKey, &newGrantPubKey)
+}
 
-	// If grant preauth permission, grant to developer's tx key
-	if grantLevel == types.PreAuthorizationPermission {
-		txKey, err := accManager.GetTransactionKey(ctx, authorizedUser)
-		if err != nil {
-			return err
-		}
-		return accManager.storage.SetGrantPubKey(ctx, me, txKey, &newGrantPubKey)
+// AuthorizePeriodicPermission - userA preauthorize a developer to spend up to periodLimit
+// in every period until the grant expires
+func (accManager AccountManager) AuthorizePeriodicPermission(
+	ctx sdk.Context, me types.AccountKey, authorizedUser types.AccountKey,
+	validityPeriod int64, period int64, periodLimit types.Coin) sdk.Error {
+	d := time.Duration(validityPeriod) * time.Second
+	newGrantPubKey := model.GrantPubKey{
+		Username:      authorizedUser,
+		Permission:    types.PreAuthorizationPermission,
+		CreatedAt:     ctx.BlockHeader().Time.Unix(),
+		ExpiresAt:     ctx.BlockHeader().Time.Add(d).Unix(),
+		Amount:        periodLimit,
+		PeriodSec:     period,
+		PeriodLimit:   periodLimit,
+		PeriodStartAt: ctx.BlockHeader().Time.Unix(),
+	}
+	grantKey, err := accManager.getGrantKeyOfApp(ctx, authorizedUser, types.PreAuthorizationPermission)
+	if err != nil {
+		return err
 	}
+	return accManager.storage.SetGrantPubKey(ctx, me, grantKey, &newGrantPubKey)
+}
 
-	// If grant app permission, grant to developer's app key
-	if grantLevel == types.AppPermission {
-		appKey, err := accManager.GetAppKey(ctx, authorizedUser)
-		if err != nil {
-			return err
-		}
-		return accManager.storage.SetGrantPubKey(ctx, me, appKey, &newGrantPubKey)
+// GetPreAuthAllowance - get remaining amount the developer can spend in current period,
+// for one-time preauth grant it's the remaining lump amount
+func (accManager AccountManager) GetPreAuthAllowance(
+	ctx sdk.Context, me types.AccountKey, authorizedUser types.AccountKey) (types.Coin, sdk.Error) {
+	grantKey, err := accManager.getGrantKeyOfApp(ctx, authorizedUser, types.PreAuthorizationPermission)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	grantPubKey, err := accManager.storage.GetGrantPubKey(ctx, me, grantKey)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	if grantPubKey.Username != authorizedUser || grantPubKey.Permission != types.PreAuthorizationPermission {
+		return types.NewCoinFromInt64(0), model.ErrGrantPubKeyNotFound()
 	}
-	return ErrUnsupportGrantLevel()
+	if grantPubKey.ExpiresAt < ctx.BlockHeader().Time.Unix() {
+		return types.NewCoinFromInt64(0), ErrGrantKeyExpired(me)
+	}
+	RollPreAuthPeriod(grantPubKey, ctx.BlockHeader().Time.Unix())
+	return grantPubKey.Amount, nil
+}
+
+// RollPreAuthPeriod - move periodic preauth grant to the period unixTime is in,
+// allowance is reset to period limit if a new period starts
+func RollPreAuthPeriod(grantPubKey *model.GrantPubKey, unixTime int64) {
+	if grantPubKey.PeriodSec <= 0 || unixTime < grantPubKey.PeriodStartAt+grantPubKey.PeriodSec {
+		return
+	}
+	passedPeriods := (unixTime - grantPubKey.PeriodStartAt) / grantPubKey.PeriodSec
+	grantPubKey.PeriodStartAt += passedPeriods * grantPubKey.PeriodSec
+	grantPubKey.Amount = grantPubKey.PeriodLimit
+}
+
+// UpdateGrantScopes - replace msg types the authorized app can sign with the granted permission,
+// empty scopes allow all msgs of the permission
+func (accManager AccountManager) UpdateGrantScopes(
+	ctx sdk.Context, me types.AccountKey, authorizedUser types.AccountKey,
+	grantLevel types.Permission, scopes []string) sdk.Error {
+	grantKey, err := accManager.getGrantKeyOfApp(ctx, authorizedUser, grantLevel)
+	if err != nil {
+		return err
+	}
+	grantPubKey, err := accManager.storage.GetGrantPubKey(ctx, me, grantKey)
+	if err != nil {
+		return err
+	}
+	if grantPubKey.Username != authorizedUser || grantPubKey.Permission != grantLevel {
+		return model.ErrGrantPubKeyNotFound()
+	}
+	grantPubKey.Scopes = scopes
+	return accManager.storage.SetGrantPubKey(ctx, me, grantKey, grantPubKey)
+}
+
+// GetGrantScopes - get msg types the authorized app can sign with the granted permission
+func (accManager AccountManager) GetGrantScopes(
+	ctx sdk.Context, me types.AccountKey, authorizedUser types.AccountKey,
+	grantLevel types.Permission) ([]string, sdk.Error) {
+	grantKey, err := accManager.getGrantKeyOfApp(ctx, authorizedUser, grantLevel)
+	if err != nil {
+		return nil, err
+	}
+	grantPubKey, err := accManager.storage.GetGrantPubKey(ctx, me, grantKey)
+	if err != nil {
+		return nil, err
+	}
+	if grantPubKey.Username != authorizedUser || grantPubKey.Permission != grantLevel {
+		return nil, model.ErrGrantPubKeyNotFound()
+	}
+	return grantPubKey.Scopes, nil
+}
+
+// getGrantKeyOfApp - preauth permission is granted to developer's tx key
+// and app permission is granted to developer's app key
+func (accManager AccountManager) getGrantKeyOfApp(
+	ctx sdk.Context, authorizedUser types.AccountKey, grantLevel types.Permission) (crypto.PubKey, sdk.Error) {
+	switch grantLevel {
+	case types.PreAuthorizationPermission:
+		return accManager.GetTransactionKey(ctx, authorizedUser)
+	case types.AppPermission:
+		return accManager.GetAppKey(ctx, authorizedUser)
+	}
+	return nil, ErrUnsupportGrantLevel()
 }
 
 // RevokePermission - revoke permission from a developer
@@ -855,10 +1060,72 @@ func (accManager AccountManager) RevokePermission(
 	return nil
 }
 
+// RevokeAppPermission - revoke all permissions granted to an app, no matter which key is granted
+func (accManager AccountManager) RevokeAppPermission(
+	ctx sdk.Context, me types.AccountKey, app types.AccountKey) sdk.Error {
+	numOfRevoked, err := accManager.sweepGrantPubKeys(ctx, me, func(grantPubKey model.GrantPubKey) bool {
+		return grantPubKey.Username == app
+	})
+	if err != nil {
+		return err
+	}
+	if numOfRevoked == 0 {
+		return model.ErrGrantPubKeyNotFound()
+	}
+	return nil
+}
+
+// RevokeAllPermissions - revoke all permissions granted by user
+func (accManager AccountManager) RevokeAllPermissions(ctx sdk.Context, me types.AccountKey) sdk.Error {
+	_, err := accManager.sweepGrantPubKeys(ctx, me, func(grantPubKey model.GrantPubKey) bool {
+		return true
+	})
+	return err
+}
+
+// GetGrantedApps - get all unexpired permissions granted by user,
+// expired grants are removed during the iteration
+func (accManager AccountManager) GetGrantedApps(
+	ctx sdk.Context, me types.AccountKey) ([]model.GrantPubKey, sdk.Error) {
+	grantedApps := []model.GrantPubKey{}
+	_, err := accManager.sweepGrantPubKeys(ctx, me, func(grantPubKey model.GrantPubKey) bool {
+		grantedApps = append(grantedApps, grantPubKey)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grantedApps, nil
+}
+
+// sweepGrantPubKeys - delete expired grants and grants matched by revoke,
+// returns the number of unexpired grants revoked
+func (accManager AccountManager) sweepGrantPubKeys(
+	ctx sdk.Context, me types.AccountKey, revoke func(model.GrantPubKey) bool) (int, sdk.Error) {
+	expiredKeys := []crypto.PubKey{}
+	revokedKeys := []crypto.PubKey{}
+	err := accManager.storage.IterateGrantPubKeys(
+		ctx, me, func(pubKey crypto.PubKey, grantPubKey model.GrantPubKey) bool {
+			if grantPubKey.ExpiresAt < ctx.BlockHeader().Time.Unix() {
+				expiredKeys = append(expiredKeys, pubKey)
+			} else if revoke(grantPubKey) {
+				revokedKeys = append(revokedKeys, pubKey)
+			}
+			return false
+		})
+	if err != nil {
+		return 0, err
+	}
+	for _, pubKey := range append(expiredKeys, revokedKeys...) {
+		accManager.storage.DeleteGrantPubKey(ctx, me, pubKey)
+	}
+	return len(revokedKeys), nil
+}
+
 // CheckSigningPubKeyOwner - given a public key, check if it is valid for given permission
 func (accManager AccountManager) CheckSigningPubKeyOwner(
 	ctx sdk.Context, me types.AccountKey, signKey crypto.PubKey,
-	permission types.Permission, amount types.Coin) (types.AccountKey, sdk.Error) {
+	permission types.Permission, msgType string, amount types.Coin) (types.AccountKey, sdk.Error) {
 	if !accManager.DoesAccountExist(ctx, me) {
 		return "", ErrAccountNotFound(me)
 	}
@@ -910,6 +1177,9 @@ func (accManager AccountManager) CheckSigningPubKeyOwner(
 		accManager.storage.DeleteGrantPubKey(ctx, me, signKey)
 		return "", ErrGrantKeyExpired(me)
 	}
+	if !isMsgTypeInScopes(grantPubKey.Scopes, msgType) {
+		return "", ErrGrantScopeMismatch(grantPubKey.Username, msgType)
+	}
 	if permission != grantPubKey.Permission {
 		ErrGrantKeyMismatch(grantPubKey.Username)
 	}
@@ -922,11 +1192,13 @@ func (accManager AccountManager) CheckSigningPubKeyOwner(
 			accManager.storage.DeleteGrantPubKey(ctx, me, signKey)
 			return "", ErrPreAuthGrantKeyMismatch(grantPubKey.Username)
 		}
+		RollPreAuthPeriod(grantPubKey, ctx.BlockHeader().Time.Unix())
 		if amount.IsGT(grantPubKey.Amount) {
 			return "", ErrPreAuthAmountInsufficient(grantPubKey.Username, grantPubKey.Amount, amount)
 		}
 		grantPubKey.Amount = grantPubKey.Amount.Minus(amount)
-		if grantPubKey.Amount.IsEqual(types.NewCoinFromInt64(0)) {
+		// periodic grant is kept until expired since allowance will be reset in next period
+		if grantPubKey.PeriodSec == 0 && grantPubKey.Amount.IsEqual(types.NewCoinFromInt64(0)) {
 			accManager.storage.DeleteGrantPubKey(ctx, me, signKey)
 		} else {
 			if err := accManager.storage.SetGrantPubKey(ctx, me, signKey, grantPubKey); err != nil {
@@ -997,23 +1269,482 @@ func (accManager AccountManager) RecoverAccount(
 	return nil
 }
 
-func (accManager AccountManager) updateTXFromPendingCoinDayQueue(
-	ctx sdk.Context, bank *model.AccountBank, pendingCoinDayQueue *model.PendingCoinDayQueue) sdk.Error {
-	// remove expired transaction
-	coinDayParams, err := accManager.paramHolder.GetCoinDayParam(ctx)
+// ChangeAppKey - replace app key only, app permission granted to the old app key is migrated
+func (accManager AccountManager) ChangeAppKey(
+	ctx sdk.Context, username types.AccountKey, newAppPubKey crypto.PubKey) sdk.Error {
+	accInfo, err := accManager.storage.GetInfo(ctx, username)
+	if err != nil {
+		return err
+	}
+	oldAppPubKey := accInfo.AppKey
+	accInfo.AppKey = newAppPubKey
+	if err := accManager.storage.SetInfo(ctx, username, accInfo); err != nil {
+		return err
+	}
+	return accManager.migrateGrantPubKeys(ctx, username, types.AppPermission, oldAppPubKey, newAppPubKey)
+}
+
+// ChangeTransactionKey - replace transaction key only, preauthorization granted to
+// the old transaction key is migrated
+func (accManager AccountManager) ChangeTransactionKey(
+	ctx sdk.Context, username types.AccountKey, newTransactionPubKey crypto.PubKey) sdk.Error {
+	accInfo, err := accManager.storage.GetInfo(ctx, username)
+	if err != nil {
+		return err
+	}
+	oldTransactionPubKey := accInfo.TransactionKey
+	accInfo.TransactionKey = newTransactionPubKey
+	if err := accManager.storage.SetInfo(ctx, username, accInfo); err != nil {
+		return err
+	}
+	return accManager.migrateGrantPubKeys(
+		ctx, username, types.PreAuthorizationPermission, oldTransactionPubKey, newTransactionPubKey)
+}
+
+// migrateGrantPubKeys - move grants other accounts issued to app's old key to the new key,
+// fails if any grantor has already granted the new key
+func (accManager AccountManager) migrateGrantPubKeys(
+	ctx sdk.Context, app types.AccountKey, permission types.Permission,
+	oldPubKey, newPubKey crypto.PubKey) sdk.Error {
+	if oldPubKey == nil || oldPubKey.Equals(newPubKey) {
+		return nil
+	}
+	// grants set before they were indexed by app are indexed on first migration
+	if err := accManager.storage.BuildAppGrantIndex(ctx); err != nil {
+		return err
+	}
+	grantors := []types.AccountKey{}
+	grantPubKeys := []model.GrantPubKey{}
+	if err := accManager.storage.IterateAppGrantPubKeys(ctx, app,
+		func(me types.AccountKey, pubKey crypto.PubKey, grantPubKey model.GrantPubKey) bool {
+			if grantPubKey.Permission == permission && pubKey.Equals(oldPubKey) {
+				grantors = append(grantors, me)
+				grantPubKeys = append(grantPubKeys, grantPubKey)
+			}
+			return false
+		}); err != nil {
+		return err
+	}
+	for _, grantor := range grantors {
+		if _, err := accManager.storage.GetGrantPubKey(ctx, grantor, newPubKey); err == nil {
+			return ErrGrantPubKeyConflict(grantor)
+		}
+	}
+	// store can't be modified during iteration
+	for i, grantor := range grantors {
+		accManager.storage.DeleteGrantPubKey(ctx, grantor, oldPubKey)
+		if err := accManager.storage.SetGrantPubKey(ctx, grantor, newPubKey, &grantPubKeys[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListUsername - list username for sale, price is paid to payee once it's sold
+func (accManager AccountManager) ListUsername(
+	ctx sdk.Context, username, payee types.AccountKey, price types.Coin,
+	clearGrants, clearFollows bool) sdk.Error {
+	if !accManager.DoesAccountExist(ctx, payee) {
+		return ErrAccountNotFound(payee)
+	}
+	if err := accManager.checkUsernameSaleAllowed(ctx, username); err != nil {
+		return err
+	}
+	return accManager.storage.SetUsernameListing(ctx, username, &model.UsernameListing{
+		Price:        price,
+		Payee:        payee,
+		ClearGrants:  clearGrants,
+		ClearFollows: clearFollows,
+		CreatedAt:    ctx.BlockHeader().Time.Unix(),
+	})
+}
+
+// CancelUsernameListing - take username off sale
+func (accManager AccountManager) CancelUsernameListing(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	listing, err := accManager.storage.GetUsernameListing(ctx, username)
 	if err != nil {
 		return err
 	}
+	if listing == nil {
+		return ErrUsernameListingNotFound(username)
+	}
+	accManager.storage.DeleteUsernameListing(ctx, username)
+	return nil
+}
 
+// BuyUsername - buyer pays the listing price to payee and buyer's keys replace all keys
+// of the username. Saving and unclaimed reward of the username are swept to payee,
+// guardians and profile are always cleared since they are trusted by or describe the seller.
+func (accManager AccountManager) BuyUsername(
+	ctx sdk.Context, buyer, username types.AccountKey, price types.Coin,
+	newResetPubKey, newTransactionPubKey, newAppPubKey crypto.PubKey) sdk.Error {
+	listing, err := accManager.storage.GetUsernameListing(ctx, username)
+	if err != nil {
+		return err
+	}
+	if listing == nil {
+		return ErrUsernameListingNotFound(username)
+	}
+	if !listing.Price.IsEqual(price) {
+		return ErrUsernameListingPriceMismatch(username)
+	}
+	if err := accManager.checkUsernameSaleAllowed(ctx, username); err != nil {
+		return err
+	}
+
+	memo := fmt.Sprintf("username sale: %v", username)
+	if err := accManager.MinusSavingCoin(
+		ctx, buyer, price, listing.Payee, memo, types.UsernameSaleOut); err != nil {
+		return err
+	}
+	if err := accManager.AddSavingCoin(
+		ctx, listing.Payee, price, buyer, memo, types.UsernameSaleIn); err != nil {
+		return err
+	}
+
+	// saving and unclaimed reward belong to the seller
+	if err := accManager.sweepAccount(
+		ctx, username, listing.Payee, memo, types.UsernameSaleOut, types.UsernameSaleIn); err != nil {
+		return err
+	}
+
+	if listing.ClearGrants {
+		if err := accManager.RevokeAllPermissions(ctx, username); err != nil {
+			return err
+		}
+	}
+	if listing.ClearFollows {
+		if err := accManager.clearFollows(ctx, username); err != nil {
+			return err
+		}
+	}
+	accManager.storage.DeleteGuardians(ctx, username)
+	accManager.storage.DeleteProfile(ctx, username)
+	accManager.storage.DeleteUsernameListing(ctx, username)
+	return accManager.RecoverAccount(
+		ctx, username, newResetPubKey, newTransactionPubKey, newAppPubKey)
+}
+
+// sweepAccount - move all saving and unclaimed reward of the account to receiver.
+// Saving can't go below minimum balance through MinusSavingCoin, sweep it directly.
+func (accManager AccountManager) sweepAccount(
+	ctx sdk.Context, username, receiver types.AccountKey, memo string,
+	outType, inType types.TransferDetailType) sdk.Error {
+	bank, err := accManager.storage.GetBankFromAccountKey(ctx, username)
+	if err != nil {
+		return err
+	}
+	reward, err := accManager.storage.GetReward(ctx, username)
+	if err != nil {
+		return err
+	}
+	sweep := bank.Saving.Plus(reward.UnclaimReward)
+	if !sweep.IsPositive() {
+		return nil
+	}
+	if err := accManager.AddBalanceHistory(
+		ctx, username, bank.NumOfTx, model.Detail{
+			Amount:     sweep,
+			DetailType: outType,
+			To:         receiver,
+			From:       username,
+			Balance:    types.NewCoinFromInt64(0),
+			CreatedAt:  ctx.BlockHeader().Time.Unix(),
+			Memo:       memo,
+		}); err != nil {
+		return err
+	}
+	bank.NumOfTx++
+	bank.Saving = types.NewCoinFromInt64(0)
+	bank.CoinDay = types.NewCoinFromInt64(0)
+	if err := accManager.storage.SetBankFromAccountKey(ctx, username, bank); err != nil {
+		return err
+	}
+	if err := accManager.storage.SetPendingCoinDayQueue(
+		ctx, username, &model.PendingCoinDayQueue{
+			LastUpdatedAt: ctx.BlockHeader().Time.Unix(),
+			TotalCoinDay:  sdk.ZeroRat(),
+			TotalCoin:     types.NewCoinFromInt64(0),
+		}); err != nil {
+		return err
+	}
+	reward.UnclaimReward = types.NewCoinFromInt64(0)
+	if err := accManager.storage.SetReward(ctx, username, reward); err != nil {
+		return err
+	}
+	return accManager.AddSavingCoin(ctx, receiver, sweep, username, memo, inType)
+}
+
+// GetUsernameListing - get sale listing of the username, nil if it isn't for sale
+func (accManager AccountManager) GetUsernameListing(
+	ctx sdk.Context, username types.AccountKey) (*model.UsernameListing, sdk.Error) {
+	return accManager.storage.GetUsernameListing(ctx, username)
+}
+
+// CloseAccount - sweep saving and unclaimed reward to receiver, then remove the account
+// with its grants and follow relations. The username is retired and can't be registered
+// again, balance and reward history are kept.
+func (accManager AccountManager) CloseAccount(
+	ctx sdk.Context, username, receiver types.AccountKey) sdk.Error {
+	if !accManager.DoesAccountExist(ctx, receiver) {
+		return ErrAccountNotFound(receiver)
+	}
+	if err := accManager.checkAccountCloseAllowed(ctx, username); err != nil {
+		return err
+	}
+	if err := accManager.sweepAccount(
+		ctx, username, receiver, fmt.Sprintf("account closed: %v", username),
+		types.AccountCloseOut, types.AccountCloseIn); err != nil {
+		return err
+	}
+
+	if err := accManager.RevokeAllPermissions(ctx, username); err != nil {
+		return err
+	}
+	if err := accManager.clearFollows(ctx, username); err != nil {
+		return err
+	}
+	accManager.storage.DeleteGuardians(ctx, username)
+	accManager.storage.DeleteProfile(ctx, username)
+	accManager.storage.DeleteUsernameListing(ctx, username)
+	accManager.storage.DeleteAccount(ctx, username)
+	return accManager.storage.SetRetiredAccount(ctx, username, &model.RetiredAccount{
+		Receiver:  receiver,
+		RetiredAt: ctx.BlockHeader().Time.Unix(),
+	})
+}
+
+// IsUsernameRetired - returns true when the username belongs to a closed account
+func (accManager AccountManager) IsUsernameRetired(ctx sdk.Context, username types.AccountKey) bool {
+	return accManager.storage.IsUsernameRetired(ctx, username)
+}
+
+// checkAccountCloseAllowed - frozen money would be returned to the account later
+// and recovery in progress means the keys may be compromised
+func (accManager AccountManager) checkAccountCloseAllowed(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	bank, err := accManager.storage.GetBankFromAccountKey(ctx, username)
+	if err != nil {
+		return err
+	}
+	if len(bank.FrozenMoneyList) != 0 {
+		return ErrAccountCloseBlocked(username, "outstanding frozen money")
+	}
+	request, err := accManager.storage.GetRecoveryRequest(ctx, username)
+	if err != nil {
+		return err
+	}
+	if request != nil {
+		return ErrAccountCloseBlocked(username, "recovery in progress")
+	}
+	return nil
+}
+
+// checkUsernameSaleAllowed - frozen money and ongoing recovery belong to the seller
+func (accManager AccountManager) checkUsernameSaleAllowed(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	bank, err := accManager.storage.GetBankFromAccountKey(ctx, username)
+	if err != nil {
+		return err
+	}
+	if len(bank.FrozenMoneyList) != 0 {
+		return ErrUsernameSaleBlocked(username, "outstanding frozen money")
+	}
+	request, err := accManager.storage.GetRecoveryRequest(ctx, username)
+	if err != nil {
+		return err
+	}
+	if request != nil {
+		return ErrUsernameSaleBlocked(username, "recovery in progress")
+	}
+	return nil
+}
+
+// clearFollows - remove all follow relations of the account in both directions
+func (accManager AccountManager) clearFollows(ctx sdk.Context, username types.AccountKey) sdk.Error {
+	followers, err := accManager.storage.GetFollowers(ctx, username)
+	if err != nil {
+		return err
+	}
+	for _, follower := range followers {
+		accManager.storage.RemoveFollowerMeta(ctx, username, follower.FollowerName)
+		accManager.storage.RemoveFollowingMeta(ctx, follower.FollowerName, username)
+	}
+	followings, err := accManager.storage.GetFollowings(ctx, username)
+	if err != nil {
+		return err
+	}
+	for _, following := range followings {
+		accManager.storage.RemoveFollowingMeta(ctx, username, following.FollowingName)
+		accManager.storage.RemoveFollowerMeta(ctx, following.FollowingName, username)
+	}
+	return nil
+}
+
+// SetGuardians - designate guardians who can approve social recovery of the account
+func (accManager AccountManager) SetGuardians(
+	ctx sdk.Context, username types.AccountKey, guardians []types.AccountKey, threshold int64) sdk.Error {
+	if !accManager.DoesAccountExist(ctx, username) {
+		return ErrAccountNotFound(username)
+	}
+	for _, guardian := range guardians {
+		if !accManager.DoesAccountExist(ctx, guardian) {
+			return ErrAccountNotFound(guardian)
+		}
+	}
+	// guardians can't be changed under an ongoing recovery, it has to be cancelled first
+	request, err := accManager.storage.GetRecoveryRequest(ctx, username)
+	if err != nil {
+		return err
+	}
+	if request != nil {
+		return ErrRecoveryInProgress(username)
+	}
+	return accManager.storage.SetGuardians(
+		ctx, username, &model.Guardians{Guardians: guardians, Threshold: threshold})
+}
+
+// ApproveRecovery - guardian approves a new key set for the account, approving a different
+// key set restarts the approval. Once threshold is reached the recovery is scheduled and
+// the execution time is returned, otherwise returns zero.
+func (accManager AccountManager) ApproveRecovery(
+	ctx sdk.Context, username, guardian types.AccountKey,
+	newResetPubKey, newTransactionPubKey, newAppPubKey crypto.PubKey) (int64, sdk.Error) {
+	guardians, err := accManager.storage.GetGuardians(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	if guardians == nil || !isAccountKeyIn(guardian, guardians.Guardians) {
+		return 0, ErrNotGuardian(guardian, username)
+	}
+	request, err := accManager.storage.GetRecoveryRequest(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	if request != nil && request.ExecuteAt != 0 {
+		return 0, ErrRecoveryInProgress(username)
+	}
+	if request == nil ||
+		!request.NewResetKey.Equals(newResetPubKey) ||
+		!request.NewTransactionKey.Equals(newTransactionPubKey) ||
+		!request.NewAppKey.Equals(newAppPubKey) {
+		request = &model.RecoveryRequest{
+			NewResetKey:       newResetPubKey,
+			NewTransactionKey: newTransactionPubKey,
+			NewAppKey:         newAppPubKey,
+			Approvals:         []types.AccountKey{},
+			CreatedAt:         ctx.BlockHeader().Time.Unix(),
+		}
+	}
+	if isAccountKeyIn(guardian, request.Approvals) {
+		return 0, ErrRecoveryAlreadyApproved(guardian)
+	}
+	request.Approvals = append(request.Approvals, guardian)
+	if err := accManager.addRecoveryHistory(
+		ctx, username, guardian, fmt.Sprintf("recovery approved by %v", guardian)); err != nil {
+		return 0, err
+	}
+	if int64(len(request.Approvals)) >= guardians.Threshold {
+		accParams, err := accManager.paramHolder.GetAccountParam(ctx)
+		if err != nil {
+			return 0, err
+		}
+		request.ExecuteAt = ctx.BlockHeader().Time.Unix() + accParams.SocialRecoveryDelaySec
+		request.ResetKey, err = accManager.GetResetKey(ctx, username)
+		if err != nil {
+			return 0, err
+		}
+		if err := accManager.addRecoveryHistory(
+			ctx, username, guardian, fmt.Sprintf("recovery scheduled at %v", request.ExecuteAt)); err != nil {
+			return 0, err
+		}
+	}
+	if err := accManager.storage.SetRecoveryRequest(ctx, username, request); err != nil {
+		return 0, err
+	}
+	return request.ExecuteAt, nil
+}
+
+// CancelRecovery - remove pending recovery request of the account, returns the removed request
+func (accManager AccountManager) CancelRecovery(
+	ctx sdk.Context, username types.AccountKey) (*model.RecoveryRequest, sdk.Error) {
+	request, err := accManager.storage.GetRecoveryRequest(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrRecoveryRequestNotFound(username)
+	}
+	accManager.storage.DeleteRecoveryRequest(ctx, username)
+	if err := accManager.addRecoveryHistory(ctx, username, username, "recovery cancelled"); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ExecuteRecovery - replace keys with the recovery request scheduled at executeAt,
+// stale schedule is ignored
+func (accManager AccountManager) ExecuteRecovery(
+	ctx sdk.Context, username types.AccountKey, executeAt int64) sdk.Error {
+	request, err := accManager.storage.GetRecoveryRequest(ctx, username)
+	if err != nil {
+		return err
+	}
+	if request == nil || request.ExecuteAt == 0 || request.ExecuteAt != executeAt {
+		return nil
+	}
+	resetKey, err := accManager.GetResetKey(ctx, username)
+	if err != nil {
+		return err
+	}
+	if request.ResetKey != nil && !resetKey.Equals(request.ResetKey) {
+		accManager.storage.DeleteRecoveryRequest(ctx, username)
+		return accManager.addRecoveryHistory(ctx, username, username, "recovery dropped, reset key changed")
+	}
+	if err := accManager.RecoverAccount(
+		ctx, username, request.NewResetKey, request.NewTransactionKey, request.NewAppKey); err != nil {
+		return err
+	}
+	accManager.storage.DeleteRecoveryRequest(ctx, username)
+	return accManager.addRecoveryHistory(ctx, username, username, "recovery executed")
+}
+
+// addRecoveryHistory - record social recovery progress as zero amount balance history
+func (accManager AccountManager) addRecoveryHistory(
+	ctx sdk.Context, username, from types.AccountKey, memo string) sdk.Error {
+	bank, err := accManager.storage.GetBankFromAccountKey(ctx, username)
+	if err != nil {
+		return err
+	}
+	if err := accManager.AddBalanceHistory(ctx, username, bank.NumOfTx,
+		model.Detail{
+			Amount:     types.NewCoinFromInt64(0),
+			DetailType: types.SocialRecovery,
+			To:         username,
+			From:       from,
+			Balance:    bank.Saving,
+			CreatedAt:  ctx.BlockHeader().Time.Unix(),
+			Memo:       memo,
+		}); err != nil {
+		return err
+	}
+	bank.NumOfTx++
+	return accManager.storage.SetBankFromAccountKey(ctx, username, bank)
+}
+
+func (accManager AccountManager) updateTXFromPendingCoinDayQueue(
+	ctx sdk.Context, bank *model.AccountBank, pendingCoinDayQueue *model.PendingCoinDayQueue) sdk.Error {
+	// each pending coin day recovers within its own [StartTime, EndTime) window,
+	// so a coin day param change only affects coin added after the change
 	currentTimeSlot := ctx.BlockHeader().Time.Unix() / types.CoinDayRecordIntervalSec * types.CoinDayRecordIntervalSec
-	for len(pendingCoinDayQueue.PendingCoinDays) > 0 {
-		pendingCoinDay := pendingCoinDayQueue.PendingCoinDays[0]
+	// end time is not monotonic after a param change, check every transaction in queue
+	idx := 0
+	for idx < len(pendingCoinDayQueue.PendingCoinDays) {
+		pendingCoinDay := pendingCoinDayQueue.PendingCoinDays[idx]
 		if pendingCoinDay.EndTime <= currentTimeSlot {
 			// remove the transaction from queue, clean coin day coin in queue and minus total coin
 			// coinDayRatioOfThisTransaction means the ratio of coin day of this transaction was added last time
 			coinDayRatioOfThisTransaction := sdk.NewRat(
 				pendingCoinDayQueue.LastUpdatedAt-pendingCoinDay.StartTime,
-				coinDayParams.SecondsToRecoverCoinDay)
+				pendingCoinDay.EndTime-pendingCoinDay.StartTime)
 			// remote the coin day in the queue of this transaction
 			pendingCoinDayQueue.TotalCoinDay =
 				pendingCoinDayQueue.TotalCoinDay.Sub(
@@ -1023,27 +1754,26 @@ func (accManager AccountManager) updateTXFromPendingCoinDayQueue(
 
 			pendingCoinDayQueue.TotalCoin = pendingCoinDayQueue.TotalCoin.Minus(pendingCoinDay.Coin)
 
-			pendingCoinDayQueue.PendingCoinDays = pendingCoinDayQueue.PendingCoinDays[1:]
-		} else {
-			break
+			pendingCoinDayQueue.PendingCoinDays = append(
+				pendingCoinDayQueue.PendingCoinDays[:idx], pendingCoinDayQueue.PendingCoinDays[idx+1:]...)
+			continue
 		}
+		idx++
 	}
 	if len(pendingCoinDayQueue.PendingCoinDays) == 0 {
 		pendingCoinDayQueue.TotalCoin = types.NewCoinFromInt64(0)
 		pendingCoinDayQueue.TotalCoinDay = sdk.ZeroRat()
 	} else {
 		// update all pending coin day at the same time
-		// recoverRatio = (currentTime - lastUpdateTime)/totalRecoverSeconds
-		recoverRatio := sdk.NewRat(
-			currentTimeSlot-pendingCoinDayQueue.LastUpdatedAt,
-			coinDayParams.SecondsToRecoverCoinDay)
-
-		if err != nil {
-			return err
+		// recoverRatio = (currentTime - lastUpdateTime)/(endTime - startTime)
+		for _, pendingCoinDay := range pendingCoinDayQueue.PendingCoinDays {
+			recoverRatio := sdk.NewRat(
+				currentTimeSlot-pendingCoinDayQueue.LastUpdatedAt,
+				pendingCoinDay.EndTime-pendingCoinDay.StartTime)
+			pendingCoinDayQueue.TotalCoinDay =
+				pendingCoinDayQueue.TotalCoinDay.Add(
+					recoverRatio.Mul(pendingCoinDay.Coin.ToRat()))
 		}
-		pendingCoinDayQueue.TotalCoinDay =
-			pendingCoinDayQueue.TotalCoinDay.Add(
-				recoverRatio.Mul(pendingCoinDayQueue.TotalCoin.ToRat()))
 	}
 
 	pendingCoinDayQueue.LastUpdatedAt = currentTimeSlot
@@ -1113,3 +1843,26 @@ func max(a, b int64) int64 {
 	}
 	return a
 }
+
+// isMsgTypeInScopes - check if msg type is allowed by grant scopes, empty scopes allow all msgs
+func isMsgTypeInScopes(scopes []string, msgType string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		if scope == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// isAccountKeyIn - check if username is in the list
+func isAccountKeyIn(username types.AccountKey, usernames []types.AccountKey) bool {
+	for _, name := range usernames {
+		if name == username {
+			return true
+		}
+	}
+	return false
+}
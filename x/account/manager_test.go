@@ -1,9 +1,11 @@
 package account
 
 import (
+	"sort"
 	"testing"
 	"time"
 
+	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/account/model"
 
@@ -1018,6 +1020,7 @@ func TestCreateAccountNormalCase(t *testing.T) {
 			ResetKey:       resetPriv.PubKey(),
 			TransactionKey: txPriv.PubKey(),
 			AppKey:         appPriv.PubKey(),
+			Referrer:       accountReferrer,
 		}
 		checkAccountInfo(t, ctx, tc.testName, tc.username, accInfo)
 		accMeta := model.AccountMeta{
@@ -1117,6 +1120,7 @@ func TestCreateAccountWithLargeRegisterFee(t *testing.T) {
 		ResetKey:       resetPriv.PubKey(),
 		TransactionKey: txPriv.PubKey(),
 		AppKey:         appPriv.PubKey(),
+		Referrer:       accountReferrer,
 	}
 	checkAccountInfo(t, ctx, testName, accKey, accInfo)
 
@@ -1412,6 +1416,100 @@ func TestCoinDaySize(t *testing.T) {
 	assert.Equal(t, 504, len(pendingCoinDayQueue.PendingCoinDays))
 }
 
+func TestCoinDayParamChange(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	accParam, _ := am.paramHolder.GetAccountParam(ctx)
+	accKey := types.AccountKey("accKey")
+	createTestAccount(ctx, am, string(accKey))
+
+	coinDayParam, _ := am.paramHolder.GetCoinDayParam(ctx)
+	oldRecoverSec := coinDayParam.SecondsToRecoverCoinDay
+	newRecoverSec := oldRecoverSec / 7
+	baseTime := ctx.BlockHeader().Time.Unix() / types.CoinDayRecordIntervalSec * types.CoinDayRecordIntervalSec
+
+	// first deposit recovers under the original param
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime, 0)})
+	err := am.AddSavingCoin(ctx, accKey, c700, "", "", types.TransferIn)
+	assert.Nil(t, err)
+
+	// shorten the recover period mid-chain
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime+newRecoverSec, 0)})
+	err = param.ChangeParamEvent{
+		Param: param.CoinDayParam{SecondsToRecoverCoinDay: newRecoverSec},
+	}.Execute(ctx, am.paramHolder, nil)
+	assert.Nil(t, err)
+	err = am.AddSavingCoin(ctx, accKey, c100, "", "", types.TransferIn)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName              string
+		atWhen                int64
+		expectCoinDay         types.Coin
+		expectCoinDayInBank   types.Coin
+		expectPendingCoinDays []model.PendingCoinDay
+	}{
+		{
+			testName:            "both deposits are still charging",
+			atWhen:              baseTime + newRecoverSec,
+			expectCoinDay:       accParam.RegisterFee.Plus(c100),
+			expectCoinDayInBank: accParam.RegisterFee,
+			expectPendingCoinDays: []model.PendingCoinDay{
+				{StartTime: baseTime, EndTime: baseTime + oldRecoverSec, Coin: c700},
+				{StartTime: baseTime + newRecoverSec, EndTime: baseTime + 2*newRecoverSec, Coin: c100},
+			},
+		},
+		{
+			testName:            "second deposit is fully charged under new param",
+			atWhen:              baseTime + 2*newRecoverSec,
+			expectCoinDay:       accParam.RegisterFee.Plus(c100).Plus(c200),
+			expectCoinDayInBank: accParam.RegisterFee.Plus(c100),
+			expectPendingCoinDays: []model.PendingCoinDay{
+				{StartTime: baseTime, EndTime: baseTime + oldRecoverSec, Coin: c700},
+			},
+		},
+		{
+			testName:              "first deposit is fully charged under original param",
+			atWhen:                baseTime + oldRecoverSec,
+			expectCoinDay:         accParam.RegisterFee.Plus(c100).Plus(c700),
+			expectCoinDayInBank:   accParam.RegisterFee.Plus(c100).Plus(c700),
+			expectPendingCoinDays: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(tc.atWhen, 0)})
+		coinDay, err := am.GetCoinDay(ctx, accKey)
+		if err != nil {
+			t.Errorf("%s: failed to get coin day, got err %v", tc.testName, err)
+		}
+		if !tc.expectCoinDay.IsEqual(coinDay) {
+			t.Errorf("%s: diff coin day, got %v, want %v", tc.testName, coinDay, tc.expectCoinDay)
+		}
+
+		bank, err := am.storage.GetBankFromAccountKey(ctx, accKey)
+		if err != nil {
+			t.Errorf("%s: failed to get bank, got err %v", tc.testName, err)
+		}
+		if !tc.expectCoinDayInBank.IsEqual(bank.CoinDay) {
+			t.Errorf("%s: diff coin day in bank, got %v, want %v", tc.testName, bank.CoinDay, tc.expectCoinDayInBank)
+		}
+
+		pendingCoinDayQueue, err := am.storage.GetPendingCoinDayQueue(ctx, accKey)
+		if err != nil {
+			t.Errorf("%s: failed to get pending coin day queue, got err %v", tc.testName, err)
+		}
+		if len(tc.expectPendingCoinDays) == 0 {
+			assert.Equal(t, 0, len(pendingCoinDayQueue.PendingCoinDays), tc.testName)
+		} else {
+			assert.Equal(t, tc.expectPendingCoinDays, pendingCoinDayQueue.PendingCoinDays, tc.testName)
+		}
+		if types.RatToCoin(pendingCoinDayQueue.TotalCoinDay).IsGT(pendingCoinDayQueue.TotalCoin) {
+			t.Errorf("%s: pending coin day %v exceeds pending coin %v",
+				tc.testName, pendingCoinDayQueue.TotalCoinDay, pendingCoinDayQueue.TotalCoin)
+		}
+	}
+}
+
 func TestAddIncomeAndReward(t *testing.T) {
 	testName := "TestAddIncomeAndReward"
 
@@ -1667,6 +1765,41 @@ func TestCheckUserTPSCapacity(t *testing.T) {
 	}
 }
 
+func TestGetTransactionCapacityCost(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+
+	testCases := []struct {
+		testName         string
+		tpsCapacityRatio sdk.Rat
+		expectCost       types.Coin
+	}{
+		{
+			testName:         "zero tps",
+			tpsCapacityRatio: sdk.ZeroRat(),
+			expectCost:       types.NewCoinFromInt64(0),
+		},
+		{
+			testName:         "half capacity tps",
+			tpsCapacityRatio: sdk.NewRat(1, 2),
+			expectCost:       types.NewCoinFromInt64(types.Decimals / 2),
+		},
+		{
+			testName:         "full capacity tps",
+			tpsCapacityRatio: sdk.OneRat(),
+			expectCost:       types.NewCoinFromInt64(1 * types.Decimals),
+		},
+	}
+	for _, tc := range testCases {
+		cost, err := am.GetTransactionCapacityCost(ctx, tc.tpsCapacityRatio)
+		if err != nil {
+			t.Errorf("%s: failed to get transaction capacity cost, got err %v", tc.testName, err)
+		}
+		if !cost.IsEqual(tc.expectCost) {
+			t.Errorf("%s: diff cost, got %v, want %v", tc.testName, cost, tc.expectCost)
+		}
+	}
+}
+
 func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 	testName := "TestCheckAuthenticatePubKeyOwner"
 
@@ -1676,6 +1809,7 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 	appPermissionUser := types.AccountKey("user2")
 	preAuthPermissionUser := types.AccountKey("user3")
 	unauthUser := types.AccountKey("user4")
+	scopedAppPermissionUser := types.AccountKey("user5")
 	resetKey := secp256k1.GenPrivKey()
 	transactionKey := secp256k1.GenPrivKey()
 	appKey := secp256k1.GenPrivKey()
@@ -1686,17 +1820,24 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 	_, unauthTxPriv, authAppPriv := createTestAccount(ctx, am, string(appPermissionUser))
 	_, authTxPriv, unauthAppPriv := createTestAccount(ctx, am, string(preAuthPermissionUser))
 	_, unauthPriv1, unauthPriv2 := createTestAccount(ctx, am, string(unauthUser))
+	_, _, scopedAppPriv := createTestAccount(ctx, am, string(scopedAppPermissionUser))
 
-	err := am.AuthorizePermission(ctx, user1, appPermissionUser, 100, types.AppPermission, types.NewCoinFromInt64(0))
+	err := am.AuthorizePermission(ctx, user1, appPermissionUser, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
 	if err != nil {
 		t.Errorf("%s: failed to authorize app permission, got err %v", testName, err)
 	}
 
 	preAuthAmount := types.NewCoinFromInt64(100)
-	err = am.AuthorizePermission(ctx, user1, preAuthPermissionUser, 100, types.PreAuthorizationPermission, preAuthAmount)
+	err = am.AuthorizePermission(ctx, user1, preAuthPermissionUser, 100, types.PreAuthorizationPermission, preAuthAmount, nil)
 	if err != nil {
 		t.Errorf("%s: failed to authorize preauth permission, got err %v", testName, err)
 	}
+
+	err = am.AuthorizePermission(
+		ctx, user1, scopedAppPermissionUser, 100, types.AppPermission, types.NewCoinFromInt64(0), []string{"ViewMsg"})
+	if err != nil {
+		t.Errorf("%s: failed to authorize scoped app permission, got err %v", testName, err)
+	}
 	baseTime := ctx.BlockHeader().Time
 
 	testCases := []struct {
@@ -1706,6 +1847,7 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 		atWhen            time.Time
 		amount            types.Coin
 		permission        types.Permission
+		msgType           string
 		expectUser        types.AccountKey
 		expectResult      sdk.Error
 		expectGrantPubKey *model.GrantPubKey
@@ -1818,11 +1960,12 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 			expectUser:   appPermissionUser,
 			expectResult: nil,
 			expectGrantPubKey: &model.GrantPubKey{
-				Username:   appPermissionUser,
-				Permission: types.AppPermission,
-				CreatedAt:  baseTime.Unix(),
-				ExpiresAt:  baseTime.Unix() + 100,
-				Amount:     types.NewCoinFromInt64(0),
+				Username:    appPermissionUser,
+				Permission:  types.AppPermission,
+				CreatedAt:   baseTime.Unix(),
+				ExpiresAt:   baseTime.Unix() + 100,
+				Amount:      types.NewCoinFromInt64(0),
+				PeriodLimit: types.NewCoinFromInt64(0),
 			},
 		},
 		{
@@ -1868,11 +2011,12 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 			expectUser:   preAuthPermissionUser,
 			expectResult: nil,
 			expectGrantPubKey: &model.GrantPubKey{
-				Username:   preAuthPermissionUser,
-				Permission: types.PreAuthorizationPermission,
-				CreatedAt:  baseTime.Unix(),
-				ExpiresAt:  baseTime.Unix() + 100,
-				Amount:     preAuthAmount.Minus(types.NewCoinFromInt64(10)),
+				Username:    preAuthPermissionUser,
+				Permission:  types.PreAuthorizationPermission,
+				CreatedAt:   baseTime.Unix(),
+				ExpiresAt:   baseTime.Unix() + 100,
+				Amount:      preAuthAmount.Minus(types.NewCoinFromInt64(10)),
+				PeriodLimit: types.NewCoinFromInt64(0),
 			},
 		},
 		{
@@ -1909,11 +2053,12 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 				preAuthPermissionUser, preAuthAmount.Minus(types.NewCoinFromInt64(10)),
 				preAuthAmount),
 			expectGrantPubKey: &model.GrantPubKey{
-				Username:   preAuthPermissionUser,
-				Permission: types.PreAuthorizationPermission,
-				CreatedAt:  baseTime.Unix(),
-				ExpiresAt:  baseTime.Unix() + 100,
-				Amount:     preAuthAmount.Minus(types.NewCoinFromInt64(10)),
+				Username:    preAuthPermissionUser,
+				Permission:  types.PreAuthorizationPermission,
+				CreatedAt:   baseTime.Unix(),
+				ExpiresAt:   baseTime.Unix() + 100,
+				Amount:      preAuthAmount.Minus(types.NewCoinFromInt64(10)),
+				PeriodLimit: types.NewCoinFromInt64(0),
 			},
 		},
 		{
@@ -1925,11 +2070,50 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 			expectUser:   "",
 			expectResult: nil,
 			expectGrantPubKey: &model.GrantPubKey{
-				Username:   appPermissionUser,
-				Permission: types.AppPermission,
-				CreatedAt:  baseTime.Unix(),
-				ExpiresAt:  baseTime.Unix() + 100,
-				Amount:     types.NewCoinFromInt64(0),
+				Username:    appPermissionUser,
+				Permission:  types.AppPermission,
+				CreatedAt:   baseTime.Unix(),
+				ExpiresAt:   baseTime.Unix() + 100,
+				Amount:      types.NewCoinFromInt64(0),
+				PeriodLimit: types.NewCoinFromInt64(0),
+			},
+		},
+		{
+			testName:     "check scoped app permission with msg in scopes",
+			checkUser:    user1,
+			checkPubKey:  scopedAppPriv.PubKey(),
+			atWhen:       baseTime,
+			permission:   types.AppPermission,
+			msgType:      "ViewMsg",
+			expectUser:   scopedAppPermissionUser,
+			expectResult: nil,
+			expectGrantPubKey: &model.GrantPubKey{
+				Username:    scopedAppPermissionUser,
+				Permission:  types.AppPermission,
+				CreatedAt:   baseTime.Unix(),
+				ExpiresAt:   baseTime.Unix() + 100,
+				Amount:      types.NewCoinFromInt64(0),
+				PeriodLimit: types.NewCoinFromInt64(0),
+				Scopes:      []string{"ViewMsg"},
+			},
+		},
+		{
+			testName:     "check scoped app permission with msg out of scopes",
+			checkUser:    user1,
+			checkPubKey:  scopedAppPriv.PubKey(),
+			atWhen:       baseTime,
+			permission:   types.AppPermission,
+			msgType:      "CreatePostMsg",
+			expectUser:   "",
+			expectResult: ErrGrantScopeMismatch(scopedAppPermissionUser, "CreatePostMsg"),
+			expectGrantPubKey: &model.GrantPubKey{
+				Username:    scopedAppPermissionUser,
+				Permission:  types.AppPermission,
+				CreatedAt:   baseTime.Unix(),
+				ExpiresAt:   baseTime.Unix() + 100,
+				Amount:      types.NewCoinFromInt64(0),
+				PeriodLimit: types.NewCoinFromInt64(0),
+				Scopes:      []string{"ViewMsg"},
 			},
 		},
 		{
@@ -1957,7 +2141,8 @@ func TestCheckAuthenticatePubKeyOwner(t *testing.T) {
 
 	for _, tc := range testCases {
 		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: tc.atWhen})
-		grantPubKey, err := am.CheckSigningPubKeyOwner(ctx, tc.checkUser, tc.checkPubKey, tc.permission, tc.amount)
+		grantPubKey, err := am.CheckSigningPubKeyOwner(
+			ctx, tc.checkUser, tc.checkPubKey, tc.permission, tc.msgType, tc.amount)
 		if tc.expectResult == nil {
 			if tc.expectUser != grantPubKey {
 				t.Errorf("%s: diff key owner,  got %v, want %v", tc.testName, grantPubKey, tc.expectUser)
@@ -2000,17 +2185,17 @@ func TestRevokePermission(t *testing.T) {
 
 	baseTime := ctx.BlockHeader().Time
 
-	err := am.AuthorizePermission(ctx, user1, userWithAppPermission, 100, types.AppPermission, types.NewCoinFromInt64(0))
+	err := am.AuthorizePermission(ctx, user1, userWithAppPermission, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
 	if err != nil {
 		t.Errorf("%s: failed to authorize user1 app permission to user with only app permission, got err %v", testName, err)
 	}
 
-	err = am.AuthorizePermission(ctx, user2, userWithAppPermission, 100, types.AppPermission, types.NewCoinFromInt64(0))
+	err = am.AuthorizePermission(ctx, user2, userWithAppPermission, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
 	if err != nil {
 		t.Errorf("%s: failed to authorize user2 app permission to user with only app permission, got err %v", testName, err)
 	}
 
-	err = am.AuthorizePermission(ctx, user1, userWithPreAuthPermission, 100, types.PreAuthorizationPermission, types.NewCoinFromInt64(100))
+	err = am.AuthorizePermission(ctx, user1, userWithPreAuthPermission, 100, types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
 	if err != nil {
 		t.Errorf("%s: failed to authorize user1 preauth permission to user with preauth permission, got err %v", testName, err)
 	}
@@ -2060,6 +2245,310 @@ func TestRevokePermission(t *testing.T) {
 	}
 }
 
+
+func TestPeriodicPreAuthorization(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	app := types.AccountKey("app")
+	createTestAccount(ctx, am, string(user1))
+	_, appTxPriv, _ := createTestAccount(ctx, am, string(app))
+
+	baseTime := ctx.BlockHeader().Time
+	err := am.AuthorizePeriodicPermission(ctx, user1, app, 1000, 100, types.NewCoinFromInt64(10))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName        string
+		atWhen          time.Time
+		amount          types.Coin
+		expectResult    sdk.Error
+		expectAllowance types.Coin
+	}{
+		{
+			testName:        "spend in first period",
+			atWhen:          baseTime,
+			amount:          types.NewCoinFromInt64(6),
+			expectResult:    nil,
+			expectAllowance: types.NewCoinFromInt64(4),
+		},
+		{
+			testName:        "spend exceeds allowance of first period",
+			atWhen:          baseTime.Add(time.Duration(99) * time.Second),
+			amount:          types.NewCoinFromInt64(5),
+			expectResult:    ErrPreAuthAmountInsufficient(app, types.NewCoinFromInt64(4), types.NewCoinFromInt64(5)),
+			expectAllowance: types.NewCoinFromInt64(4),
+		},
+		{
+			testName:        "spend all allowance of first period",
+			atWhen:          baseTime.Add(time.Duration(99) * time.Second),
+			amount:          types.NewCoinFromInt64(4),
+			expectResult:    nil,
+			expectAllowance: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:        "allowance is reset in second period",
+			atWhen:          baseTime.Add(time.Duration(100) * time.Second),
+			amount:          types.NewCoinFromInt64(5),
+			expectResult:    nil,
+			expectAllowance: types.NewCoinFromInt64(5),
+		},
+		{
+			testName:        "allowance is reset after skipping periods",
+			atWhen:          baseTime.Add(time.Duration(550) * time.Second),
+			amount:          types.NewCoinFromInt64(10),
+			expectResult:    nil,
+			expectAllowance: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:        "rolling period keeps start time of grant",
+			atWhen:          baseTime.Add(time.Duration(599) * time.Second),
+			amount:          types.NewCoinFromInt64(1),
+			expectResult:    ErrPreAuthAmountInsufficient(app, types.NewCoinFromInt64(0), types.NewCoinFromInt64(1)),
+			expectAllowance: types.NewCoinFromInt64(0),
+		},
+		{
+			testName:        "allowance is reset in next period",
+			atWhen:          baseTime.Add(time.Duration(600) * time.Second),
+			amount:          types.NewCoinFromInt64(0),
+			expectResult:    nil,
+			expectAllowance: types.NewCoinFromInt64(10),
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: tc.atWhen})
+		_, err := am.CheckSigningPubKeyOwner(
+			ctx, user1, appTxPriv.PubKey(), types.PreAuthorizationPermission, "DonateMsg", tc.amount)
+		if !assert.Equal(t, tc.expectResult, err) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, err, tc.expectResult)
+		}
+		allowance, err := am.GetPreAuthAllowance(ctx, user1, app)
+		assert.Nil(t, err)
+		if !assert.Equal(t, tc.expectAllowance, allowance) {
+			t.Errorf("%s: diff allowance, got %v, want %v", tc.testName, allowance, tc.expectAllowance)
+		}
+	}
+
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: baseTime.Add(time.Duration(1001) * time.Second)})
+	_, err = am.GetPreAuthAllowance(ctx, user1, app)
+	assert.Equal(t, ErrGrantKeyExpired(user1), err)
+}
+
+func TestShareRewardWithReferrer(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	accParam, err := am.paramHolder.GetAccountParam(ctx)
+	assert.Nil(t, err)
+	// user0 is created before referrer exists
+	user0 := types.AccountKey("user0")
+	user1 := types.AccountKey("user1")
+	createTestAccount(ctx, am, string(user0))
+	createTestAccount(ctx, am, string(accountReferrer))
+	createTestAccount(ctx, am, string(user1))
+
+	baseTime := ctx.BlockHeader().Time
+	testCases := []struct {
+		testName             string
+		user                 types.AccountKey
+		reward               types.Coin
+		atWhen               time.Time
+		expectReward         types.Coin
+		expectReferrerSaving types.Coin
+		expectTotalReward    types.Coin
+	}{
+		{
+			testName:             "referrer gets share of reward",
+			user:                 user1,
+			reward:               types.NewCoinFromInt64(100),
+			atWhen:               baseTime,
+			expectReward:         types.NewCoinFromInt64(95),
+			expectReferrerSaving: accParam.RegisterFee.Plus(types.NewCoinFromInt64(5)),
+			expectTotalReward:    types.NewCoinFromInt64(5),
+		},
+		{
+			testName:             "share is rounded at the end of referral period",
+			user:                 user1,
+			reward:               types.NewCoinFromInt64(8),
+			atWhen:               baseTime.Add(time.Duration(accParam.ReferralRewardPeriodSec) * time.Second),
+			expectReward:         types.NewCoinFromInt64(8),
+			expectReferrerSaving: accParam.RegisterFee.Plus(types.NewCoinFromInt64(5)),
+			expectTotalReward:    types.NewCoinFromInt64(5),
+		},
+		{
+			testName:             "referral reward period is over",
+			user:                 user1,
+			reward:               types.NewCoinFromInt64(100),
+			atWhen:               baseTime.Add(time.Duration(accParam.ReferralRewardPeriodSec+1) * time.Second),
+			expectReward:         types.NewCoinFromInt64(100),
+			expectReferrerSaving: accParam.RegisterFee.Plus(types.NewCoinFromInt64(5)),
+			expectTotalReward:    types.NewCoinFromInt64(5),
+		},
+		{
+			testName:             "referral is recorded when referrer is created after registration",
+			user:                 user0,
+			reward:               types.NewCoinFromInt64(100),
+			atWhen:               baseTime,
+			expectReward:         types.NewCoinFromInt64(95),
+			expectReferrerSaving: accParam.RegisterFee.Plus(types.NewCoinFromInt64(10)),
+			expectTotalReward:    types.NewCoinFromInt64(5),
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: tc.atWhen})
+		reward, err := am.ShareRewardWithReferrer(ctx, tc.user, tc.reward)
+		assert.Nil(t, err)
+		if !assert.Equal(t, tc.expectReward, reward) {
+			t.Errorf("%s: diff reward, got %v, want %v", tc.testName, reward, tc.expectReward)
+		}
+		saving, err := am.GetSavingFromBank(ctx, accountReferrer)
+		assert.Nil(t, err)
+		if !saving.IsEqual(tc.expectReferrerSaving) {
+			t.Errorf("%s: diff referrer saving, got %v, want %v", tc.testName, saving, tc.expectReferrerSaving)
+		}
+		referral, err := am.storage.GetReferral(ctx, accountReferrer, tc.user)
+		assert.Nil(t, err)
+		if !referral.TotalReward.IsEqual(tc.expectTotalReward) {
+			t.Errorf("%s: diff referral total reward, got %v, want %v", tc.testName, referral.TotalReward, tc.expectTotalReward)
+		}
+	}
+
+	referrals, err := am.GetReferrals(ctx, accountReferrer)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(referrals))
+}
+
+func TestRevokeAppPermission(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	app := types.AccountKey("app")
+	createTestAccount(ctx, am, string(user1))
+	createTestAccount(ctx, am, string(user2))
+	createTestAccount(ctx, am, string(app))
+
+	baseTime := ctx.BlockHeader().Time
+	err := am.AuthorizePermission(ctx, user1, app, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, user1, app, 100, types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, user2, app, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName     string
+		user         types.AccountKey
+		app          types.AccountKey
+		atWhen       time.Time
+		expectResult sdk.Error
+	}{
+		{
+			testName:     "revoke both app and preauth permission",
+			user:         user1,
+			app:          app,
+			atWhen:       baseTime,
+			expectResult: nil,
+		},
+		{
+			testName:     "revoke app without permission",
+			user:         user1,
+			app:          app,
+			atWhen:       baseTime,
+			expectResult: model.ErrGrantPubKeyNotFound(),
+		},
+		{
+			testName:     "revoke expired permission",
+			user:         user2,
+			app:          app,
+			atWhen:       baseTime.Add(time.Duration(101) * time.Second),
+			expectResult: model.ErrGrantPubKeyNotFound(),
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: tc.atWhen})
+		err := am.RevokeAppPermission(ctx, tc.user, tc.app)
+		if !assert.Equal(t, tc.expectResult, err) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, err, tc.expectResult)
+		}
+		grantedApps, err := am.GetGrantedApps(ctx, tc.user)
+		assert.Nil(t, err)
+		if !assert.Equal(t, 0, len(grantedApps)) {
+			t.Errorf("%s: permission is not revoked, got %v", tc.testName, grantedApps)
+		}
+	}
+}
+
+func TestGetGrantedApps(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	app1 := types.AccountKey("app1")
+	app2 := types.AccountKey("app2")
+	createTestAccount(ctx, am, string(user1))
+	_, _, appPriv1 := createTestAccount(ctx, am, string(app1))
+	createTestAccount(ctx, am, string(app2))
+
+	baseTime := ctx.BlockHeader().Time
+	err := am.AuthorizePermission(ctx, user1, app1, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, user1, app2, 200, types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
+	assert.Nil(t, err)
+	app1Grant := model.GrantPubKey{
+		Username:    app1,
+		Permission:  types.AppPermission,
+		CreatedAt:   baseTime.Unix(),
+		ExpiresAt:   baseTime.Unix() + 100,
+		Amount:      types.NewCoinFromInt64(0),
+		PeriodLimit: types.NewCoinFromInt64(0),
+	}
+	app2Grant := model.GrantPubKey{
+		Username:    app2,
+		Permission:  types.PreAuthorizationPermission,
+		CreatedAt:   baseTime.Unix(),
+		ExpiresAt:   baseTime.Unix() + 200,
+		Amount:      types.NewCoinFromInt64(100),
+		PeriodLimit: types.NewCoinFromInt64(0),
+	}
+
+	testCases := []struct {
+		testName          string
+		atWhen            time.Time
+		expectGrantedApps []model.GrantPubKey
+	}{
+		{
+			testName:          "get all granted apps",
+			atWhen:            baseTime,
+			expectGrantedApps: []model.GrantPubKey{app1Grant, app2Grant},
+		},
+		{
+			testName:          "app permission expired",
+			atWhen:            baseTime.Add(time.Duration(101) * time.Second),
+			expectGrantedApps: []model.GrantPubKey{app2Grant},
+		},
+		{
+			testName:          "all permissions expired",
+			atWhen:            baseTime.Add(time.Duration(201) * time.Second),
+			expectGrantedApps: []model.GrantPubKey{},
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: tc.atWhen})
+		grantedApps, err := am.GetGrantedApps(ctx, user1)
+		assert.Nil(t, err)
+		// grants are stored by pubkey, order them by app name before comparison
+		sort.Slice(grantedApps, func(i, j int) bool {
+			return grantedApps[i].Username < grantedApps[j].Username
+		})
+		if !assert.Equal(t, tc.expectGrantedApps, grantedApps) {
+			t.Errorf("%s: diff granted apps, got %v, want %v", tc.testName, grantedApps, tc.expectGrantedApps)
+		}
+	}
+
+	// expired grant is swept during the iteration
+	_, err = am.storage.GetGrantPubKey(ctx, user1, appPriv1.PubKey())
+	assert.Equal(t, model.ErrGrantPubKeyNotFound(), err)
+}
+
 func TestAuthorizePermission(t *testing.T) {
 	ctx, am, _ := setupTest(t, 1)
 	user1 := types.AccountKey("user1")
@@ -2137,7 +2626,7 @@ func TestAuthorizePermission(t *testing.T) {
 
 	for _, tc := range testCases {
 		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Height: 1, Time: baseTime})
-		err := am.AuthorizePermission(ctx, tc.user, tc.grantTo, tc.validityPeriod, tc.level, tc.amount)
+		err := am.AuthorizePermission(ctx, tc.user, tc.grantTo, tc.validityPeriod, tc.level, tc.amount, nil)
 		if !assert.Equal(t, tc.expectResult, err) {
 			t.Errorf("%s: failed to authorize permission, got err %v", tc.testName, err)
 		}
@@ -2148,11 +2637,12 @@ func TestAuthorizePermission(t *testing.T) {
 				t.Errorf("%s: failed to get grant pub key, got err %v", tc.testName, err)
 			}
 			expectGrantPubKey := model.GrantPubKey{
-				Username:   tc.grantTo,
-				ExpiresAt:  baseTime.Unix() + tc.validityPeriod,
-				CreatedAt:  baseTime.Unix(),
-				Permission: tc.level,
-				Amount:     tc.amount,
+				Username:    tc.grantTo,
+				ExpiresAt:   baseTime.Unix() + tc.validityPeriod,
+				CreatedAt:   baseTime.Unix(),
+				Permission:  tc.level,
+				Amount:      tc.amount,
+				PeriodLimit: types.NewCoinFromInt64(0),
 			}
 			if !assert.Equal(t, expectGrantPubKey, *grantPubKey) {
 				t.Errorf("%s: diff grant pub key, got %v, want %v", tc.testName, *grantPubKey, expectGrantPubKey)
@@ -2255,6 +2745,7 @@ func TestAccountRecoverNormalCase(t *testing.T) {
 		ResetKey:       newResetPrivKey.PubKey(),
 		TransactionKey: newTransactionPrivKey.PubKey(),
 		AppKey:         newAppPrivKey.PubKey(),
+		Referrer:       accountReferrer,
 	}
 	bank := model.AccountBank{
 		Saving:  accParam.RegisterFee,
@@ -2471,3 +2962,553 @@ func TestAddFrozenMoney(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateGrantScopes(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	app := types.AccountKey("app")
+	preAuthApp := types.AccountKey("preauthapp")
+	createTestAccount(ctx, am, string(user1))
+	createTestAccount(ctx, am, string(app))
+	createTestAccount(ctx, am, string(preAuthApp))
+
+	err := am.AuthorizePermission(
+		ctx, user1, app, 100, types.AppPermission, types.NewCoinFromInt64(0), []string{"ViewMsg"})
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(
+		ctx, user1, preAuthApp, 100, types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName     string
+		app          types.AccountKey
+		level        types.Permission
+		scopes       []string
+		expectResult sdk.Error
+		expectScopes []string
+	}{
+		{
+			testName:     "update scopes of app permission",
+			app:          app,
+			level:        types.AppPermission,
+			scopes:       []string{"ViewMsg", "CreatePostMsg"},
+			expectResult: nil,
+			expectScopes: []string{"ViewMsg", "CreatePostMsg"},
+		},
+		{
+			testName:     "app doesn't have preauth permission",
+			app:          app,
+			level:        types.PreAuthorizationPermission,
+			scopes:       []string{"DonateMsg"},
+			expectResult: model.ErrGrantPubKeyNotFound(),
+			expectScopes: nil,
+		},
+		{
+			testName:     "unsupported grant level",
+			app:          app,
+			level:        types.TransactionPermission,
+			scopes:       []string{"DonateMsg"},
+			expectResult: ErrUnsupportGrantLevel(),
+			expectScopes: nil,
+		},
+		{
+			testName:     "update scopes of preauth permission",
+			app:          preAuthApp,
+			level:        types.PreAuthorizationPermission,
+			scopes:       []string{"DonateMsg"},
+			expectResult: nil,
+			expectScopes: []string{"DonateMsg"},
+		},
+	}
+	for _, tc := range testCases {
+		err := am.UpdateGrantScopes(ctx, user1, tc.app, tc.level, tc.scopes)
+		if !assert.Equal(t, tc.expectResult, err) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, err, tc.expectResult)
+		}
+		if tc.expectResult != nil {
+			continue
+		}
+		scopes, err := am.GetGrantScopes(ctx, user1, tc.app, tc.level)
+		if err != nil {
+			t.Errorf("%s: failed to get grant scopes, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.expectScopes, scopes) {
+			t.Errorf("%s: diff scopes, got %v, want %v", tc.testName, scopes, tc.expectScopes)
+		}
+	}
+}
+
+func TestSocialRecovery(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	accParam, err := am.paramHolder.GetAccountParam(ctx)
+	assert.Nil(t, err)
+	user1 := types.AccountKey("user1")
+	guardian1 := types.AccountKey("guardian1")
+	guardian2 := types.AccountKey("guardian2")
+	guardian3 := types.AccountKey("guardian3")
+	stranger := types.AccountKey("stranger")
+	createTestAccount(ctx, am, string(user1))
+	createTestAccount(ctx, am, string(guardian1))
+	createTestAccount(ctx, am, string(guardian2))
+	createTestAccount(ctx, am, string(guardian3))
+	createTestAccount(ctx, am, string(stranger))
+
+	err = am.SetGuardians(ctx, user1, []types.AccountKey{guardian1, types.AccountKey("nobody")}, 1)
+	assert.Equal(t, ErrAccountNotFound(types.AccountKey("nobody")), err)
+	err = am.SetGuardians(ctx, user1, []types.AccountKey{guardian1, guardian2, guardian3}, 2)
+	assert.Nil(t, err)
+
+	keysA := []crypto.PubKey{
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()}
+	keysB := []crypto.PubKey{
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()}
+	executeAt := ctx.BlockHeader().Time.Unix() + accParam.SocialRecoveryDelaySec
+
+	testCases := []struct {
+		testName        string
+		guardian        types.AccountKey
+		keys            []crypto.PubKey
+		expectErr       sdk.Error
+		expectExecuteAt int64
+		expectApprovals []types.AccountKey
+	}{
+		{
+			testName:        "stranger can't approve",
+			guardian:        stranger,
+			keys:            keysA,
+			expectErr:       ErrNotGuardian(stranger, user1),
+			expectExecuteAt: 0,
+			expectApprovals: nil,
+		},
+		{
+			testName:        "first approval",
+			guardian:        guardian1,
+			keys:            keysA,
+			expectErr:       nil,
+			expectExecuteAt: 0,
+			expectApprovals: []types.AccountKey{guardian1},
+		},
+		{
+			testName:        "approve twice",
+			guardian:        guardian1,
+			keys:            keysA,
+			expectErr:       ErrRecoveryAlreadyApproved(guardian1),
+			expectExecuteAt: 0,
+			expectApprovals: []types.AccountKey{guardian1},
+		},
+		{
+			testName:        "approve different keys restarts approval",
+			guardian:        guardian2,
+			keys:            keysB,
+			expectErr:       nil,
+			expectExecuteAt: 0,
+			expectApprovals: []types.AccountKey{guardian2},
+		},
+		{
+			testName:        "threshold is reached",
+			guardian:        guardian1,
+			keys:            keysB,
+			expectErr:       nil,
+			expectExecuteAt: executeAt,
+			expectApprovals: []types.AccountKey{guardian2, guardian1},
+		},
+		{
+			testName:        "can't approve scheduled recovery",
+			guardian:        guardian3,
+			keys:            keysA,
+			expectErr:       ErrRecoveryInProgress(user1),
+			expectExecuteAt: 0,
+			expectApprovals: []types.AccountKey{guardian2, guardian1},
+		},
+	}
+
+	for _, tc := range testCases {
+		gotExecuteAt, err := am.ApproveRecovery(ctx, user1, tc.guardian, tc.keys[0], tc.keys[1], tc.keys[2])
+		if !assert.Equal(t, tc.expectErr, err) {
+			t.Errorf("%s: diff err, got %v, want %v", tc.testName, err, tc.expectErr)
+		}
+		if gotExecuteAt != tc.expectExecuteAt {
+			t.Errorf("%s: diff execute at, got %v, want %v", tc.testName, gotExecuteAt, tc.expectExecuteAt)
+		}
+		request, err := am.storage.GetRecoveryRequest(ctx, user1)
+		assert.Nil(t, err)
+		if tc.expectApprovals == nil {
+			assert.Nil(t, request)
+			continue
+		}
+		if !assert.Equal(t, tc.expectApprovals, request.Approvals) {
+			t.Errorf("%s: diff approvals, got %v, want %v", tc.testName, request.Approvals, tc.expectApprovals)
+		}
+	}
+
+	// guardians can't be changed during recovery
+	err = am.SetGuardians(ctx, user1, []types.AccountKey{guardian3}, 1)
+	assert.Equal(t, ErrRecoveryInProgress(user1), err)
+
+	// stale schedule is ignored
+	err = am.ExecuteRecovery(ctx, user1, executeAt+1)
+	assert.Nil(t, err)
+	request, err := am.storage.GetRecoveryRequest(ctx, user1)
+	assert.Nil(t, err)
+	assert.NotNil(t, request)
+
+	err = am.ExecuteRecovery(ctx, user1, executeAt)
+	assert.Nil(t, err)
+	request, err = am.storage.GetRecoveryRequest(ctx, user1)
+	assert.Nil(t, err)
+	assert.Nil(t, request)
+	accInfo, err := am.storage.GetInfo(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, keysB, []crypto.PubKey{accInfo.ResetKey, accInfo.TransactionKey, accInfo.AppKey})
+
+	// register, 3 approvals, scheduled and executed
+	bank, err := am.storage.GetBankFromAccountKey(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), bank.NumOfTx)
+	balanceHistory, err := am.storage.GetBalanceHistory(ctx, user1, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(balanceHistory.Details))
+	for _, detail := range balanceHistory.Details[1:] {
+		assert.Equal(t, types.SocialRecovery, detail.DetailType)
+		assert.True(t, detail.Amount.IsZero())
+		assert.True(t, detail.Balance.IsEqual(accParam.RegisterFee))
+	}
+	assert.Equal(t, "recovery approved by guardian1", balanceHistory.Details[1].Memo)
+	assert.Equal(t, "recovery executed", balanceHistory.Details[5].Memo)
+}
+
+func TestSocialRecoveryAfterResetKeyChange(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	guardian1 := types.AccountKey("guardian1")
+	createTestAccount(ctx, am, string(user1))
+	createTestAccount(ctx, am, string(guardian1))
+	assert.Nil(t, am.SetGuardians(ctx, user1, []types.AccountKey{guardian1}, 1))
+
+	executeAt, err := am.ApproveRecovery(
+		ctx, user1, guardian1, secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey())
+	assert.Nil(t, err)
+
+	// owner replaces keys with reset key before the recovery is executed
+	ownerKeys := []crypto.PubKey{
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()}
+	assert.Nil(t, am.RecoverAccount(ctx, user1, ownerKeys[0], ownerKeys[1], ownerKeys[2]))
+
+	err = am.ExecuteRecovery(ctx, user1, executeAt)
+	assert.Nil(t, err)
+	request, err := am.storage.GetRecoveryRequest(ctx, user1)
+	assert.Nil(t, err)
+	assert.Nil(t, request)
+	accInfo, err := am.storage.GetInfo(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, ownerKeys[0], accInfo.ResetKey)
+	assert.Equal(t, ownerKeys[1], accInfo.TransactionKey)
+	assert.Equal(t, ownerKeys[2], accInfo.AppKey)
+}
+
+func TestCancelRecovery(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	guardian1 := types.AccountKey("guardian1")
+	createTestAccount(ctx, am, string(user1))
+	createTestAccount(ctx, am, string(guardian1))
+
+	_, err := am.CancelRecovery(ctx, user1)
+	assert.Equal(t, ErrRecoveryRequestNotFound(user1), err)
+
+	err = am.SetGuardians(ctx, user1, []types.AccountKey{guardian1}, 1)
+	assert.Nil(t, err)
+	executeAt, err := am.ApproveRecovery(
+		ctx, user1, guardian1, secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey())
+	assert.Nil(t, err)
+
+	request, err := am.CancelRecovery(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, executeAt, request.ExecuteAt)
+	request, err = am.storage.GetRecoveryRequest(ctx, user1)
+	assert.Nil(t, err)
+	assert.Nil(t, request)
+
+	// cancelled recovery isn't executed
+	accInfo, err := am.storage.GetInfo(ctx, user1)
+	assert.Nil(t, err)
+	err = am.ExecuteRecovery(ctx, user1, executeAt)
+	assert.Nil(t, err)
+	checkAccountInfo(t, ctx, "cancelled recovery", user1, *accInfo)
+
+	balanceHistory, err := am.storage.GetBalanceHistory(ctx, user1, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "recovery cancelled", balanceHistory.Details[len(balanceHistory.Details)-1].Memo)
+}
+
+func TestChangeKeyMigratesGrants(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	app := types.AccountKey("app")
+	createTestAccount(ctx, am, string(user1))
+	createTestAccount(ctx, am, string(user2))
+	_, oldTxPriv, oldAppPriv := createTestAccount(ctx, am, string(app))
+
+	err := am.AuthorizePermission(ctx, user1, app, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, user2, app, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, user1, app, 100, types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
+	assert.Nil(t, err)
+
+	newAppPriv := secp256k1.GenPrivKey()
+	err = am.ChangeAppKey(ctx, app, newAppPriv.PubKey())
+	assert.Nil(t, err)
+	appKey, err := am.GetAppKey(ctx, app)
+	assert.Nil(t, err)
+	assert.Equal(t, newAppPriv.PubKey(), appKey)
+	for _, user := range []types.AccountKey{user1, user2} {
+		grantUser, err := am.CheckSigningPubKeyOwner(
+			ctx, user, newAppPriv.PubKey(), types.AppPermission, "", types.NewCoinFromInt64(0))
+		assert.Nil(t, err)
+		assert.Equal(t, app, grantUser)
+		_, err = am.storage.GetGrantPubKey(ctx, user, oldAppPriv.PubKey())
+		assert.NotNil(t, err)
+	}
+	// preauthorization granted to transaction key isn't touched
+	grantPubKey, err := am.storage.GetGrantPubKey(ctx, user1, oldTxPriv.PubKey())
+	assert.Nil(t, err)
+	assert.Equal(t, types.PreAuthorizationPermission, grantPubKey.Permission)
+
+	newTxPriv := secp256k1.GenPrivKey()
+	err = am.ChangeTransactionKey(ctx, app, newTxPriv.PubKey())
+	assert.Nil(t, err)
+	txKey, err := am.GetTransactionKey(ctx, app)
+	assert.Nil(t, err)
+	assert.Equal(t, newTxPriv.PubKey(), txKey)
+	grantUser, err := am.CheckSigningPubKeyOwner(
+		ctx, user1, newTxPriv.PubKey(), types.PreAuthorizationPermission, "", types.NewCoinFromInt64(10))
+	assert.Nil(t, err)
+	assert.Equal(t, app, grantUser)
+	_, err = am.storage.GetGrantPubKey(ctx, user1, oldTxPriv.PubKey())
+	assert.NotNil(t, err)
+
+	// migration onto a key the grantor has already granted is rejected
+	app2 := types.AccountKey("app2")
+	_, _, app2Priv := createTestAccount(ctx, am, string(app2))
+	err = am.AuthorizePermission(ctx, user1, app2, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.ChangeAppKey(ctx, app, app2Priv.PubKey())
+	assert.Equal(t, ErrGrantPubKeyConflict(user1), err)
+	grantPubKey, err = am.storage.GetGrantPubKey(ctx, user1, app2Priv.PubKey())
+	assert.Nil(t, err)
+	assert.Equal(t, app2, grantPubKey.Username)
+}
+
+func TestBuyUsername(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	accParam, err := am.paramHolder.GetAccountParam(ctx)
+	assert.Nil(t, err)
+	seller := types.AccountKey("seller")
+	payee := types.AccountKey("payee")
+	buyer := types.AccountKey("buyer")
+	follower := types.AccountKey("follower")
+	guardian := types.AccountKey("guardian")
+	app := types.AccountKey("app")
+	createTestAccount(ctx, am, string(seller))
+	createTestAccount(ctx, am, string(payee))
+	createTestAccount(ctx, am, string(buyer))
+	createTestAccount(ctx, am, string(follower))
+	createTestAccount(ctx, am, string(guardian))
+	_, _, appPriv := createTestAccount(ctx, am, string(app))
+	price := types.NewCoinFromInt64(100 * types.Decimals)
+	err = am.AddSavingCoin(ctx, buyer, price, "", "", types.TransferIn)
+	assert.Nil(t, err)
+
+	err = am.SetFollower(ctx, seller, follower)
+	assert.Nil(t, err)
+	err = am.SetFollowing(ctx, follower, seller)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, seller, app, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.SetGuardians(ctx, seller, []types.AccountKey{guardian}, 1)
+	assert.Nil(t, err)
+	err = am.UpdateProfile(ctx, seller, model.Profile{DisplayName: "seller"})
+	assert.Nil(t, err)
+
+	err = am.ListUsername(ctx, seller, types.AccountKey("nobody"), price, true, true)
+	assert.Equal(t, ErrAccountNotFound(types.AccountKey("nobody")), err)
+	err = am.BuyUsername(ctx, buyer, seller, price,
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey())
+	assert.Equal(t, ErrUsernameListingNotFound(seller), err)
+	err = am.CancelUsernameListing(ctx, seller)
+	assert.Equal(t, ErrUsernameListingNotFound(seller), err)
+
+	err = am.ListUsername(ctx, seller, payee, price, true, true)
+	assert.Nil(t, err)
+	err = am.BuyUsername(ctx, buyer, seller, price.Minus(types.NewCoinFromInt64(1)),
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey())
+	assert.Equal(t, ErrUsernameListingPriceMismatch(seller), err)
+
+	// frozen money blocks the sale
+	err = am.AddFrozenMoney(ctx, seller, types.NewCoinFromInt64(1), ctx.BlockHeader().Time.Unix(), 10, 1)
+	assert.Nil(t, err)
+	err = am.BuyUsername(ctx, buyer, seller, price,
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey())
+	assert.Equal(t, ErrUsernameSaleBlocked(seller, "outstanding frozen money"), err)
+	bank, err := am.storage.GetBankFromAccountKey(ctx, seller)
+	assert.Nil(t, err)
+	bank.FrozenMoneyList = nil
+	err = am.storage.SetBankFromAccountKey(ctx, seller, bank)
+	assert.Nil(t, err)
+
+	reward := types.NewCoinFromInt64(5 * types.Decimals)
+	err = am.AddIncomeAndReward(ctx, seller, reward, reward, reward, "donor", seller, "post")
+	assert.Nil(t, err)
+	sellerSaving, err := am.GetSavingFromBank(ctx, seller)
+	assert.Nil(t, err)
+
+	newResetPriv := secp256k1.GenPrivKey()
+	newTxPriv := secp256k1.GenPrivKey()
+	newAppPriv := secp256k1.GenPrivKey()
+	err = am.BuyUsername(ctx, buyer, seller, price,
+		newResetPriv.PubKey(), newTxPriv.PubKey(), newAppPriv.PubKey())
+	assert.Nil(t, err)
+
+	accInfo, err := am.storage.GetInfo(ctx, seller)
+	assert.Nil(t, err)
+	assert.Equal(t, newResetPriv.PubKey(), accInfo.ResetKey)
+	assert.Equal(t, newTxPriv.PubKey(), accInfo.TransactionKey)
+	assert.Equal(t, newAppPriv.PubKey(), accInfo.AppKey)
+	payeeSaving, err := am.GetSavingFromBank(ctx, payee)
+	assert.Nil(t, err)
+	assert.True(t, payeeSaving.IsEqual(accParam.RegisterFee.Plus(price).Plus(sellerSaving).Plus(reward)))
+	buyerSaving, err := am.GetSavingFromBank(ctx, buyer)
+	assert.Nil(t, err)
+	assert.True(t, buyerSaving.IsEqual(accParam.RegisterFee))
+	// saving and unclaimed reward are swept to payee before the username changes hands
+	sellerSaving, err = am.GetSavingFromBank(ctx, seller)
+	assert.Nil(t, err)
+	assert.True(t, sellerSaving.IsZero())
+	sellerReward, err := am.storage.GetReward(ctx, seller)
+	assert.Nil(t, err)
+	assert.True(t, sellerReward.UnclaimReward.IsZero())
+	sellerBank, err := am.storage.GetBankFromAccountKey(ctx, seller)
+	assert.Nil(t, err)
+	balanceHistory, err := am.storage.GetBalanceHistory(ctx, seller, 0)
+	assert.Nil(t, err)
+	lastDetail := balanceHistory.Details[len(balanceHistory.Details)-1]
+	assert.Equal(t, types.UsernameSaleOut, lastDetail.DetailType)
+	assert.Equal(t, payee, lastDetail.To)
+	assert.Equal(t, int64(len(balanceHistory.Details)), sellerBank.NumOfTx)
+
+	listing, err := am.GetUsernameListing(ctx, seller)
+	assert.Nil(t, err)
+	assert.Nil(t, listing)
+	guardians, err := am.storage.GetGuardians(ctx, seller)
+	assert.Nil(t, err)
+	assert.Nil(t, guardians)
+	profile, err := am.GetProfile(ctx, seller)
+	assert.Nil(t, err)
+	assert.Nil(t, profile)
+	assert.False(t, am.storage.IsMyFollower(ctx, seller, follower))
+	assert.False(t, am.storage.IsMyFollowing(ctx, follower, seller))
+	_, err = am.storage.GetGrantPubKey(ctx, seller, appPriv.PubKey())
+	assert.NotNil(t, err)
+}
+
+func TestCancelUsernameListing(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	seller := types.AccountKey("seller")
+	payee := types.AccountKey("payee")
+	createTestAccount(ctx, am, string(seller))
+	createTestAccount(ctx, am, string(payee))
+
+	err := am.ListUsername(ctx, seller, payee, types.NewCoinFromInt64(1), false, false)
+	assert.Nil(t, err)
+	err = am.CancelUsernameListing(ctx, seller)
+	assert.Nil(t, err)
+	listing, err := am.GetUsernameListing(ctx, seller)
+	assert.Nil(t, err)
+	assert.Nil(t, listing)
+}
+
+func TestCloseAccount(t *testing.T) {
+	ctx, am, _ := setupTest(t, 1)
+	accParam, err := am.paramHolder.GetAccountParam(ctx)
+	assert.Nil(t, err)
+	user := types.AccountKey("user")
+	receiver := types.AccountKey("receiver")
+	follower := types.AccountKey("follower")
+	guardian := types.AccountKey("guardian")
+	app := types.AccountKey("app")
+	createTestAccount(ctx, am, string(user))
+	createTestAccount(ctx, am, string(receiver))
+	createTestAccount(ctx, am, string(follower))
+	createTestAccount(ctx, am, string(guardian))
+	_, _, appPriv := createTestAccount(ctx, am, string(app))
+	unclaimReward := types.NewCoinFromInt64(10)
+	err = am.AddIncomeAndReward(
+		ctx, user, types.NewCoinFromInt64(0), types.NewCoinFromInt64(0), unclaimReward, follower, user, "post")
+	assert.Nil(t, err)
+
+	err = am.SetFollower(ctx, user, follower)
+	assert.Nil(t, err)
+	err = am.SetFollowing(ctx, follower, user)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(ctx, user, app, 100, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.SetGuardians(ctx, user, []types.AccountKey{guardian}, 1)
+	assert.Nil(t, err)
+	err = am.UpdateProfile(ctx, user, model.Profile{DisplayName: "user"})
+	assert.Nil(t, err)
+
+	err = am.CloseAccount(ctx, user, types.AccountKey("nobody"))
+	assert.Equal(t, ErrAccountNotFound(types.AccountKey("nobody")), err)
+
+	// frozen money blocks closing
+	err = am.AddFrozenMoney(ctx, user, types.NewCoinFromInt64(1), ctx.BlockHeader().Time.Unix(), 10, 1)
+	assert.Nil(t, err)
+	err = am.CloseAccount(ctx, user, receiver)
+	assert.Equal(t, ErrAccountCloseBlocked(user, "outstanding frozen money"), err)
+	bank, err := am.storage.GetBankFromAccountKey(ctx, user)
+	assert.Nil(t, err)
+	bank.FrozenMoneyList = nil
+	err = am.storage.SetBankFromAccountKey(ctx, user, bank)
+	assert.Nil(t, err)
+
+	// pending recovery blocks closing
+	_, err = am.ApproveRecovery(ctx, user, guardian,
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey())
+	assert.Nil(t, err)
+	err = am.CloseAccount(ctx, user, receiver)
+	assert.Equal(t, ErrAccountCloseBlocked(user, "recovery in progress"), err)
+	_, err = am.CancelRecovery(ctx, user)
+	assert.Nil(t, err)
+
+	err = am.CloseAccount(ctx, user, receiver)
+	assert.Nil(t, err)
+
+	assert.False(t, am.DoesAccountExist(ctx, user))
+	assert.True(t, am.IsUsernameRetired(ctx, user))
+	retired, err := am.storage.GetRetiredAccount(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, model.RetiredAccount{Receiver: receiver, RetiredAt: ctx.BlockHeader().Time.Unix()}, *retired)
+	receiverSaving, err := am.GetSavingFromBank(ctx, receiver)
+	assert.Nil(t, err)
+	assert.True(t, receiverSaving.IsEqual(accParam.RegisterFee.Plus(accParam.RegisterFee).Plus(unclaimReward)))
+
+	guardians, err := am.storage.GetGuardians(ctx, user)
+	assert.Nil(t, err)
+	assert.Nil(t, guardians)
+	profile, err := am.GetProfile(ctx, user)
+	assert.Nil(t, err)
+	assert.Nil(t, profile)
+	assert.False(t, am.storage.IsMyFollower(ctx, user, follower))
+	assert.False(t, am.storage.IsMyFollowing(ctx, follower, user))
+	_, err = am.storage.GetGrantPubKey(ctx, user, appPriv.PubKey())
+	assert.NotNil(t, err)
+
+	// retired username can't be registered again
+	err = am.CreateAccount(ctx, accountReferrer, user, secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(), accParam.RegisterFee)
+	assert.Equal(t, ErrUsernameRetired(user), err)
+}
@@ -15,6 +15,7 @@ type AccountInfo struct {
 	ResetKey       crypto.PubKey    `json:"reset_key"`
 	TransactionKey crypto.PubKey    `json:"transaction_key"`
 	AppKey         crypto.PubKey    `json:"app_key"`
+	Referrer       types.AccountKey `json:"referrer"`
 }
 
 // AccountBank - user balance
@@ -49,13 +50,20 @@ type PendingCoinDay struct {
 	Coin      types.Coin `json:"coin"`
 }
 
-// GrantPubKey - user grant permission to a public key with a certain permission
+// GrantPubKey - user grant permission to a public key with a certain permission,
+// scopes are msg types the key can sign and empty scopes allow all msgs of the permission
 type GrantPubKey struct {
 	Username   types.AccountKey `json:"username"`
 	Permission types.Permission `json:"permission"`
 	CreatedAt  int64            `json:"created_at"`
 	ExpiresAt  int64            `json:"expires_at"`
 	Amount     types.Coin       `json:"amount"`
+	Scopes     []string         `json:"scopes"`
+	// periodic preauth grant resets Amount to PeriodLimit every PeriodSec,
+	// zero PeriodSec means Amount is a one-time lump
+	PeriodSec     int64      `json:"period_second"`
+	PeriodLimit   types.Coin `json:"period_limit"`
+	PeriodStartAt int64      `json:"period_start_at"`
 }
 
 // AccountMeta - stores tiny and frequently updated fields.
@@ -95,6 +103,65 @@ type Reward struct {
 	UnclaimReward   types.Coin `json:"unclaim_reward"`
 }
 
+// Referral - account registered by referrer, TotalReward is the lifetime
+// reward referrer got from the referee
+type Referral struct {
+	Referee     types.AccountKey `json:"referee"`
+	CreatedAt   int64            `json:"created_at"`
+	TotalReward types.Coin       `json:"total_reward"`
+}
+
+// Guardians - accounts designated to approve social recovery,
+// Threshold is the number of approvals needed to recover the account
+type Guardians struct {
+	Guardians []types.AccountKey `json:"guardians"`
+	Threshold int64              `json:"threshold"`
+}
+
+// RecoveryRequest - pending key set approved by guardians, new keys take effect
+// at ExecuteAt which is zero until the threshold is reached. ResetKey is the reset key
+// of the account when the recovery is scheduled, the recovery is dropped if it's changed
+type RecoveryRequest struct {
+	NewResetKey       crypto.PubKey      `json:"new_reset_key"`
+	NewTransactionKey crypto.PubKey      `json:"new_transaction_key"`
+	NewAppKey         crypto.PubKey      `json:"new_app_key"`
+	Approvals         []types.AccountKey `json:"approvals"`
+	CreatedAt         int64              `json:"created_at"`
+	ExecuteAt         int64              `json:"execute_at"`
+	ResetKey          crypto.PubKey      `json:"reset_key"`
+}
+
+// UsernameListing - username for sale, Price is paid to Payee and the buyer's keys
+// replace all keys of the account. Grants and follow relations are cleared as configured.
+type UsernameListing struct {
+	Price        types.Coin       `json:"price"`
+	Payee        types.AccountKey `json:"payee"`
+	ClearGrants  bool             `json:"clear_grants"`
+	ClearFollows bool             `json:"clear_follows"`
+	CreatedAt    int64            `json:"created_at"`
+}
+
+// Profile - structured public profile of an account
+type Profile struct {
+	DisplayName   string         `json:"display_name"`
+	Avatar        string         `json:"avatar"`
+	Bio           string         `json:"bio"`
+	Website       string         `json:"website"`
+	VerifiedLinks []VerifiedLink `json:"verified_links"`
+}
+
+// VerifiedLink - external account of the user, platform is unique in a profile
+type VerifiedLink struct {
+	Platform string `json:"platform"`
+	URL      string `json:"url"`
+}
+
+// RetiredAccount - closed account, the username can't be registered again
+type RetiredAccount struct {
+	Receiver  types.AccountKey `json:"receiver"`
+	RetiredAt int64            `json:"retired_at"`
+}
+
 // RewardDetail - reward detail
 type RewardDetail struct {
 	OriginalDonation types.Coin       `json:"original_donation"`
@@ -63,6 +63,16 @@ func ErrFailedToMarshalFollowingMeta(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToMarshalFollowingMeta, fmt.Sprintf("failed to marshal following meta: %s", err.Error()))
 }
 
+// ErrFailedToUnmarshalFollowerMeta - error if unmarshal follower meta failed
+func ErrFailedToUnmarshalFollowerMeta(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalFollowerMeta, fmt.Sprintf("failed to unmarshal follower meta: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalFollowingMeta - error if unmarshal following meta failed
+func ErrFailedToUnmarshalFollowingMeta(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalFollowingMeta, fmt.Sprintf("failed to unmarshal following meta: %s", err.Error()))
+}
+
 // ErrFailedToMarshalReward - error if marshal reward failed
 func ErrFailedToMarshalReward(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToMarshalReward, fmt.Sprintf("failed to marshal reward: %s", err.Error()))
@@ -137,3 +147,63 @@ func ErrFailedToUnmarshalBalanceHistory(err error) sdk.Error {
 func ErrFailedToUnmarshalRewardHistory(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalRewardHistory, fmt.Sprintf("failed to unmarshal reward history: %s", err.Error()))
 }
+
+// ErrFailedToMarshalReferral - error if marshal referral failed
+func ErrFailedToMarshalReferral(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalReferral, fmt.Sprintf("failed to marshal referral: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalReferral - error if unmarshal referral failed
+func ErrFailedToUnmarshalReferral(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalReferral, fmt.Sprintf("failed to unmarshal referral: %s", err.Error()))
+}
+
+// ErrFailedToMarshalGuardians - error if marshal guardians failed
+func ErrFailedToMarshalGuardians(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalGuardians, fmt.Sprintf("failed to marshal guardians: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalGuardians - error if unmarshal guardians failed
+func ErrFailedToUnmarshalGuardians(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalGuardians, fmt.Sprintf("failed to unmarshal guardians: %s", err.Error()))
+}
+
+// ErrFailedToMarshalRecoveryRequest - error if marshal recovery request failed
+func ErrFailedToMarshalRecoveryRequest(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalRecoveryRequest, fmt.Sprintf("failed to marshal recovery request: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalRecoveryRequest - error if unmarshal recovery request failed
+func ErrFailedToUnmarshalRecoveryRequest(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalRecoveryRequest, fmt.Sprintf("failed to unmarshal recovery request: %s", err.Error()))
+}
+
+// ErrFailedToMarshalUsernameListing - error if marshal username listing failed
+func ErrFailedToMarshalUsernameListing(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalUsernameListing, fmt.Sprintf("failed to marshal username listing: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalUsernameListing - error if unmarshal username listing failed
+func ErrFailedToUnmarshalUsernameListing(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalUsernameListing, fmt.Sprintf("failed to unmarshal username listing: %s", err.Error()))
+}
+
+// ErrFailedToMarshalProfile - error if marshal profile failed
+func ErrFailedToMarshalProfile(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalProfile, fmt.Sprintf("failed to marshal profile: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalProfile - error if unmarshal profile failed
+func ErrFailedToUnmarshalProfile(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalProfile, fmt.Sprintf("failed to unmarshal profile: %s", err.Error()))
+}
+
+// ErrFailedToMarshalRetiredAccount - error if marshal retired account failed
+func ErrFailedToMarshalRetiredAccount(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalRetiredAccount, fmt.Sprintf("failed to marshal retired account: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalRetiredAccount - error if unmarshal retired account failed
+func ErrFailedToUnmarshalRetiredAccount(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalRetiredAccount, fmt.Sprintf("failed to unmarshal retired account: %s", err.Error()))
+}
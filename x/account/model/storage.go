@@ -3,9 +3,11 @@ package model
 import (
 	"encoding/hex"
 	"strconv"
+	"strings"
 
 	"github.com/lino-network/lino/types"
 	crypto "github.com/tendermint/tendermint/crypto"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
@@ -23,6 +25,14 @@ var (
 	accountBalanceHistorySubstore      = []byte{0x08}
 	accountGrantPubKeySubstore         = []byte{0x09}
 	accountRewardHistorySubstore       = []byte{0x0a}
+	accountReferralSubstore            = []byte{0x0b}
+	accountGuardiansSubstore           = []byte{0x0c}
+	accountRecoveryRequestSubstore     = []byte{0x0d}
+	accountUsernameListingSubstore     = []byte{0x0e}
+	accountProfileSubstore             = []byte{0x0f}
+	accountRetiredSubstore             = []byte{0x10}
+	accountAppGrantSubstore            = []byte{0x11}
+	accountAppGrantIndexBuiltKey       = []byte{0x12}
 )
 
 // AccountStorage - account storage
@@ -180,6 +190,38 @@ func (as AccountStorage) RemoveFollowingMeta(ctx sdk.Context, me types.AccountKe
 	return
 }
 
+// GetFollowers - returns all followers of a given account
+func (as AccountStorage) GetFollowers(ctx sdk.Context, me types.AccountKey) ([]FollowerMeta, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	iter := sdk.KVStorePrefixIterator(store, GetFollowerPrefix(me))
+	defer iter.Close()
+	followers := []FollowerMeta{}
+	for ; iter.Valid(); iter.Next() {
+		meta := new(FollowerMeta)
+		if err := as.cdc.UnmarshalJSON(iter.Value(), meta); err != nil {
+			return nil, ErrFailedToUnmarshalFollowerMeta(err)
+		}
+		followers = append(followers, *meta)
+	}
+	return followers, nil
+}
+
+// GetFollowings - returns all accounts a given account follows
+func (as AccountStorage) GetFollowings(ctx sdk.Context, me types.AccountKey) ([]FollowingMeta, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	iter := sdk.KVStorePrefixIterator(store, GetFollowingPrefix(me))
+	defer iter.Close()
+	followings := []FollowingMeta{}
+	for ; iter.Valid(); iter.Next() {
+		meta := new(FollowingMeta)
+		if err := as.cdc.UnmarshalJSON(iter.Value(), meta); err != nil {
+			return nil, ErrFailedToUnmarshalFollowingMeta(err)
+		}
+		followings = append(followings, *meta)
+	}
+	return followings, nil
+}
+
 // GetReward - returns reward info of a given account, returns error if any.
 func (as AccountStorage) GetReward(ctx sdk.Context, accKey types.AccountKey) (*Reward, sdk.Error) {
 	store := ctx.KVStore(as.key)
@@ -234,14 +276,17 @@ func (as AccountStorage) SetPendingCoinDayQueue(ctx sdk.Context, me types.Accoun
 // DeleteGrantPubKey - deletes given pubkey in KV.
 func (as AccountStorage) DeleteGrantPubKey(ctx sdk.Context, me types.AccountKey, pubKey crypto.PubKey) {
 	store := ctx.KVStore(as.key)
-	store.Delete(getGrantPubKeyKey(me, pubKey))
+	if grantPubKey, err := as.GetGrantPubKey(ctx, me, pubKey); err == nil {
+		store.Delete(GetAppGrantKey(grantPubKey.Username, me, pubKey))
+	}
+	store.Delete(GetGrantPubKeyKey(me, pubKey))
 	return
 }
 
 // GetGrantPubKey - returns grant user info keyed with pubkey.
 func (as AccountStorage) GetGrantPubKey(ctx sdk.Context, me types.AccountKey, pubKey crypto.PubKey) (*GrantPubKey, sdk.Error) {
 	store := ctx.KVStore(as.key)
-	grantPubKeyByte := store.Get(getGrantPubKeyKey(me, pubKey))
+	grantPubKeyByte := store.Get(GetGrantPubKeyKey(me, pubKey))
 	if grantPubKeyByte == nil {
 		return nil, ErrGrantPubKeyNotFound()
 	}
@@ -259,7 +304,106 @@ func (as AccountStorage) SetGrantPubKey(ctx sdk.Context, me types.AccountKey, pu
 	if err != nil {
 		return ErrFailedToMarshalGrantPubKey(err)
 	}
-	store.Set(getGrantPubKeyKey(me, pubKey), grantPubKeyByte)
+	if prev, err := as.GetGrantPubKey(ctx, me, pubKey); err == nil {
+		store.Delete(GetAppGrantKey(prev.Username, me, pubKey))
+	}
+	store.Set(GetGrantPubKeyKey(me, pubKey), grantPubKeyByte)
+	// grants are indexed by the authorized app as well
+	store.Set(GetAppGrantKey(grantPubKey.Username, me, pubKey), []byte(me))
+	return nil
+}
+
+// IterateGrantPubKeys - iterate all pubkeys granted by user, the store shouldn't
+// be modified inside process
+func (as AccountStorage) IterateGrantPubKeys(
+	ctx sdk.Context, me types.AccountKey,
+	process func(pubKey crypto.PubKey, grantPubKey GrantPubKey) (stop bool)) sdk.Error {
+	store := ctx.KVStore(as.key)
+	prefix := GetGrantPubKeyPrefix(me)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		pubKeyBytes, err := hex.DecodeString(string(iter.Key()[len(prefix):]))
+		if err != nil {
+			return ErrFailedToUnmarshalGrantPubKey(err)
+		}
+		pubKey, err := cryptoAmino.PubKeyFromBytes(pubKeyBytes)
+		if err != nil {
+			return ErrFailedToUnmarshalGrantPubKey(err)
+		}
+		grantPubKey := new(GrantPubKey)
+		if err := as.cdc.UnmarshalJSON(iter.Value(), grantPubKey); err != nil {
+			return ErrFailedToUnmarshalGrantPubKey(err)
+		}
+		if process(pubKey, *grantPubKey) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// BuildAppGrantIndex - index grant pubkeys set before grants were indexed by app,
+// the index is built only once
+func (as AccountStorage) BuildAppGrantIndex(ctx sdk.Context) sdk.Error {
+	store := ctx.KVStore(as.key)
+	if store.Has(accountAppGrantIndexBuiltKey) {
+		return nil
+	}
+	indexKeys := [][]byte{}
+	grantors := [][]byte{}
+	iter := sdk.KVStorePrefixIterator(store, accountGrantPubKeySubstore)
+	for ; iter.Valid(); iter.Next() {
+		// key is "grant pubkey substore" + "me" + separator + "hex encoded pubkey"
+		key := string(iter.Key()[len(accountGrantPubKeySubstore):])
+		sepIndex := strings.LastIndex(key, types.KeySeparator)
+		if sepIndex < 0 {
+			continue
+		}
+		grantPubKey := new(GrantPubKey)
+		if err := as.cdc.UnmarshalJSON(iter.Value(), grantPubKey); err != nil {
+			iter.Close()
+			return ErrFailedToUnmarshalGrantPubKey(err)
+		}
+		indexKeys = append(indexKeys, append(GetAppGrantPrefix(grantPubKey.Username), key...))
+		grantors = append(grantors, []byte(key[:sepIndex]))
+	}
+	iter.Close()
+	for i, indexKey := range indexKeys {
+		store.Set(indexKey, grantors[i])
+	}
+	store.Set(accountAppGrantIndexBuiltKey, []byte{1})
+	return nil
+}
+
+// IterateAppGrantPubKeys - iterate grant pubkeys issued to the app by all accounts until
+// process returns true, the store shouldn't be modified inside process
+func (as AccountStorage) IterateAppGrantPubKeys(
+	ctx sdk.Context, app types.AccountKey,
+	process func(me types.AccountKey, pubKey crypto.PubKey, grantPubKey GrantPubKey) (stop bool)) sdk.Error {
+	store := ctx.KVStore(as.key)
+	prefix := GetAppGrantPrefix(app)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		// key is "app grant substore" + "app" + separator + "me" + separator + "hex encoded pubkey"
+		me := types.AccountKey(iter.Value())
+		pubKeyBytes, err := hex.DecodeString(
+			string(iter.Key()[len(prefix)+len(me)+len(types.KeySeparator):]))
+		if err != nil {
+			return ErrFailedToUnmarshalGrantPubKey(err)
+		}
+		pubKey, err := cryptoAmino.PubKeyFromBytes(pubKeyBytes)
+		if err != nil {
+			return ErrFailedToUnmarshalGrantPubKey(err)
+		}
+		grantPubKey, getErr := as.GetGrantPubKey(ctx, me, pubKey)
+		if getErr != nil {
+			return getErr
+		}
+		if process(me, pubKey, *grantPubKey) {
+			return nil
+		}
+	}
 	return nil
 }
 
@@ -288,6 +432,229 @@ func (as AccountStorage) SetRelationship(ctx sdk.Context, me types.AccountKey, o
 	return nil
 }
 
+// GetReferral - returns the referral of referee, nil if referee isn't registered by referrer
+func (as AccountStorage) GetReferral(
+	ctx sdk.Context, referrer types.AccountKey, referee types.AccountKey) (*Referral, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	referralByte := store.Get(getReferralKey(referrer, referee))
+	if referralByte == nil {
+		return nil, nil
+	}
+	referral := new(Referral)
+	if err := as.cdc.UnmarshalJSON(referralByte, referral); err != nil {
+		return nil, ErrFailedToUnmarshalReferral(err)
+	}
+	return referral, nil
+}
+
+// SetReferral - sets referral of referee
+func (as AccountStorage) SetReferral(ctx sdk.Context, referrer types.AccountKey, referral *Referral) sdk.Error {
+	store := ctx.KVStore(as.key)
+	referralByte, err := as.cdc.MarshalJSON(*referral)
+	if err != nil {
+		return ErrFailedToMarshalReferral(err)
+	}
+	store.Set(getReferralKey(referrer, referral.Referee), referralByte)
+	return nil
+}
+
+// GetReferrals - returns all referrals of referrer ordered by referee
+func (as AccountStorage) GetReferrals(ctx sdk.Context, referrer types.AccountKey) ([]Referral, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	iter := sdk.KVStorePrefixIterator(store, GetReferralPrefix(referrer))
+	defer iter.Close()
+	referrals := []Referral{}
+	for ; iter.Valid(); iter.Next() {
+		referral := new(Referral)
+		if err := as.cdc.UnmarshalJSON(iter.Value(), referral); err != nil {
+			return nil, ErrFailedToUnmarshalReferral(err)
+		}
+		referrals = append(referrals, *referral)
+	}
+	return referrals, nil
+}
+
+// GetGuardians - returns guardians of the account, nil if no guardian is designated
+func (as AccountStorage) GetGuardians(ctx sdk.Context, me types.AccountKey) (*Guardians, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	guardiansByte := store.Get(GetGuardiansKey(me))
+	if guardiansByte == nil {
+		return nil, nil
+	}
+	guardians := new(Guardians)
+	if err := as.cdc.UnmarshalJSON(guardiansByte, guardians); err != nil {
+		return nil, ErrFailedToUnmarshalGuardians(err)
+	}
+	return guardians, nil
+}
+
+// SetGuardians - sets guardians of the account
+func (as AccountStorage) SetGuardians(ctx sdk.Context, me types.AccountKey, guardians *Guardians) sdk.Error {
+	store := ctx.KVStore(as.key)
+	guardiansByte, err := as.cdc.MarshalJSON(*guardians)
+	if err != nil {
+		return ErrFailedToMarshalGuardians(err)
+	}
+	store.Set(GetGuardiansKey(me), guardiansByte)
+	return nil
+}
+
+// DeleteGuardians - deletes guardians of the account
+func (as AccountStorage) DeleteGuardians(ctx sdk.Context, me types.AccountKey) {
+	store := ctx.KVStore(as.key)
+	store.Delete(GetGuardiansKey(me))
+	return
+}
+
+// GetRecoveryRequest - returns pending recovery request of the account, nil if there is none
+func (as AccountStorage) GetRecoveryRequest(ctx sdk.Context, me types.AccountKey) (*RecoveryRequest, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	requestByte := store.Get(GetRecoveryRequestKey(me))
+	if requestByte == nil {
+		return nil, nil
+	}
+	request := new(RecoveryRequest)
+	if err := as.cdc.UnmarshalJSON(requestByte, request); err != nil {
+		return nil, ErrFailedToUnmarshalRecoveryRequest(err)
+	}
+	return request, nil
+}
+
+// SetRecoveryRequest - sets pending recovery request of the account
+func (as AccountStorage) SetRecoveryRequest(ctx sdk.Context, me types.AccountKey, request *RecoveryRequest) sdk.Error {
+	store := ctx.KVStore(as.key)
+	requestByte, err := as.cdc.MarshalJSON(*request)
+	if err != nil {
+		return ErrFailedToMarshalRecoveryRequest(err)
+	}
+	store.Set(GetRecoveryRequestKey(me), requestByte)
+	return nil
+}
+
+// DeleteRecoveryRequest - deletes pending recovery request of the account
+func (as AccountStorage) DeleteRecoveryRequest(ctx sdk.Context, me types.AccountKey) {
+	store := ctx.KVStore(as.key)
+	store.Delete(GetRecoveryRequestKey(me))
+	return
+}
+
+// GetUsernameListing - returns sale listing of the username, nil if it isn't for sale
+func (as AccountStorage) GetUsernameListing(ctx sdk.Context, me types.AccountKey) (*UsernameListing, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	listingByte := store.Get(GetUsernameListingKey(me))
+	if listingByte == nil {
+		return nil, nil
+	}
+	listing := new(UsernameListing)
+	if err := as.cdc.UnmarshalJSON(listingByte, listing); err != nil {
+		return nil, ErrFailedToUnmarshalUsernameListing(err)
+	}
+	return listing, nil
+}
+
+// SetUsernameListing - sets sale listing of the username
+func (as AccountStorage) SetUsernameListing(ctx sdk.Context, me types.AccountKey, listing *UsernameListing) sdk.Error {
+	store := ctx.KVStore(as.key)
+	listingByte, err := as.cdc.MarshalJSON(*listing)
+	if err != nil {
+		return ErrFailedToMarshalUsernameListing(err)
+	}
+	store.Set(GetUsernameListingKey(me), listingByte)
+	return nil
+}
+
+// DeleteUsernameListing - deletes sale listing of the username
+func (as AccountStorage) DeleteUsernameListing(ctx sdk.Context, me types.AccountKey) {
+	store := ctx.KVStore(as.key)
+	store.Delete(GetUsernameListingKey(me))
+	return
+}
+
+// GetProfile - returns profile of the account, nil if it isn't set
+func (as AccountStorage) GetProfile(ctx sdk.Context, me types.AccountKey) (*Profile, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	profileByte := store.Get(GetProfileKey(me))
+	if profileByte == nil {
+		return nil, nil
+	}
+	profile := new(Profile)
+	if err := as.cdc.UnmarshalJSON(profileByte, profile); err != nil {
+		return nil, ErrFailedToUnmarshalProfile(err)
+	}
+	return profile, nil
+}
+
+// SetProfile - sets profile of the account
+func (as AccountStorage) SetProfile(ctx sdk.Context, me types.AccountKey, profile *Profile) sdk.Error {
+	store := ctx.KVStore(as.key)
+	profileByte, err := as.cdc.MarshalJSON(*profile)
+	if err != nil {
+		return ErrFailedToMarshalProfile(err)
+	}
+	store.Set(GetProfileKey(me), profileByte)
+	return nil
+}
+
+// DeleteProfile - deletes profile of the account
+func (as AccountStorage) DeleteProfile(ctx sdk.Context, me types.AccountKey) {
+	store := ctx.KVStore(as.key)
+	store.Delete(GetProfileKey(me))
+	return
+}
+
+// DeleteAccount - deletes info, bank, meta, reward, pending coin day queue and
+// relationships of the account, balance and reward history are kept
+func (as AccountStorage) DeleteAccount(ctx sdk.Context, me types.AccountKey) {
+	store := ctx.KVStore(as.key)
+	store.Delete(GetAccountInfoKey(me))
+	store.Delete(GetAccountBankKey(me))
+	store.Delete(GetAccountMetaKey(me))
+	store.Delete(getRewardKey(me))
+	store.Delete(getPendingCoinDayQueueKey(me))
+
+	iter := sdk.KVStorePrefixIterator(store, getRelationshipPrefix(me))
+	keys := [][]byte{}
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return
+}
+
+// IsUsernameRetired - returns true when the username belongs to a closed account
+func (as AccountStorage) IsUsernameRetired(ctx sdk.Context, me types.AccountKey) bool {
+	store := ctx.KVStore(as.key)
+	return store.Has(GetRetiredAccountKey(me))
+}
+
+// GetRetiredAccount - returns retired record of the username, nil if it isn't retired
+func (as AccountStorage) GetRetiredAccount(ctx sdk.Context, me types.AccountKey) (*RetiredAccount, sdk.Error) {
+	store := ctx.KVStore(as.key)
+	retiredByte := store.Get(GetRetiredAccountKey(me))
+	if retiredByte == nil {
+		return nil, nil
+	}
+	retired := new(RetiredAccount)
+	if err := as.cdc.UnmarshalJSON(retiredByte, retired); err != nil {
+		return nil, ErrFailedToUnmarshalRetiredAccount(err)
+	}
+	return retired, nil
+}
+
+// SetRetiredAccount - sets retired record of the username
+func (as AccountStorage) SetRetiredAccount(ctx sdk.Context, me types.AccountKey, retired *RetiredAccount) sdk.Error {
+	store := ctx.KVStore(as.key)
+	retiredByte, err := as.cdc.MarshalJSON(*retired)
+	if err != nil {
+		return ErrFailedToMarshalRetiredAccount(err)
+	}
+	store.Set(GetRetiredAccountKey(me), retiredByte)
+	return nil
+}
+
 // GetRelationship - returns the relationship between two accounts
 func (as AccountStorage) GetBalanceHistory(
 	ctx sdk.Context, me types.AccountKey, bucketSlot int64) (*BalanceHistory, sdk.Error) {
@@ -370,19 +737,21 @@ func GetAccountMetaKey(accKey types.AccountKey) []byte {
 }
 
 func getFollowerKey(me types.AccountKey, myFollower types.AccountKey) []byte {
-	return append(getFollowerPrefix(me), myFollower...)
+	return append(GetFollowerPrefix(me), myFollower...)
 }
 
-func getFollowerPrefix(me types.AccountKey) []byte {
+// GetFollowerPrefix - "follower substore" + "me" + separator
+func GetFollowerPrefix(me types.AccountKey) []byte {
 	return append(append(accountFollowerSubstore, me...), types.KeySeparator...)
 }
 
 // "following substore" + "me" + "my following"
 func getFollowingKey(me types.AccountKey, myFollowing types.AccountKey) []byte {
-	return append(getFollowingPrefix(me), myFollowing...)
+	return append(GetFollowingPrefix(me), myFollowing...)
 }
 
-func getFollowingPrefix(me types.AccountKey) []byte {
+// GetFollowingPrefix - "following substore" + "me" + separator
+func GetFollowingPrefix(me types.AccountKey) []byte {
 	return append(append(accountFollowingSubstore, me...), types.KeySeparator...)
 }
 
@@ -402,12 +771,60 @@ func getPendingCoinDayQueueKey(accKey types.AccountKey) []byte {
 	return append(accountPendingCoinDayQueueSubstore, accKey...)
 }
 
-func getGrantPubKeyPrefix(me types.AccountKey) []byte {
+// GetGrantPubKeyPrefix - "grant pubkey substore" + "me"
+func GetGrantPubKeyPrefix(me types.AccountKey) []byte {
 	return append(append(accountGrantPubKeySubstore, me...), types.KeySeparator...)
 }
 
-func getGrantPubKeyKey(me types.AccountKey, pubKey crypto.PubKey) []byte {
-	return append(getGrantPubKeyPrefix(me), hex.EncodeToString(pubKey.Bytes())...)
+// GetGrantPubKeyKey - "grant pubkey substore" + "me" + "hex encoded pubkey"
+func GetGrantPubKeyKey(me types.AccountKey, pubKey crypto.PubKey) []byte {
+	return append(GetGrantPubKeyPrefix(me), hex.EncodeToString(pubKey.Bytes())...)
+}
+
+// GetAppGrantPrefix - "app grant substore" + "app"
+// which can be used to access all grants issued to the app
+func GetAppGrantPrefix(app types.AccountKey) []byte {
+	return append(append(accountAppGrantSubstore, app...), types.KeySeparator...)
+}
+
+// GetAppGrantKey - "app grant substore" + "app" + "me" + "hex encoded pubkey"
+func GetAppGrantKey(app, me types.AccountKey, pubKey crypto.PubKey) []byte {
+	return append(append(append(GetAppGrantPrefix(app), me...), types.KeySeparator...),
+		hex.EncodeToString(pubKey.Bytes())...)
+}
+
+// GetReferralPrefix - "referral substore" + "referrer"
+func GetReferralPrefix(referrer types.AccountKey) []byte {
+	return append(append(accountReferralSubstore, referrer...), types.KeySeparator...)
+}
+
+func getReferralKey(referrer types.AccountKey, referee types.AccountKey) []byte {
+	return append(GetReferralPrefix(referrer), referee...)
+}
+
+// GetGuardiansKey - "guardians substore" + "me"
+func GetGuardiansKey(me types.AccountKey) []byte {
+	return append(accountGuardiansSubstore, me...)
+}
+
+// GetRecoveryRequestKey - "recovery request substore" + "me"
+func GetRecoveryRequestKey(me types.AccountKey) []byte {
+	return append(accountRecoveryRequestSubstore, me...)
+}
+
+// GetUsernameListingKey - "username listing substore" + "me"
+func GetUsernameListingKey(me types.AccountKey) []byte {
+	return append(accountUsernameListingSubstore, me...)
+}
+
+// GetProfileKey - "profile substore" + "me"
+func GetProfileKey(me types.AccountKey) []byte {
+	return append(accountProfileSubstore, me...)
+}
+
+// GetRetiredAccountKey - "retired account substore" + "me"
+func GetRetiredAccountKey(me types.AccountKey) []byte {
+	return append(accountRetiredSubstore, me...)
 }
 
 func getBalanceHistoryPrefix(me types.AccountKey) []byte {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store"
 	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -104,6 +105,32 @@ func TestAccountReward(t *testing.T) {
 	assert.Equal(t, reward, *resultPtr, "Account reward should be equal")
 }
 
+func TestAccountFollowers(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	followers, err := as.GetFollowers(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []FollowerMeta{}, followers)
+
+	follower := FollowerMeta{CreatedAt: 1, FollowerName: "user2"}
+	following := FollowingMeta{CreatedAt: 2, FollowingName: "user3"}
+	err = as.SetFollowerMeta(ctx, types.AccountKey("user1"), follower)
+	assert.Nil(t, err)
+	err = as.SetFollowingMeta(ctx, types.AccountKey("user1"), following)
+	assert.Nil(t, err)
+	// relations of other account shouldn't be listed
+	err = as.SetFollowerMeta(ctx, types.AccountKey("user11"), follower)
+	assert.Nil(t, err)
+
+	followers, err = as.GetFollowers(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []FollowerMeta{follower}, followers)
+	followings, err := as.GetFollowings(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []FollowingMeta{following}, followings)
+}
+
 func TestAccountRelationShip(t *testing.T) {
 	as := NewAccountStorage(TestKVStoreKey)
 	ctx := getContext()
@@ -118,6 +145,190 @@ func TestAccountRelationShip(t *testing.T) {
 	assert.Equal(t, relationship, *resultPtr, "Account relationship should be equal")
 }
 
+func TestAccountReferral(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	resultPtr, err := as.GetReferral(ctx, types.AccountKey("referrer"), types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+
+	referral1 := Referral{Referee: "user1", CreatedAt: 1, TotalReward: types.NewCoinFromInt64(1)}
+	referral2 := Referral{Referee: "user2", CreatedAt: 2, TotalReward: types.NewCoinFromInt64(0)}
+	err = as.SetReferral(ctx, types.AccountKey("referrer"), &referral2)
+	assert.Nil(t, err)
+	err = as.SetReferral(ctx, types.AccountKey("referrer"), &referral1)
+	assert.Nil(t, err)
+	// referral of other referrer shouldn't be listed
+	err = as.SetReferral(ctx, types.AccountKey("referrer2"), &referral1)
+	assert.Nil(t, err)
+
+	resultPtr, err = as.GetReferral(ctx, types.AccountKey("referrer"), types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, referral1, *resultPtr, "Account referral should be equal")
+
+	referrals, err := as.GetReferrals(ctx, types.AccountKey("referrer"))
+	assert.Nil(t, err)
+	assert.Equal(t, []Referral{referral1, referral2}, referrals)
+}
+
+func TestAccountGuardians(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	resultPtr, err := as.GetGuardians(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+
+	guardians := Guardians{Guardians: []types.AccountKey{"user2", "user3"}, Threshold: 2}
+	err = as.SetGuardians(ctx, types.AccountKey("user1"), &guardians)
+	assert.Nil(t, err)
+
+	resultPtr, err = as.GetGuardians(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, guardians, *resultPtr, "Account guardians should be equal")
+}
+
+func TestAccountRecoveryRequest(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	resultPtr, err := as.GetRecoveryRequest(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+
+	request := RecoveryRequest{
+		NewResetKey:       secp256k1.GenPrivKey().PubKey(),
+		NewTransactionKey: secp256k1.GenPrivKey().PubKey(),
+		NewAppKey:         secp256k1.GenPrivKey().PubKey(),
+		Approvals:         []types.AccountKey{"user2"},
+		CreatedAt:         1,
+		ExecuteAt:         2,
+	}
+	err = as.SetRecoveryRequest(ctx, types.AccountKey("user1"), &request)
+	assert.Nil(t, err)
+
+	resultPtr, err = as.GetRecoveryRequest(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, request, *resultPtr, "Account recovery request should be equal")
+
+	as.DeleteRecoveryRequest(ctx, types.AccountKey("user1"))
+	resultPtr, err = as.GetRecoveryRequest(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+}
+
+func TestAccountUsernameListing(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	resultPtr, err := as.GetUsernameListing(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+
+	listing := UsernameListing{
+		Price: types.NewCoinFromInt64(100), Payee: "user2", ClearGrants: true, CreatedAt: 1}
+	err = as.SetUsernameListing(ctx, types.AccountKey("user1"), &listing)
+	assert.Nil(t, err)
+
+	resultPtr, err = as.GetUsernameListing(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, listing, *resultPtr, "Username listing should be equal")
+
+	as.DeleteUsernameListing(ctx, types.AccountKey("user1"))
+	resultPtr, err = as.GetUsernameListing(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+}
+
+func TestAccountProfile(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	resultPtr, err := as.GetProfile(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+
+	profile := Profile{
+		DisplayName: "User One",
+		Avatar:      "ipfs://avatar",
+		Bio:         "bio",
+		Website:     "https://lino.network",
+		VerifiedLinks: []VerifiedLink{
+			{Platform: "twitter", URL: "https://twitter.com/user1"},
+		},
+	}
+	err = as.SetProfile(ctx, types.AccountKey("user1"), &profile)
+	assert.Nil(t, err)
+
+	resultPtr, err = as.GetProfile(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, profile, *resultPtr, "Profile should be equal")
+
+	as.DeleteProfile(ctx, types.AccountKey("user1"))
+	resultPtr, err = as.GetProfile(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+	user := types.AccountKey("test")
+
+	err := as.SetInfo(ctx, user, &AccountInfo{Username: user})
+	assert.Nil(t, err)
+	err = as.SetBankFromAccountKey(ctx, user, &AccountBank{})
+	assert.Nil(t, err)
+	err = as.SetMeta(ctx, user, &AccountMeta{})
+	assert.Nil(t, err)
+	err = as.SetReward(ctx, user, &Reward{})
+	assert.Nil(t, err)
+	err = as.SetPendingCoinDayQueue(ctx, user, &PendingCoinDayQueue{})
+	assert.Nil(t, err)
+	err = as.SetRelationship(ctx, user, types.AccountKey("other"), &Relationship{DonationTimes: 1})
+	assert.Nil(t, err)
+	balanceHistory := BalanceHistory{[]Detail{{Amount: types.NewCoinFromInt64(10), Balance: types.NewCoinFromInt64(0)}}}
+	err = as.SetBalanceHistory(ctx, user, 0, &balanceHistory)
+	assert.Nil(t, err)
+
+	as.DeleteAccount(ctx, user)
+	assert.False(t, as.DoesAccountExist(ctx, user))
+	_, err = as.GetBankFromAccountKey(ctx, user)
+	assert.NotNil(t, err)
+	_, err = as.GetMeta(ctx, user)
+	assert.NotNil(t, err)
+	_, err = as.GetReward(ctx, user)
+	assert.NotNil(t, err)
+	_, err = as.GetPendingCoinDayQueue(ctx, user)
+	assert.NotNil(t, err)
+	relationship, err := as.GetRelationship(ctx, user, types.AccountKey("other"))
+	assert.Nil(t, err)
+	assert.Nil(t, relationship)
+	// balance history is kept
+	resultPtr, err := as.GetBalanceHistory(ctx, user, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, balanceHistory, *resultPtr)
+}
+
+func TestAccountRetired(t *testing.T) {
+	as := NewAccountStorage(TestKVStoreKey)
+	ctx := getContext()
+
+	assert.False(t, as.IsUsernameRetired(ctx, types.AccountKey("user1")))
+	resultPtr, err := as.GetRetiredAccount(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Nil(t, resultPtr)
+
+	retired := RetiredAccount{Receiver: types.AccountKey("user2"), RetiredAt: 1}
+	err = as.SetRetiredAccount(ctx, types.AccountKey("user1"), &retired)
+	assert.Nil(t, err)
+	assert.True(t, as.IsUsernameRetired(ctx, types.AccountKey("user1")))
+	resultPtr, err = as.GetRetiredAccount(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, retired, *resultPtr, "Retired account should be equal")
+}
+
 func TestAccountBalanceHistory(t *testing.T) {
 	as := NewAccountStorage(TestKVStoreKey)
 	ctx := getContext()
@@ -171,7 +382,7 @@ func TestAccountGrantPubkey(t *testing.T) {
 	ctx := getContext()
 	priv := secp256k1.GenPrivKey()
 
-	grantPubKey := GrantPubKey{Amount: types.NewCoinFromInt64(0)}
+	grantPubKey := GrantPubKey{Amount: types.NewCoinFromInt64(0), PeriodLimit: types.NewCoinFromInt64(0)}
 	err := as.SetGrantPubKey(ctx, types.AccountKey("test"), priv.PubKey(), &grantPubKey)
 	assert.Nil(t, err)
 
@@ -179,6 +390,63 @@ func TestAccountGrantPubkey(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, grantPubKey, *resultPtr, "Account grant user should be equal")
 
+	priv2 := secp256k1.GenPrivKey()
+	grantPubKey2 := GrantPubKey{
+		Username: "app", Amount: types.NewCoinFromInt64(1), PeriodLimit: types.NewCoinFromInt64(0)}
+	err = as.SetGrantPubKey(ctx, types.AccountKey("test"), priv2.PubKey(), &grantPubKey2)
+	assert.Nil(t, err)
+	grantPubKeys := map[string]GrantPubKey{}
+	err = as.IterateGrantPubKeys(ctx, types.AccountKey("test"), func(pubKey crypto.PubKey, grantPubKey GrantPubKey) bool {
+		grantPubKeys[string(pubKey.Bytes())] = grantPubKey
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]GrantPubKey{
+		string(priv.PubKey().Bytes()):  grantPubKey,
+		string(priv2.PubKey().Bytes()): grantPubKey2,
+	}, grantPubKeys)
+
+	err = as.SetGrantPubKey(ctx, types.AccountKey("test2"), priv2.PubKey(), &grantPubKey2)
+	assert.Nil(t, err)
+	getAppGrantors := func(app types.AccountKey) []types.AccountKey {
+		grantors := []types.AccountKey{}
+		err := as.IterateAppGrantPubKeys(ctx, app,
+			func(me types.AccountKey, pubKey crypto.PubKey, grantPubKey GrantPubKey) bool {
+				assert.True(t, pubKey.Equals(priv2.PubKey()))
+				assert.Equal(t, grantPubKey2, grantPubKey)
+				grantors = append(grantors, me)
+				return false
+			})
+		assert.Nil(t, err)
+		return grantors
+	}
+	assert.Equal(t, []types.AccountKey{"test", "test2"}, getAppGrantors("app"))
+	assert.Equal(t, []types.AccountKey{}, getAppGrantors("ap"))
+
+	// regrant to another app removes the grant from previous app index
+	grantPubKey3 := grantPubKey2
+	grantPubKey3.Username = "app2"
+	err = as.SetGrantPubKey(ctx, types.AccountKey("test2"), priv2.PubKey(), &grantPubKey3)
+	assert.Nil(t, err)
+	assert.Equal(t, []types.AccountKey{"test"}, getAppGrantors("app"))
+	as.DeleteGrantPubKey(ctx, types.AccountKey("test"), priv2.PubKey())
+	assert.Equal(t, []types.AccountKey{}, getAppGrantors("app"))
+
+	// grants set before grants were indexed by app are indexed once
+	grantPubKeyBytes, marshalErr := as.cdc.MarshalJSON(grantPubKey2)
+	assert.Nil(t, marshalErr)
+	ctx.KVStore(TestKVStoreKey).Set(GetGrantPubKeyKey(types.AccountKey("test3"), priv2.PubKey()), grantPubKeyBytes)
+	assert.Equal(t, []types.AccountKey{}, getAppGrantors("app"))
+	err = as.BuildAppGrantIndex(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []types.AccountKey{"test3"}, getAppGrantors("app"))
+	as.DeleteGrantPubKey(ctx, types.AccountKey("test3"), priv2.PubKey())
+	err = as.SetGrantPubKey(ctx, types.AccountKey("test2"), priv2.PubKey(), &grantPubKey2)
+	assert.Nil(t, err)
+	err = as.BuildAppGrantIndex(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []types.AccountKey{"test2"}, getAppGrantors("app"))
+
 	as.DeleteGrantPubKey(ctx, types.AccountKey("test"), priv.PubKey())
 	resultPtr, err = as.GetGrantPubKey(ctx, types.AccountKey("test"), priv.PubKey())
 	assert.NotNil(t, err)
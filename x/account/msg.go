@@ -6,6 +6,7 @@ import (
 	"regexp"
 
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/account/model"
 	crypto "github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,6 +19,16 @@ var _ types.Msg = TransferMsg{}
 var _ types.Msg = RecoverMsg{}
 var _ types.Msg = RegisterMsg{}
 var _ types.Msg = UpdateAccountMsg{}
+var _ types.Msg = SetGuardiansMsg{}
+var _ types.Msg = ApproveRecoveryMsg{}
+var _ types.Msg = CancelRecoveryMsg{}
+var _ types.Msg = ChangeAppKeyMsg{}
+var _ types.Msg = ChangeTransactionKeyMsg{}
+var _ types.Msg = ListUsernameMsg{}
+var _ types.Msg = CancelUsernameListingMsg{}
+var _ types.Msg = BuyUsernameMsg{}
+var _ types.Msg = UpdateProfileMsg{}
+var _ types.Msg = CloseAccountMsg{}
 
 // RegisterMsg - bind username with public key, need to be referred by others (pay for it)
 type RegisterMsg struct {
@@ -68,6 +79,76 @@ type UpdateAccountMsg struct {
 	JSONMeta string           `json:"json_meta"`
 }
 
+// SetGuardiansMsg - designate guardians and the number of approvals needed for social recovery,
+// empty guardians with zero threshold disable social recovery
+type SetGuardiansMsg struct {
+	Username  types.AccountKey   `json:"username"`
+	Guardians []types.AccountKey `json:"guardians"`
+	Threshold int64              `json:"threshold"`
+}
+
+// ApproveRecoveryMsg - guardian approves new keys for the account
+type ApproveRecoveryMsg struct {
+	Guardian             types.AccountKey `json:"guardian"`
+	Username             types.AccountKey `json:"username"`
+	NewResetPubKey       crypto.PubKey    `json:"new_reset_public_key"`
+	NewTransactionPubKey crypto.PubKey    `json:"new_transaction_public_key"`
+	NewAppPubKey         crypto.PubKey    `json:"new_app_public_key"`
+}
+
+// CancelRecoveryMsg - cancel pending social recovery of the account
+type CancelRecoveryMsg struct {
+	Username types.AccountKey `json:"username"`
+}
+
+// ChangeAppKeyMsg - replace app key, signed by transaction key
+type ChangeAppKeyMsg struct {
+	Username     types.AccountKey `json:"username"`
+	NewAppPubKey crypto.PubKey    `json:"new_app_public_key"`
+}
+
+// ChangeTransactionKeyMsg - replace transaction key, signed by reset key
+type ChangeTransactionKeyMsg struct {
+	Username             types.AccountKey `json:"username"`
+	NewTransactionPubKey crypto.PubKey    `json:"new_transaction_public_key"`
+}
+
+// ListUsernameMsg - list username for sale, price is paid to payee
+type ListUsernameMsg struct {
+	Username     types.AccountKey `json:"username"`
+	Payee        types.AccountKey `json:"payee"`
+	Price        types.LNO        `json:"price"`
+	ClearGrants  bool             `json:"clear_grants"`
+	ClearFollows bool             `json:"clear_follows"`
+}
+
+// CancelUsernameListingMsg - take username off sale
+type CancelUsernameListingMsg struct {
+	Username types.AccountKey `json:"username"`
+}
+
+// BuyUsernameMsg - buy listed username, buyer's keys replace all keys of the username
+type BuyUsernameMsg struct {
+	Buyer                types.AccountKey `json:"buyer"`
+	Username             types.AccountKey `json:"username"`
+	Price                types.LNO        `json:"price"`
+	NewResetPubKey       crypto.PubKey    `json:"new_reset_public_key"`
+	NewTransactionPubKey crypto.PubKey    `json:"new_transaction_public_key"`
+	NewAppPubKey         crypto.PubKey    `json:"new_app_public_key"`
+}
+
+// UpdateProfileMsg - replace structured profile of the account
+type UpdateProfileMsg struct {
+	Username types.AccountKey `json:"username"`
+	Profile  model.Profile    `json:"profile"`
+}
+
+// CloseAccountMsg - sweep remaining balance to receiver and retire the username
+type CloseAccountMsg struct {
+	Username types.AccountKey `json:"username"`
+	Receiver types.AccountKey `json:"receiver"`
+}
+
 // NewFollowMsg - return a FollowMsg
 func NewFollowMsg(follower string, followee string) FollowMsg {
 	return FollowMsg{
@@ -460,3 +541,589 @@ func (msg UpdateAccountMsg) GetSigners() []sdk.AccAddress {
 func (msg UpdateAccountMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
+
+// NewSetGuardiansMsg - return a SetGuardiansMsg
+func NewSetGuardiansMsg(username string, guardians []string, threshold int64) SetGuardiansMsg {
+	guardianKeys := []types.AccountKey{}
+	for _, guardian := range guardians {
+		guardianKeys = append(guardianKeys, types.AccountKey(guardian))
+	}
+	return SetGuardiansMsg{
+		Username:  types.AccountKey(username),
+		Guardians: guardianKeys,
+		Threshold: threshold,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg SetGuardiansMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg SetGuardiansMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if len(msg.Guardians) > types.MaximumNumOfGuardians {
+		return ErrInvalidGuardians("too many guardians")
+	}
+	if msg.Threshold < 0 || msg.Threshold > int64(len(msg.Guardians)) ||
+		(msg.Threshold == 0 && len(msg.Guardians) != 0) {
+		return ErrInvalidGuardians("illegal threshold")
+	}
+	for i, guardian := range msg.Guardians {
+		if len(guardian) < types.MinimumUsernameLength ||
+			len(guardian) > types.MaximumUsernameLength {
+			return ErrInvalidUsername("illegal length")
+		}
+		if guardian == msg.Username {
+			return ErrInvalidGuardians("can't guard yourself")
+		}
+		if isAccountKeyIn(guardian, msg.Guardians[:i]) {
+			return ErrInvalidGuardians("duplicate guardian")
+		}
+	}
+	return nil
+}
+
+func (msg SetGuardiansMsg) String() string {
+	return fmt.Sprintf("SetGuardiansMsg{user:%v, guardians:%v, threshold:%v}",
+		msg.Username, msg.Guardians, msg.Threshold)
+}
+
+// GetPermission - implements types.Msg
+func (msg SetGuardiansMsg) GetPermission() types.Permission {
+	return types.ResetPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg SetGuardiansMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg SetGuardiansMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg SetGuardiansMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewApproveRecoveryMsg - return an ApproveRecoveryMsg
+func NewApproveRecoveryMsg(
+	guardian, username string, resetPubkey, transactionPubkey,
+	appPubkey crypto.PubKey) ApproveRecoveryMsg {
+	return ApproveRecoveryMsg{
+		Guardian:             types.AccountKey(guardian),
+		Username:             types.AccountKey(username),
+		NewResetPubKey:       resetPubkey,
+		NewTransactionPubKey: transactionPubkey,
+		NewAppPubKey:         appPubkey,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg ApproveRecoveryMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg ApproveRecoveryMsg) ValidateBasic() sdk.Error {
+	if len(msg.Guardian) < types.MinimumUsernameLength ||
+		len(msg.Guardian) > types.MaximumUsernameLength ||
+		len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if msg.NewResetPubKey == nil || msg.NewTransactionPubKey == nil || msg.NewAppPubKey == nil {
+		return ErrInvalidGuardians("new keys are required")
+	}
+	return nil
+}
+
+func (msg ApproveRecoveryMsg) String() string {
+	return fmt.Sprintf("ApproveRecoveryMsg{guardian:%v, user:%v, new reset key:%v, new app Key:%v, new transaction key:%v}",
+		msg.Guardian, msg.Username, msg.NewResetPubKey, msg.NewAppPubKey, msg.NewTransactionPubKey)
+}
+
+// GetPermission - implements types.Msg
+func (msg ApproveRecoveryMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg ApproveRecoveryMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg ApproveRecoveryMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Guardian)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg ApproveRecoveryMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewCancelRecoveryMsg - return a CancelRecoveryMsg
+func NewCancelRecoveryMsg(username string) CancelRecoveryMsg {
+	return CancelRecoveryMsg{
+		Username: types.AccountKey(username),
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg CancelRecoveryMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg CancelRecoveryMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	return nil
+}
+
+func (msg CancelRecoveryMsg) String() string {
+	return fmt.Sprintf("CancelRecoveryMsg{user:%v}", msg.Username)
+}
+
+// GetPermission - implements types.Msg
+func (msg CancelRecoveryMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg CancelRecoveryMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg CancelRecoveryMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg CancelRecoveryMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewChangeAppKeyMsg - return a ChangeAppKeyMsg
+func NewChangeAppKeyMsg(username string, appPubkey crypto.PubKey) ChangeAppKeyMsg {
+	return ChangeAppKeyMsg{
+		Username:     types.AccountKey(username),
+		NewAppPubKey: appPubkey,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg ChangeAppKeyMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg ChangeAppKeyMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if msg.NewAppPubKey == nil {
+		return ErrInvalidNewKey("app key is required")
+	}
+	return nil
+}
+
+func (msg ChangeAppKeyMsg) String() string {
+	return fmt.Sprintf("ChangeAppKeyMsg{user:%v, new app key:%v}", msg.Username, msg.NewAppPubKey)
+}
+
+// GetPermission - implements types.Msg
+func (msg ChangeAppKeyMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg ChangeAppKeyMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg ChangeAppKeyMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg ChangeAppKeyMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewChangeTransactionKeyMsg - return a ChangeTransactionKeyMsg
+func NewChangeTransactionKeyMsg(username string, transactionPubkey crypto.PubKey) ChangeTransactionKeyMsg {
+	return ChangeTransactionKeyMsg{
+		Username:             types.AccountKey(username),
+		NewTransactionPubKey: transactionPubkey,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg ChangeTransactionKeyMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg ChangeTransactionKeyMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if msg.NewTransactionPubKey == nil {
+		return ErrInvalidNewKey("transaction key is required")
+	}
+	return nil
+}
+
+func (msg ChangeTransactionKeyMsg) String() string {
+	return fmt.Sprintf("ChangeTransactionKeyMsg{user:%v, new transaction key:%v}",
+		msg.Username, msg.NewTransactionPubKey)
+}
+
+// GetPermission - implements types.Msg
+func (msg ChangeTransactionKeyMsg) GetPermission() types.Permission {
+	return types.ResetPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg ChangeTransactionKeyMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg ChangeTransactionKeyMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg ChangeTransactionKeyMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewListUsernameMsg - return a ListUsernameMsg
+func NewListUsernameMsg(
+	username, payee string, price types.LNO, clearGrants, clearFollows bool) ListUsernameMsg {
+	return ListUsernameMsg{
+		Username:     types.AccountKey(username),
+		Payee:        types.AccountKey(payee),
+		Price:        price,
+		ClearGrants:  clearGrants,
+		ClearFollows: clearFollows,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg ListUsernameMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg ListUsernameMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength ||
+		len(msg.Payee) < types.MinimumUsernameLength ||
+		len(msg.Payee) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if msg.Payee == msg.Username {
+		return ErrInvalidUsernameListing("payee can't be the listed username")
+	}
+	// price below lower bound is rejected
+	if _, err := types.LinoToCoin(msg.Price); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (msg ListUsernameMsg) String() string {
+	return fmt.Sprintf("ListUsernameMsg{user:%v, payee:%v, price:%v, clear grants:%v, clear follows:%v}",
+		msg.Username, msg.Payee, msg.Price, msg.ClearGrants, msg.ClearFollows)
+}
+
+// GetPermission - implements types.Msg
+func (msg ListUsernameMsg) GetPermission() types.Permission {
+	return types.ResetPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg ListUsernameMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg ListUsernameMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg ListUsernameMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewCancelUsernameListingMsg - return a CancelUsernameListingMsg
+func NewCancelUsernameListingMsg(username string) CancelUsernameListingMsg {
+	return CancelUsernameListingMsg{
+		Username: types.AccountKey(username),
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg CancelUsernameListingMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg CancelUsernameListingMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	return nil
+}
+
+func (msg CancelUsernameListingMsg) String() string {
+	return fmt.Sprintf("CancelUsernameListingMsg{user:%v}", msg.Username)
+}
+
+// GetPermission - implements types.Msg
+func (msg CancelUsernameListingMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg CancelUsernameListingMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg CancelUsernameListingMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg CancelUsernameListingMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewBuyUsernameMsg - return a BuyUsernameMsg
+func NewBuyUsernameMsg(
+	buyer, username string, price types.LNO, resetPubkey, transactionPubkey,
+	appPubkey crypto.PubKey) BuyUsernameMsg {
+	return BuyUsernameMsg{
+		Buyer:                types.AccountKey(buyer),
+		Username:             types.AccountKey(username),
+		Price:                price,
+		NewResetPubKey:       resetPubkey,
+		NewTransactionPubKey: transactionPubkey,
+		NewAppPubKey:         appPubkey,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg BuyUsernameMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg BuyUsernameMsg) ValidateBasic() sdk.Error {
+	if len(msg.Buyer) < types.MinimumUsernameLength ||
+		len(msg.Buyer) > types.MaximumUsernameLength ||
+		len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if msg.Buyer == msg.Username {
+		return ErrInvalidUsernameListing("can't buy your own username")
+	}
+	if _, err := types.LinoToCoin(msg.Price); err != nil {
+		return err
+	}
+	if msg.NewResetPubKey == nil || msg.NewTransactionPubKey == nil || msg.NewAppPubKey == nil {
+		return ErrInvalidNewKey("new keys are required")
+	}
+	return nil
+}
+
+func (msg BuyUsernameMsg) String() string {
+	return fmt.Sprintf("BuyUsernameMsg{buyer:%v, user:%v, price:%v, new reset key:%v, new app Key:%v, new transaction key:%v}",
+		msg.Buyer, msg.Username, msg.Price, msg.NewResetPubKey, msg.NewAppPubKey, msg.NewTransactionPubKey)
+}
+
+// GetPermission - implements types.Msg
+func (msg BuyUsernameMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg BuyUsernameMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg BuyUsernameMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Buyer)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg BuyUsernameMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewUpdateProfileMsg - return a UpdateProfileMsg
+func NewUpdateProfileMsg(username string, profile model.Profile) UpdateProfileMsg {
+	return UpdateProfileMsg{
+		Username: types.AccountKey(username),
+		Profile:  profile,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg UpdateProfileMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg UpdateProfileMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if len(msg.Profile.DisplayName) > types.MaximumLengthOfDisplayName {
+		return ErrInvalidProfile("display name is too long")
+	}
+	if len(msg.Profile.Avatar) > types.MaximumLengthOfAvatar {
+		return ErrInvalidProfile("avatar is too long")
+	}
+	if len(msg.Profile.Bio) > types.MaximumLengthOfBio {
+		return ErrInvalidProfile("bio is too long")
+	}
+	if len(msg.Profile.Website) > types.MaximumLengthOfProfileWebsite {
+		return ErrInvalidProfile("website is too long")
+	}
+	if len(msg.Profile.VerifiedLinks) > types.MaximumNumOfVerifiedLinks {
+		return ErrInvalidProfile("too many verified links")
+	}
+	platforms := map[string]bool{}
+	for _, link := range msg.Profile.VerifiedLinks {
+		if len(link.Platform) == 0 || len(link.Platform) > types.MaximumLinkIdentifier {
+			return ErrInvalidProfile("illegal verified link platform")
+		}
+		if len(link.URL) == 0 || len(link.URL) > types.MaximumLinkURL {
+			return ErrInvalidProfile("illegal verified link URL")
+		}
+		if platforms[link.Platform] {
+			return ErrInvalidProfile(fmt.Sprintf("duplicate verified link platform %v", link.Platform))
+		}
+		platforms[link.Platform] = true
+	}
+	return nil
+}
+
+func (msg UpdateProfileMsg) String() string {
+	return fmt.Sprintf("UpdateProfileMsg{user:%v, profile:%v}", msg.Username, msg.Profile)
+}
+
+// GetPermission - implements types.Msg
+func (msg UpdateProfileMsg) GetPermission() types.Permission {
+	return types.AppPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg UpdateProfileMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg UpdateProfileMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg UpdateProfileMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// NewCloseAccountMsg - return a CloseAccountMsg
+func NewCloseAccountMsg(username, receiver string) CloseAccountMsg {
+	return CloseAccountMsg{
+		Username: types.AccountKey(username),
+		Receiver: types.AccountKey(receiver),
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg CloseAccountMsg) Type() string { return types.AccountRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg CloseAccountMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength ||
+		len(msg.Receiver) < types.MinimumUsernameLength ||
+		len(msg.Receiver) > types.MaximumUsernameLength {
+		return ErrInvalidUsername("illegal length")
+	}
+	if msg.Username == msg.Receiver {
+		return ErrInvalidAccountClose("receiver can't be the closed account")
+	}
+	return nil
+}
+
+func (msg CloseAccountMsg) String() string {
+	return fmt.Sprintf("CloseAccountMsg{user:%v, receiver:%v}", msg.Username, msg.Receiver)
+}
+
+// GetPermission - implements types.Msg
+func (msg CloseAccountMsg) GetPermission() types.Permission {
+	return types.ResetPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg CloseAccountMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg CloseAccountMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg CloseAccountMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
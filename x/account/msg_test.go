@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/account/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
@@ -392,6 +393,379 @@ func TestRegisterUsername(t *testing.T) {
 	}
 }
 
+func TestSetGuardiansMsg(t *testing.T) {
+	testCases := map[string]struct {
+		msg      SetGuardiansMsg
+		wantCode sdk.CodeType
+	}{
+		"normal case": {
+			msg:      NewSetGuardiansMsg("test", []string{"userA", "userB", "userC"}, 2),
+			wantCode: sdk.CodeOK,
+		},
+		"disable social recovery": {
+			msg:      NewSetGuardiansMsg("test", []string{}, 0),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid set guardians - Username is too short": {
+			msg:      NewSetGuardiansMsg("te", []string{"userA"}, 1),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid set guardians - guardian is too short": {
+			msg:      NewSetGuardiansMsg("test", []string{"us"}, 1),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid set guardians - too many guardians": {
+			msg: NewSetGuardiansMsg("test", []string{
+				"user0", "user1", "user2", "user3", "user4", "user5",
+				"user6", "user7", "user8", "user9", "user10"}, 1),
+			wantCode: types.CodeInvalidGuardians,
+		},
+		"invalid set guardians - threshold larger than number of guardians": {
+			msg:      NewSetGuardiansMsg("test", []string{"userA", "userB"}, 3),
+			wantCode: types.CodeInvalidGuardians,
+		},
+		"invalid set guardians - zero threshold": {
+			msg:      NewSetGuardiansMsg("test", []string{"userA", "userB"}, 0),
+			wantCode: types.CodeInvalidGuardians,
+		},
+		"invalid set guardians - negative threshold": {
+			msg:      NewSetGuardiansMsg("test", []string{}, -1),
+			wantCode: types.CodeInvalidGuardians,
+		},
+		"invalid set guardians - guard yourself": {
+			msg:      NewSetGuardiansMsg("test", []string{"userA", "test"}, 1),
+			wantCode: types.CodeInvalidGuardians,
+		},
+		"invalid set guardians - duplicate guardian": {
+			msg:      NewSetGuardiansMsg("test", []string{"userA", "userA"}, 1),
+			wantCode: types.CodeInvalidGuardians,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
+func TestApproveRecoveryMsg(t *testing.T) {
+	testCases := map[string]struct {
+		msg      ApproveRecoveryMsg
+		wantCode sdk.CodeType
+	}{
+		"normal case": {
+			msg: NewApproveRecoveryMsg("userA", "test", secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(),
+			),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid approve recovery - guardian is too short": {
+			msg: NewApproveRecoveryMsg("us", "test", secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(),
+			),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid approve recovery - Username is too long": {
+			msg: NewApproveRecoveryMsg("userA", "testtesttesttesttesttest", secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey(),
+			),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid approve recovery - missing new key": {
+			msg: NewApproveRecoveryMsg("userA", "test", secp256k1.GenPrivKey().PubKey(),
+				nil, secp256k1.GenPrivKey().PubKey(),
+			),
+			wantCode: types.CodeInvalidGuardians,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
+func TestCancelRecoveryMsg(t *testing.T) {
+	testCases := map[string]struct {
+		msg      CancelRecoveryMsg
+		wantCode sdk.CodeType
+	}{
+		"normal case": {
+			msg:      NewCancelRecoveryMsg("test"),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid cancel recovery - Username is too short": {
+			msg:      NewCancelRecoveryMsg("te"),
+			wantCode: types.CodeInvalidUsername,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
+func TestChangeKeyMsg(t *testing.T) {
+	testCases := map[string]struct {
+		msg      types.Msg
+		wantCode sdk.CodeType
+	}{
+		"normal change app key": {
+			msg:      NewChangeAppKeyMsg("test", secp256k1.GenPrivKey().PubKey()),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid change app key - Username is too short": {
+			msg:      NewChangeAppKeyMsg("te", secp256k1.GenPrivKey().PubKey()),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid change app key - missing new key": {
+			msg:      NewChangeAppKeyMsg("test", nil),
+			wantCode: types.CodeInvalidNewKey,
+		},
+		"normal change transaction key": {
+			msg:      NewChangeTransactionKeyMsg("test", secp256k1.GenPrivKey().PubKey()),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid change transaction key - Username is too long": {
+			msg:      NewChangeTransactionKeyMsg("testtesttesttesttesttest", secp256k1.GenPrivKey().PubKey()),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid change transaction key - missing new key": {
+			msg:      NewChangeTransactionKeyMsg("test", nil),
+			wantCode: types.CodeInvalidNewKey,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
+func TestUsernameSaleMsg(t *testing.T) {
+	testCases := map[string]struct {
+		msg      types.Msg
+		wantCode sdk.CodeType
+	}{
+		"normal list username": {
+			msg:      NewListUsernameMsg("test", "payee", types.LNO("100"), true, false),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid list username - payee is too short": {
+			msg:      NewListUsernameMsg("test", "pa", types.LNO("100"), true, false),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid list username - payee is the listed username": {
+			msg:      NewListUsernameMsg("test", "test", types.LNO("100"), true, false),
+			wantCode: types.CodeInvalidUsernameListing,
+		},
+		"invalid list username - zero price": {
+			msg:      NewListUsernameMsg("test", "payee", types.LNO("0"), true, false),
+			wantCode: types.CodeInvalidCoins,
+		},
+		"invalid list username - illegal price": {
+			msg:      NewListUsernameMsg("test", "payee", types.LNO("-1"), true, false),
+			wantCode: types.CodeInvalidCoins,
+		},
+		"normal cancel username listing": {
+			msg:      NewCancelUsernameListingMsg("test"),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid cancel username listing - Username is too short": {
+			msg:      NewCancelUsernameListingMsg("te"),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"normal buy username": {
+			msg: NewBuyUsernameMsg("buyer", "test", types.LNO("100"), secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid buy username - buy your own username": {
+			msg: NewBuyUsernameMsg("test", "test", types.LNO("100"), secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+			wantCode: types.CodeInvalidUsernameListing,
+		},
+		"invalid buy username - missing new key": {
+			msg: NewBuyUsernameMsg("buyer", "test", types.LNO("100"), secp256k1.GenPrivKey().PubKey(),
+				nil, secp256k1.GenPrivKey().PubKey()),
+			wantCode: types.CodeInvalidNewKey,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
+func TestUpdateProfileMsg(t *testing.T) {
+	longString := string(make([]byte, types.MaximumLengthOfBio+1))
+	testCases := map[string]struct {
+		msg      UpdateProfileMsg
+		wantCode sdk.CodeType
+	}{
+		"normal case": {
+			msg: NewUpdateProfileMsg("test", model.Profile{
+				DisplayName: "Test", Avatar: "ipfs://avatar", Bio: "bio", Website: "https://lino.network",
+				VerifiedLinks: []model.VerifiedLink{
+					{Platform: "twitter", URL: "https://twitter.com/test"},
+					{Platform: "github", URL: "https://github.com/test"},
+				}}),
+			wantCode: sdk.CodeOK,
+		},
+		"empty profile": {
+			msg:      NewUpdateProfileMsg("test", model.Profile{}),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid username": {
+			msg:      NewUpdateProfileMsg("te", model.Profile{}),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"display name is too long": {
+			msg:      NewUpdateProfileMsg("test", model.Profile{DisplayName: longString}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"avatar is too long": {
+			msg:      NewUpdateProfileMsg("test", model.Profile{Avatar: longString}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"bio is too long": {
+			msg:      NewUpdateProfileMsg("test", model.Profile{Bio: longString}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"website is too long": {
+			msg:      NewUpdateProfileMsg("test", model.Profile{Website: longString}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"too many verified links": {
+			msg: NewUpdateProfileMsg("test", model.Profile{
+				VerifiedLinks: make([]model.VerifiedLink, types.MaximumNumOfVerifiedLinks+1)}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"verified link without platform": {
+			msg: NewUpdateProfileMsg("test", model.Profile{
+				VerifiedLinks: []model.VerifiedLink{{URL: "https://twitter.com/test"}}}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"verified link without URL": {
+			msg: NewUpdateProfileMsg("test", model.Profile{
+				VerifiedLinks: []model.VerifiedLink{{Platform: "twitter"}}}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"verified link URL is too long": {
+			msg: NewUpdateProfileMsg("test", model.Profile{
+				VerifiedLinks: []model.VerifiedLink{{Platform: "twitter", URL: longString}}}),
+			wantCode: types.CodeInvalidProfile,
+		},
+		"duplicate verified link platform": {
+			msg: NewUpdateProfileMsg("test", model.Profile{
+				VerifiedLinks: []model.VerifiedLink{
+					{Platform: "twitter", URL: "https://twitter.com/test"},
+					{Platform: "twitter", URL: "https://twitter.com/test2"},
+				}}),
+			wantCode: types.CodeInvalidProfile,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
+func TestCloseAccountMsg(t *testing.T) {
+	testCases := map[string]struct {
+		msg      CloseAccountMsg
+		wantCode sdk.CodeType
+	}{
+		"normal case": {
+			msg:      NewCloseAccountMsg("test", "receiver"),
+			wantCode: sdk.CodeOK,
+		},
+		"invalid username": {
+			msg:      NewCloseAccountMsg("te", "receiver"),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"invalid receiver": {
+			msg:      NewCloseAccountMsg("test", "re"),
+			wantCode: types.CodeInvalidUsername,
+		},
+		"receiver is the closed account": {
+			msg:      NewCloseAccountMsg("test", "test"),
+			wantCode: types.CodeInvalidAccountClose,
+		},
+	}
+
+	for testName, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+
+		if got == nil {
+			if tc.wantCode != sdk.CodeOK {
+				t.Errorf("%s: diff error: got %v, want %v", testName, got, tc.wantCode)
+			}
+			continue
+		}
+		if got.Code() != tc.wantCode {
+			t.Errorf("%s: diff error code: got %v, want %v", testName, got.Code(), tc.wantCode)
+		}
+	}
+}
+
 func TestMsgPermission(t *testing.T) {
 	cases := map[string]struct {
 		msg              types.Msg
@@ -428,6 +802,49 @@ func TestMsgPermission(t *testing.T) {
 			msg:              NewUpdateAccountMsg("user", "{'test':'test'}"),
 			expectPermission: types.AppPermission,
 		},
+		"set guardians msg": {
+			msg:              NewSetGuardiansMsg("user", []string{"userA"}, 1),
+			expectPermission: types.ResetPermission,
+		},
+		"approve recovery msg": {
+			msg: NewApproveRecoveryMsg(
+				"userA", "user", secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+			expectPermission: types.TransactionPermission,
+		},
+		"cancel recovery msg": {
+			msg:              NewCancelRecoveryMsg("user"),
+			expectPermission: types.TransactionPermission,
+		},
+		"change app key msg": {
+			msg:              NewChangeAppKeyMsg("user", secp256k1.GenPrivKey().PubKey()),
+			expectPermission: types.TransactionPermission,
+		},
+		"change transaction key msg": {
+			msg:              NewChangeTransactionKeyMsg("user", secp256k1.GenPrivKey().PubKey()),
+			expectPermission: types.ResetPermission,
+		},
+		"list username msg": {
+			msg:              NewListUsernameMsg("user", "payee", types.LNO("1"), false, false),
+			expectPermission: types.ResetPermission,
+		},
+		"cancel username listing msg": {
+			msg:              NewCancelUsernameListingMsg("user"),
+			expectPermission: types.TransactionPermission,
+		},
+		"buy username msg": {
+			msg: NewBuyUsernameMsg("buyer", "user", types.LNO("1"), secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+			expectPermission: types.TransactionPermission,
+		},
+		"update profile msg": {
+			msg:              NewUpdateProfileMsg("user", model.Profile{DisplayName: "user"}),
+			expectPermission: types.AppPermission,
+		},
+		"close account msg": {
+			msg:              NewCloseAccountMsg("user", "receiver"),
+			expectPermission: types.ResetPermission,
+		},
 	}
 
 	for testName, tc := range cases {
@@ -478,6 +895,39 @@ func TestGetSignBytes(t *testing.T) {
 		"update msg": {
 			msg: NewUpdateAccountMsg("user", "{'test':'test'}"),
 		},
+		"set guardians msg": {
+			msg: NewSetGuardiansMsg("user", []string{"userA"}, 1),
+		},
+		"approve recovery msg": {
+			msg: NewApproveRecoveryMsg(
+				"userA", "user", secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+		},
+		"cancel recovery msg": {
+			msg: NewCancelRecoveryMsg("user"),
+		},
+		"change app key msg": {
+			msg: NewChangeAppKeyMsg("user", secp256k1.GenPrivKey().PubKey()),
+		},
+		"change transaction key msg": {
+			msg: NewChangeTransactionKeyMsg("user", secp256k1.GenPrivKey().PubKey()),
+		},
+		"list username msg": {
+			msg: NewListUsernameMsg("user", "payee", types.LNO("1"), false, false),
+		},
+		"cancel username listing msg": {
+			msg: NewCancelUsernameListingMsg("user"),
+		},
+		"buy username msg": {
+			msg: NewBuyUsernameMsg("buyer", "user", types.LNO("1"), secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+		},
+		"update profile msg": {
+			msg: NewUpdateProfileMsg("user", model.Profile{DisplayName: "user"}),
+		},
+		"close account msg": {
+			msg: NewCloseAccountMsg("user", "receiver"),
+		},
 	}
 
 	for testName, tc := range cases {
@@ -536,6 +986,49 @@ func TestGetSigners(t *testing.T) {
 			msg:           NewUpdateAccountMsg("user", "{'test':'test'}"),
 			expectSigners: []types.AccountKey{"user"},
 		},
+		"set guardians msg": {
+			msg:           NewSetGuardiansMsg("user", []string{"userA"}, 1),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"approve recovery msg": {
+			msg: NewApproveRecoveryMsg(
+				"userA", "user", secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+			expectSigners: []types.AccountKey{"userA"},
+		},
+		"cancel recovery msg": {
+			msg:           NewCancelRecoveryMsg("user"),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"change app key msg": {
+			msg:           NewChangeAppKeyMsg("user", secp256k1.GenPrivKey().PubKey()),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"change transaction key msg": {
+			msg:           NewChangeTransactionKeyMsg("user", secp256k1.GenPrivKey().PubKey()),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"list username msg": {
+			msg:           NewListUsernameMsg("user", "payee", types.LNO("1"), false, false),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"cancel username listing msg": {
+			msg:           NewCancelUsernameListingMsg("user"),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"buy username msg": {
+			msg: NewBuyUsernameMsg("buyer", "user", types.LNO("1"), secp256k1.GenPrivKey().PubKey(),
+				secp256k1.GenPrivKey().PubKey(), secp256k1.GenPrivKey().PubKey()),
+			expectSigners: []types.AccountKey{"buyer"},
+		},
+		"update profile msg": {
+			msg:           NewUpdateProfileMsg("user", model.Profile{DisplayName: "user"}),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		"close account msg": {
+			msg:           NewCloseAccountMsg("user", "receiver"),
+			expectSigners: []types.AccountKey{"user"},
+		},
 	}
 
 	for testName, tc := range cases {
@@ -41,6 +41,7 @@ var (
 	c400  = types.NewCoinFromInt64(400 * types.Decimals)
 	c500  = types.NewCoinFromInt64(500 * types.Decimals)
 	c600  = types.NewCoinFromInt64(600 * types.Decimals)
+	c700  = types.NewCoinFromInt64(700 * types.Decimals)
 	c1000 = types.NewCoinFromInt64(1000 * types.Decimals)
 	c1500 = types.NewCoinFromInt64(1500 * types.Decimals)
 	c1600 = types.NewCoinFromInt64(1600 * types.Decimals)
@@ -75,6 +76,7 @@ func setupTest(t *testing.T, height int64) (sdk.Context, AccountManager, global.
 	cdc := globalManager.WireCodec()
 	cdc.RegisterInterface((*types.Event)(nil), nil)
 	cdc.RegisterConcrete(ReturnCoinEvent{}, "event/return", nil)
+	cdc.RegisterConcrete(RecoveryEvent{}, "event/recovery", nil)
 
 	err := initGlobalManager(ctx, globalManager)
 	assert.Nil(t, err)
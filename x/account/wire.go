@@ -2,17 +2,28 @@ package account
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // RegisterWire - register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(RegisterMsg{}, "lino/register", nil)
-	cdc.RegisterConcrete(FollowMsg{}, "lino/follow", nil)
-	cdc.RegisterConcrete(UnfollowMsg{}, "lino/unfollow", nil)
-	cdc.RegisterConcrete(TransferMsg{}, "lino/transfer", nil)
-	cdc.RegisterConcrete(ClaimMsg{}, "lino/claim", nil)
-	cdc.RegisterConcrete(RecoverMsg{}, "lino/recover", nil)
-	cdc.RegisterConcrete(UpdateAccountMsg{}, "lino/updateAcc", nil)
+	types.RegisterMsg(cdc, RegisterMsg{}, "lino/register")
+	types.RegisterMsg(cdc, FollowMsg{}, "lino/follow")
+	types.RegisterMsg(cdc, UnfollowMsg{}, "lino/unfollow")
+	types.RegisterMsg(cdc, TransferMsg{}, "lino/transfer")
+	types.RegisterMsg(cdc, ClaimMsg{}, "lino/claim")
+	types.RegisterMsg(cdc, RecoverMsg{}, "lino/recover")
+	types.RegisterMsg(cdc, UpdateAccountMsg{}, "lino/updateAcc")
+	types.RegisterMsg(cdc, SetGuardiansMsg{}, "lino/setGuardians")
+	types.RegisterMsg(cdc, ApproveRecoveryMsg{}, "lino/approveRecovery")
+	types.RegisterMsg(cdc, CancelRecoveryMsg{}, "lino/cancelRecovery")
+	types.RegisterMsg(cdc, ChangeAppKeyMsg{}, "lino/changeAppKey")
+	types.RegisterMsg(cdc, ChangeTransactionKeyMsg{}, "lino/changeTransactionKey")
+	types.RegisterMsg(cdc, ListUsernameMsg{}, "lino/listUsername")
+	types.RegisterMsg(cdc, CancelUsernameListingMsg{}, "lino/cancelUsernameListing")
+	types.RegisterMsg(cdc, BuyUsernameMsg{}, "lino/buyUsername")
+	types.RegisterMsg(cdc, UpdateProfileMsg{}, "lino/updateProfile")
+	types.RegisterMsg(cdc, CloseAccountMsg{}, "lino/closeAccount")
 }
 
 var msgCdc = wire.NewCodec()
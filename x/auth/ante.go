@@ -74,7 +74,8 @@ func NewAnteHandler(am acc.AccountManager, gm global.GlobalManager) sdk.AnteHand
 			consumeAmount := msg.GetConsumeAmount()
 			for _, msgSigner := range msgSigners {
 				// check public key is valid to sign this msg
-				_, err := am.CheckSigningPubKeyOwner(ctx, types.AccountKey(msgSigner), sigs[idx].PubKey, permission, consumeAmount)
+				_, err := am.CheckSigningPubKeyOwner(
+					ctx, types.AccountKey(msgSigner), sigs[idx].PubKey, permission, types.GetMsgType(msg), consumeAmount)
 				if err != nil {
 					return ctx, err.Result(), true
 				}
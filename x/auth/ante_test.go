@@ -233,7 +233,7 @@ func TestGrantAuthenticationTx(t *testing.T) {
 	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, seqs)
 	checkInvalidTx(t, anteHandler, ctx, tx, accstore.ErrGrantPubKeyNotFound().Result())
 
-	err = am.AuthorizePermission(ctx, user1, user2, 3600, types.AppPermission, types.NewCoinFromInt64(0))
+	err = am.AuthorizePermission(ctx, user1, user2, 3600, types.AppPermission, types.NewCoinFromInt64(0), nil)
 	assert.Nil(t, err)
 
 	// should still fail by using transaction key
@@ -256,7 +256,7 @@ func TestGrantAuthenticationTx(t *testing.T) {
 	checkInvalidTx(t, anteHandler, ctx, tx, acc.ErrGrantKeyExpired(user1).Result())
 
 	// test pre authorization permission
-	err = am.AuthorizePermission(ctx, user1, user3, 3600, types.PreAuthorizationPermission, types.NewCoinFromInt64(100))
+	err = am.AuthorizePermission(ctx, user1, user3, 3600, types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
 	assert.Nil(t, err)
 	msg.Permission = types.PreAuthorizationPermission
 	privs, seqs = []crypto.PrivKey{post3}, []int64{2}
@@ -283,6 +283,31 @@ func TestGrantAuthenticationTx(t *testing.T) {
 
 }
 
+// Test grant authentication with scopes.
+func TestScopedGrantAuthenticationTx(t *testing.T) {
+	am, _, ph, ctx, anteHandler := setupTest()
+	_, _, _, user1 := createTestAccount(ctx, am, ph, "user1")
+	_, _, post2, user2 := createTestAccount(ctx, am, ph, "user2")
+
+	err := am.AuthorizePermission(
+		ctx, user1, user2, 3600, types.AppPermission, types.NewCoinFromInt64(0), []string{"ViewMsg"})
+	assert.Nil(t, err)
+
+	// msg out of scopes can't be signed by granted app
+	msg := newTestMsg(user1)
+	privs, seqs := []crypto.PrivKey{post2}, []int64{0}
+	tx := newTestTx(ctx, []sdk.Msg{msg}, privs, seqs)
+	checkInvalidTx(t, anteHandler, ctx, tx, acc.ErrGrantScopeMismatch(user2, "TestMsg").Result())
+
+	// should pass authentication check after msg is added to scopes
+	err = am.UpdateGrantScopes(ctx, user1, user2, types.AppPermission, []string{"ViewMsg", "TestMsg"})
+	assert.Nil(t, err)
+	checkValidTx(t, anteHandler, ctx, tx)
+	seq, err := am.GetSequence(ctx, user1)
+	assert.Nil(t, err)
+	assert.Equal(t, seq, int64(1))
+}
+
 // Test various error cases in the AnteHandler control flow.
 func TestTPSCapacity(t *testing.T) {
 	am, gm, ph, ctx, anteHandler := setupTest()
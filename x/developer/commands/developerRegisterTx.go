@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
@@ -38,13 +36,7 @@ func sendDeveloperRegisterTx(cdc *wire.Codec) client.CommandTxCallback {
 			viper.GetString(client.FlagWebsite), viper.GetString(client.FlagDescription),
 			viper.GetString(client.FlagAppMeta))
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-		if signErr != nil {
-			return signErr
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
@@ -25,6 +23,7 @@ func GrantPermissionTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagDeveloper, "", "developer name to grant")
 	cmd.Flags().Int64(client.FlagSeconds, 3600, "seconds till expire")
 	cmd.Flags().String(client.FlagPermission, "app", "grant permission")
+	cmd.Flags().StringSlice(client.FlagScopes, nil, "comma separated msg types the app can sign, such as ViewMsg")
 	return cmd
 }
 
@@ -44,15 +43,10 @@ func sendGrantDeveloperTx(cdc *wire.Codec) client.CommandTxCallback {
 			return errors.New("only app permission are allowed")
 		}
 
-		msg := dev.NewGrantPermissionMsg(username, developer, seconds, permission)
-
-		// build and sign the transaction, then broadcast to Tendermint
-		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-		if signErr != nil {
-			return signErr
-		}
+		msg := dev.NewGrantPermissionMsg(
+			username, developer, seconds, permission, viper.GetStringSlice(client.FlagScopes))
 
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
 	"github.com/spf13/cobra"
@@ -22,7 +20,8 @@ func PreAuthorizationPermissionTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
 	cmd.Flags().String(client.FlagDeveloper, "", "developer name to grant")
 	cmd.Flags().Int64(client.FlagSeconds, 3600, "seconds till expire")
-	cmd.Flags().String(client.FlagGrantAmount, "", "granted amount")
+	cmd.Flags().String(client.FlagGrantAmount, "", "granted amount, spending limit of each period if period is set")
+	cmd.Flags().Int64(client.FlagPeriod, 0, "seconds of each spending period, 0 means granted amount is one-time")
 	return cmd
 }
 
@@ -35,15 +34,10 @@ func sendPreAuthorizationTx(cdc *wire.Codec) client.CommandTxCallback {
 		seconds := viper.GetInt64(client.FlagSeconds)
 		amount := viper.GetString(client.FlagGrantAmount)
 
-		msg := dev.NewPreAuthorizationMsg(username, developer, seconds, amount)
-
-		// build and sign the transaction, then broadcast to Tendermint
-		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-		if signErr != nil {
-			return signErr
-		}
+		msg := dev.NewPeriodicPreAuthorizationMsg(
+			username, developer, seconds, amount, viper.GetInt64(client.FlagPeriod))
 
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"encoding/hex"
-	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
@@ -23,6 +22,8 @@ func RevokePermissionTxCmd(cdc *wire.Codec) *cobra.Command {
 	}
 	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
 	cmd.Flags().String(client.FlagPubKey, "", "public key to revoke")
+	cmd.Flags().String(client.FlagDeveloper, "", "revoke all permissions granted to developer instead of a public key")
+	cmd.Flags().Bool(client.FlagAll, false, "revoke all permissions granted to any developer")
 	cmd.Flags().Int64(client.FlagSeconds, 3600, "seconds till expire")
 	return cmd
 }
@@ -32,23 +33,25 @@ func sendRevokePermissionTx(cdc *wire.Codec) client.CommandTxCallback {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := client.NewCoreContextFromViper()
 		username := viper.GetString(client.FlagUser)
-		pubKeyBytes, err := hex.DecodeString(viper.GetString(client.FlagPubKey))
-		if err != nil {
-			return err
-		}
-		pubKey, err := cryptoAmino.PubKeyFromBytes(pubKeyBytes)
-		if err != nil {
-			return err
-		}
-		msg := dev.NewRevokePermissionMsg(username, pubKey)
-
-		// build and sign the transaction, then broadcast to Tendermint
-		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-		if signErr != nil {
-			return signErr
+		var msg sdk.Msg
+		switch {
+		case viper.GetBool(client.FlagAll):
+			msg = dev.NewRevokeAllPermissionsMsg(username)
+		case viper.GetString(client.FlagDeveloper) != "":
+			msg = dev.NewRevokeAppPermissionMsg(username, viper.GetString(client.FlagDeveloper))
+		default:
+			pubKeyBytes, err := hex.DecodeString(viper.GetString(client.FlagPubKey))
+			if err != nil {
+				return err
+			}
+			pubKey, err := cryptoAmino.PubKeyFromBytes(pubKeyBytes)
+			if err != nil {
+				return err
+			}
+			msg = dev.NewRevokePermissionMsg(username, pubKey)
 		}
 
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
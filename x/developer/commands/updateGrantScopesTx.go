@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	dev "github.com/lino-network/lino/x/developer"
+)
+
+// UpdateGrantScopesTxCmd - user update msg types application can sign with granted permission
+func UpdateGrantScopesTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-grant-scopes",
+		Short: "update msg types granted developer can sign",
+		RunE:  sendUpdateGrantScopesTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user of this transaction")
+	cmd.Flags().String(client.FlagDeveloper, "", "developer granted permission")
+	cmd.Flags().String(client.FlagPermission, "app", "granted permission, app or preauth")
+	cmd.Flags().StringSlice(client.FlagScopes, nil, "comma separated msg types the app can sign, empty for all")
+	return cmd
+}
+
+// send update grant scopes transaction to the blockchain
+func sendUpdateGrantScopesTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		username := viper.GetString(client.FlagUser)
+		developer := viper.GetString(client.FlagDeveloper)
+		var permission types.Permission
+		switch viper.GetString(client.FlagPermission) {
+		case "app":
+			permission = types.AppPermission
+		case "preauth":
+			permission = types.PreAuthorizationPermission
+		default:
+			return errors.New("only app and preauth permission are allowed")
+		}
+
+		msg := dev.NewUpdateGrantScopesMsg(
+			username, developer, permission, viper.GetStringSlice(client.FlagScopes))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
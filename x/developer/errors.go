@@ -61,3 +61,13 @@ func ErrInvalidValidityPeriod() sdk.Error {
 func ErrGrantPermissionTooHigh() sdk.Error {
 	return types.NewError(types.CodeGrantPermissionTooHigh, fmt.Sprintf("grant permission is too high"))
 }
+
+// ErrInvalidGrantScopes - error if grant scopes are invalid
+func ErrInvalidGrantScopes(reason string) sdk.Error {
+	return types.NewError(types.CodeInvalidGrantScopes, fmt.Sprintf("invalid grant scopes: %v", reason))
+}
+
+// ErrInvalidPreAuthPeriod - error if preauth period is negative or longer than validity period
+func ErrInvalidPreAuthPeriod() sdk.Error {
+	return types.NewError(types.CodeInvalidPreAuthPeriod, fmt.Sprintf("invalid preauthorization period"))
+}
@@ -27,6 +27,12 @@ func NewHandler(dm DeveloperManager, am acc.AccountManager, gm global.GlobalMana
 			return handleDeveloperRevokeMsg(ctx, dm, am, gm, msg)
 		case RevokePermissionMsg:
 			return handleRevokePermissionMsg(ctx, dm, am, msg)
+		case UpdateGrantScopesMsg:
+			return handleUpdateGrantScopesMsg(ctx, dm, am, msg)
+		case RevokeAppPermissionMsg:
+			return handleRevokeAppPermissionMsg(ctx, dm, am, msg)
+		case RevokeAllPermissionsMsg:
+			return handleRevokeAllPermissionsMsg(ctx, dm, am, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized developer msg type: %v", reflect.TypeOf(msg).Name())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -116,7 +122,21 @@ func handleGrantPermissionMsg(
 	}
 
 	if err := am.AuthorizePermission(
-		ctx, msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.GrantLevel, types.NewCoinFromInt64(0)); err != nil {
+		ctx, msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.GrantLevel,
+		types.NewCoinFromInt64(0), msg.Scopes); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+func handleUpdateGrantScopesMsg(
+	ctx sdk.Context, dm DeveloperManager, am acc.AccountManager, msg UpdateGrantScopesMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound().Result()
+	}
+
+	if err := am.UpdateGrantScopes(
+		ctx, msg.Username, msg.AuthorizedApp, msg.GrantLevel, msg.Scopes); err != nil {
 		return err.Result()
 	}
 	return sdk.Result{}
@@ -134,6 +154,30 @@ func handleRevokePermissionMsg(
 	return sdk.Result{}
 }
 
+func handleRevokeAppPermissionMsg(
+	ctx sdk.Context, dm DeveloperManager, am acc.AccountManager, msg RevokeAppPermissionMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound().Result()
+	}
+
+	if err := am.RevokeAppPermission(ctx, msg.Username, msg.App); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+func handleRevokeAllPermissionsMsg(
+	ctx sdk.Context, dm DeveloperManager, am acc.AccountManager, msg RevokeAllPermissionsMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound().Result()
+	}
+
+	if err := am.RevokeAllPermissions(ctx, msg.Username); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
 func handlePreAuthorizationMsg(
 	ctx sdk.Context, dm DeveloperManager, am acc.AccountManager, msg PreAuthorizationMsg) sdk.Result {
 	if !dm.DoesDeveloperExist(ctx, msg.AuthorizedApp) {
@@ -148,8 +192,17 @@ func handlePreAuthorizationMsg(
 		return err.Result()
 	}
 
+	if msg.PeriodSec > 0 {
+		if err := am.AuthorizePeriodicPermission(
+			ctx, msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.PeriodSec, amount); err != nil {
+			return err.Result()
+		}
+		return sdk.Result{}
+	}
+
 	if err := am.AuthorizePermission(
-		ctx, msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, types.PreAuthorizationPermission, amount); err != nil {
+		ctx, msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, types.PreAuthorizationPermission,
+		amount, nil); err != nil {
 		return err.Result()
 	}
 	return sdk.Result{}
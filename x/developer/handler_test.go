@@ -227,17 +227,17 @@ func TestGrantPermissionMsg(t *testing.T) {
 	}{
 		{
 			testName:     "normal grant app permission",
-			msg:          NewGrantPermissionMsg("user1", "app", 10000, types.AppPermission),
+			msg:          NewGrantPermissionMsg("user1", "app", 10000, types.AppPermission, nil),
 			expectResult: sdk.Result{},
 		},
 		{
 			testName:     "grant permission to non-exist app",
-			msg:          NewGrantPermissionMsg("user2", "invalidApp", 10000, types.AppPermission),
+			msg:          NewGrantPermissionMsg("user2", "invalidApp", 10000, types.AppPermission, nil),
 			expectResult: ErrDeveloperNotFound().Result(),
 		},
 		{
 			testName:     "grant permission to non-exist user",
-			msg:          NewGrantPermissionMsg("invalid", "app", 10000, types.AppPermission),
+			msg:          NewGrantPermissionMsg("invalid", "app", 10000, types.AppPermission, nil),
 			expectResult: ErrAccountNotFound().Result(),
 		},
 	}
@@ -250,6 +250,63 @@ func TestGrantPermissionMsg(t *testing.T) {
 	}
 }
 
+func TestHandleUpdateGrantScopesMsg(t *testing.T) {
+	ctx, am, dm, gm := setupTest(t, 0)
+	param, err := dm.paramHolder.GetDeveloperParam(ctx)
+	assert.Nil(t, err)
+
+	handler := NewHandler(dm, am, gm)
+	dm.InitGenesis(ctx)
+
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	createTestAccount(ctx, am, "user1", minBalance)
+	createTestAccount(ctx, am, "user2", minBalance)
+	createTestAccount(ctx, am, "app", minBalance)
+	err = dm.RegisterDeveloper(ctx, types.AccountKey("app"), param.DeveloperMinDeposit, "", "", "")
+	assert.Nil(t, err)
+	result := handler(ctx, NewGrantPermissionMsg("user1", "app", 10000, types.AppPermission, []string{"FollowMsg"}))
+	assert.Equal(t, sdk.Result{}, result)
+
+	testCases := []struct {
+		testName     string
+		msg          UpdateGrantScopesMsg
+		expectResult sdk.Result
+		expectScopes []string
+	}{
+		{
+			testName:     "update scopes of granted app",
+			msg:          NewUpdateGrantScopesMsg("user1", "app", types.AppPermission, []string{"FollowMsg", "UpdateProfileMsg"}),
+			expectResult: sdk.Result{},
+			expectScopes: []string{"FollowMsg", "UpdateProfileMsg"},
+		},
+		{
+			testName:     "update scopes of app without permission",
+			msg:          NewUpdateGrantScopesMsg("user2", "app", types.AppPermission, []string{"FollowMsg"}),
+			expectResult: accstore.ErrGrantPubKeyNotFound().Result(),
+		},
+		{
+			testName:     "update scopes of non-exist user",
+			msg:          NewUpdateGrantScopesMsg("invalid", "app", types.AppPermission, []string{"FollowMsg"}),
+			expectResult: ErrAccountNotFound().Result(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.expectResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectResult)
+		}
+		if tc.expectScopes == nil {
+			continue
+		}
+		scopes, err := am.GetGrantScopes(ctx, tc.msg.Username, tc.msg.AuthorizedApp, tc.msg.GrantLevel)
+		assert.Nil(t, err)
+		if !assert.Equal(t, tc.expectScopes, scopes) {
+			t.Errorf("%s: diff scopes, got %v, want %v", tc.testName, scopes, tc.expectScopes)
+		}
+	}
+}
+
 func TestHandlePreAuthorizationMsg(t *testing.T) {
 	ctx, am, dm, gm := setupTest(t, 0)
 	param, err := dm.paramHolder.GetDeveloperParam(ctx)
@@ -285,6 +342,11 @@ func TestHandlePreAuthorizationMsg(t *testing.T) {
 			msg:          NewPreAuthorizationMsg("invalid", "app", 10000, types.LNO("100")),
 			expectResult: ErrAccountNotFound().Result(),
 		},
+		{
+			testName:     "periodic preauthorization msg",
+			msg:          NewPeriodicPreAuthorizationMsg("user2", "app", 10000, types.LNO("10"), 3600),
+			expectResult: sdk.Result{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -293,6 +355,13 @@ func TestHandlePreAuthorizationMsg(t *testing.T) {
 			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectResult)
 		}
 	}
+
+	allowance, err := am.GetPreAuthAllowance(ctx, types.AccountKey("user1"), types.AccountKey("app"))
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(100*types.Decimals), allowance)
+	allowance, err = am.GetPreAuthAllowance(ctx, types.AccountKey("user2"), types.AccountKey("app"))
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(10*types.Decimals), allowance)
 }
 func TestRevokePermissionMsg(t *testing.T) {
 	ctx, am, dm, gm := setupTest(t, 0)
@@ -312,7 +381,7 @@ func TestRevokePermissionMsg(t *testing.T) {
 	err = dm.RegisterDeveloper(ctx, types.AccountKey("app"), param.DeveloperMinDeposit, "", "", "")
 	assert.Nil(t, err)
 	err = am.AuthorizePermission(
-		ctx, types.AccountKey("user1"), types.AccountKey("app"), 1000, types.AppPermission, types.NewCoinFromInt64(0))
+		ctx, types.AccountKey("user1"), types.AccountKey("app"), 1000, types.AppPermission, types.NewCoinFromInt64(0), nil)
 	assert.Nil(t, err)
 
 	testCases := []struct {
@@ -344,3 +413,110 @@ func TestRevokePermissionMsg(t *testing.T) {
 		}
 	}
 }
+
+func TestRevokeAppPermissionMsg(t *testing.T) {
+	ctx, am, dm, gm := setupTest(t, 0)
+	param, err := dm.paramHolder.GetDeveloperParam(ctx)
+	assert.Nil(t, err)
+
+	handler := NewHandler(dm, am, gm)
+	dm.InitGenesis(ctx)
+
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	createTestAccount(ctx, am, "user1", minBalance)
+	createTestAccount(ctx, am, "app", minBalance)
+
+	err = dm.RegisterDeveloper(ctx, types.AccountKey("app"), param.DeveloperMinDeposit, "", "", "")
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(
+		ctx, types.AccountKey("user1"), types.AccountKey("app"), 1000, types.AppPermission, types.NewCoinFromInt64(0), nil)
+	assert.Nil(t, err)
+	err = am.AuthorizePermission(
+		ctx, types.AccountKey("user1"), types.AccountKey("app"), 1000,
+		types.PreAuthorizationPermission, types.NewCoinFromInt64(100), nil)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName     string
+		msg          RevokeAppPermissionMsg
+		expectResult sdk.Result
+	}{
+		{
+			testName:     "revoke app and preauth permission by app name",
+			msg:          NewRevokeAppPermissionMsg("user1", "app"),
+			expectResult: sdk.Result{},
+		},
+		{
+			testName:     "revoke app without permission",
+			msg:          NewRevokeAppPermissionMsg("user1", "app"),
+			expectResult: accstore.ErrGrantPubKeyNotFound().Result(),
+		},
+		{
+			testName:     "invalid revoke user",
+			msg:          NewRevokeAppPermissionMsg("invalid", "app"),
+			expectResult: ErrAccountNotFound().Result(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.expectResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectResult)
+		}
+	}
+	grantedApps, err := am.GetGrantedApps(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(grantedApps))
+}
+
+func TestRevokeAllPermissionsMsg(t *testing.T) {
+	ctx, am, dm, gm := setupTest(t, 0)
+	param, err := dm.paramHolder.GetDeveloperParam(ctx)
+	assert.Nil(t, err)
+
+	handler := NewHandler(dm, am, gm)
+	dm.InitGenesis(ctx)
+
+	minBalance := types.NewCoinFromInt64(1 * types.Decimals)
+	createTestAccount(ctx, am, "user1", minBalance)
+	for _, app := range []string{"app1", "app2"} {
+		createTestAccount(ctx, am, app, minBalance)
+		err = dm.RegisterDeveloper(ctx, types.AccountKey(app), param.DeveloperMinDeposit, "", "", "")
+		assert.Nil(t, err)
+		err = am.AuthorizePermission(
+			ctx, types.AccountKey("user1"), types.AccountKey(app), 1000, types.AppPermission, types.NewCoinFromInt64(0), nil)
+		assert.Nil(t, err)
+	}
+
+	testCases := []struct {
+		testName     string
+		msg          RevokeAllPermissionsMsg
+		expectResult sdk.Result
+	}{
+		{
+			testName:     "revoke all permissions",
+			msg:          NewRevokeAllPermissionsMsg("user1"),
+			expectResult: sdk.Result{},
+		},
+		{
+			testName:     "revoke all permissions again",
+			msg:          NewRevokeAllPermissionsMsg("user1"),
+			expectResult: sdk.Result{},
+		},
+		{
+			testName:     "invalid revoke user",
+			msg:          NewRevokeAllPermissionsMsg("invalid"),
+			expectResult: ErrAccountNotFound().Result(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.expectResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectResult)
+		}
+	}
+	grantedApps, err := am.GetGrantedApps(ctx, types.AccountKey("user1"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(grantedApps))
+}
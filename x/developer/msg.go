@@ -17,6 +17,9 @@ var _ types.Msg = DeveloperRevokeMsg{}
 var _ types.Msg = GrantPermissionMsg{}
 var _ types.Msg = RevokePermissionMsg{}
 var _ types.Msg = PreAuthorizationMsg{}
+var _ types.Msg = UpdateGrantScopesMsg{}
+var _ types.Msg = RevokeAppPermissionMsg{}
+var _ types.Msg = RevokeAllPermissionsMsg{}
 
 // DeveloperRegisterMsg - register developer on blockchain
 type DeveloperRegisterMsg struct {
@@ -40,12 +43,22 @@ type DeveloperRevokeMsg struct {
 	Username types.AccountKey `json:"username"`
 }
 
-// GrantPermissionMsg - user grant permission to app
+// GrantPermissionMsg - user grant permission to app, scopes are msg types
+// such as "CreatePostMsg" the app can sign and empty scopes allow all msgs
 type GrantPermissionMsg struct {
 	Username          types.AccountKey `json:"username"`
 	AuthorizedApp     types.AccountKey `json:"authorized_app"`
 	ValidityPeriodSec int64            `json:"validity_period_second"`
 	GrantLevel        types.Permission `json:"grant_level"`
+	Scopes            []string         `json:"scopes"`
+}
+
+// UpdateGrantScopesMsg - user replace msg types the app can sign with granted permission
+type UpdateGrantScopesMsg struct {
+	Username      types.AccountKey `json:"username"`
+	AuthorizedApp types.AccountKey `json:"authorized_app"`
+	GrantLevel    types.Permission `json:"grant_level"`
+	Scopes        []string         `json:"scopes"`
 }
 
 // RevokePermissionMsg - user revoke permission from app
@@ -54,12 +67,25 @@ type RevokePermissionMsg struct {
 	PubKey   crypto.PubKey    `json:"public_key"`
 }
 
+// RevokeAppPermissionMsg - user revoke all permissions granted to app
+type RevokeAppPermissionMsg struct {
+	Username types.AccountKey `json:"username"`
+	App      types.AccountKey `json:"app"`
+}
+
+// RevokeAllPermissionsMsg - user revoke all permissions granted to any app
+type RevokeAllPermissionsMsg struct {
+	Username types.AccountKey `json:"username"`
+}
+
 // PreAuthorizationMsg - preauth permission to app
 type PreAuthorizationMsg struct {
 	Username          types.AccountKey `json:"username"`
 	AuthorizedApp     types.AccountKey `json:"authorized_app"`
 	ValidityPeriodSec int64            `json:"validity_period_second"`
 	Amount            types.LNO        `json:"amount"`
+	// if PeriodSec is set, Amount is the spending limit of each period
+	PeriodSec int64 `json:"period_second"`
 }
 
 // DeveloperRegisterMsg Msg Implementations
@@ -236,12 +262,13 @@ func (msg DeveloperRevokeMsg) GetConsumeAmount() types.Coin {
 
 // Grant Msg Implementations
 func NewGrantPermissionMsg(
-	user, app string, validityPeriodSec int64, grantLevel types.Permission) GrantPermissionMsg {
+	user, app string, validityPeriodSec int64, grantLevel types.Permission, scopes []string) GrantPermissionMsg {
 	return GrantPermissionMsg{
 		Username:          types.AccountKey(user),
 		AuthorizedApp:     types.AccountKey(app),
 		ValidityPeriodSec: validityPeriodSec,
 		GrantLevel:        grantLevel,
+		Scopes:            scopes,
 	}
 }
 
@@ -270,12 +297,12 @@ func (msg GrantPermissionMsg) ValidateBasic() sdk.Error {
 		return ErrGrantPermissionTooHigh()
 	}
 
-	return nil
+	return validateGrantScopes(msg.Scopes)
 }
 
 func (msg GrantPermissionMsg) String() string {
-	return fmt.Sprintf("GrantPermissionMsg{User:%v, Grant to App:%v, validity period:%v, grant level:%v}",
-		msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.GrantLevel)
+	return fmt.Sprintf("GrantPermissionMsg{User:%v, Grant to App:%v, validity period:%v, grant level:%v, scopes:%v}",
+		msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.GrantLevel, msg.Scopes)
 }
 
 func (msg GrantPermissionMsg) GetPermission() types.Permission {
@@ -349,6 +376,104 @@ func (msg RevokePermissionMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
 
+// RevokeAppPermission Msg Implementations
+func NewRevokeAppPermissionMsg(user, app string) RevokeAppPermissionMsg {
+	return RevokeAppPermissionMsg{
+		Username: types.AccountKey(user),
+		App:      types.AccountKey(app),
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg RevokeAppPermissionMsg) Type() string { return types.DeveloperRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg RevokeAppPermissionMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if len(msg.App) < types.MinimumUsernameLength ||
+		len(msg.App) > types.MaximumUsernameLength {
+		return ErrInvalidAuthorizedApp()
+	}
+	return nil
+}
+
+func (msg RevokeAppPermissionMsg) String() string {
+	return fmt.Sprintf("RevokeAppPermissionMsg{User:%v, App:%v}", msg.Username, msg.App)
+}
+
+func (msg RevokeAppPermissionMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg RevokeAppPermissionMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg RevokeAppPermissionMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg RevokeAppPermissionMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// RevokeAllPermissions Msg Implementations
+func NewRevokeAllPermissionsMsg(user string) RevokeAllPermissionsMsg {
+	return RevokeAllPermissionsMsg{
+		Username: types.AccountKey(user),
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg RevokeAllPermissionsMsg) Type() string { return types.DeveloperRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg RevokeAllPermissionsMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	return nil
+}
+
+func (msg RevokeAllPermissionsMsg) String() string {
+	return fmt.Sprintf("RevokeAllPermissionsMsg{User:%v}", msg.Username)
+}
+
+func (msg RevokeAllPermissionsMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg RevokeAllPermissionsMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg RevokeAllPermissionsMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg RevokeAllPermissionsMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
 // PreAuthorization Msg Implementations
 func NewPreAuthorizationMsg(
 	user string, authorizedApp string, validityPeriodSec int64, amount types.LNO) PreAuthorizationMsg {
@@ -360,6 +485,19 @@ func NewPreAuthorizationMsg(
 	}
 }
 
+// NewPeriodicPreAuthorizationMsg - preauth app to spend up to amount in every period
+func NewPeriodicPreAuthorizationMsg(
+	user string, authorizedApp string, validityPeriodSec int64, amount types.LNO,
+	periodSec int64) PreAuthorizationMsg {
+	return PreAuthorizationMsg{
+		Username:          types.AccountKey(user),
+		AuthorizedApp:     types.AccountKey(authorizedApp),
+		ValidityPeriodSec: validityPeriodSec,
+		Amount:            amount,
+		PeriodSec:         periodSec,
+	}
+}
+
 // Type - implements sdk.Msg
 func (msg PreAuthorizationMsg) Type() string { return types.DeveloperRouterName }
 
@@ -378,6 +516,10 @@ func (msg PreAuthorizationMsg) ValidateBasic() sdk.Error {
 		return ErrInvalidValidityPeriod()
 	}
 
+	if msg.PeriodSec < 0 || msg.PeriodSec > msg.ValidityPeriodSec {
+		return ErrInvalidPreAuthPeriod()
+	}
+
 	_, err := types.LinoToCoin(msg.Amount)
 	if err != nil {
 		return err
@@ -386,8 +528,8 @@ func (msg PreAuthorizationMsg) ValidateBasic() sdk.Error {
 }
 
 func (msg PreAuthorizationMsg) String() string {
-	return fmt.Sprintf("PreAuthorizationMsg{User:%v, Authorized App:%v, Validate Period:%v, Amount:%v}",
-		msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.Amount)
+	return fmt.Sprintf("PreAuthorizationMsg{User:%v, Authorized App:%v, Validate Period:%v, Amount:%v, Period:%v}",
+		msg.Username, msg.AuthorizedApp, msg.ValidityPeriodSec, msg.Amount, msg.PeriodSec)
 }
 
 func (msg PreAuthorizationMsg) GetPermission() types.Permission {
@@ -412,3 +554,88 @@ func (msg PreAuthorizationMsg) GetSigners() []sdk.AccAddress {
 func (msg PreAuthorizationMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
+
+// UpdateGrantScopes Msg Implementations
+func NewUpdateGrantScopesMsg(
+	user, app string, grantLevel types.Permission, scopes []string) UpdateGrantScopesMsg {
+	return UpdateGrantScopesMsg{
+		Username:      types.AccountKey(user),
+		AuthorizedApp: types.AccountKey(app),
+		GrantLevel:    grantLevel,
+		Scopes:        scopes,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg UpdateGrantScopesMsg) Type() string { return types.DeveloperRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg UpdateGrantScopesMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if len(msg.AuthorizedApp) < types.MinimumUsernameLength ||
+		len(msg.AuthorizedApp) > types.MaximumUsernameLength {
+		return ErrInvalidAuthorizedApp()
+	}
+
+	if msg.GrantLevel != types.AppPermission &&
+		msg.GrantLevel != types.PreAuthorizationPermission {
+		return ErrGrantPermissionTooHigh()
+	}
+
+	return validateGrantScopes(msg.Scopes)
+}
+
+func (msg UpdateGrantScopesMsg) String() string {
+	return fmt.Sprintf("UpdateGrantScopesMsg{User:%v, App:%v, grant level:%v, scopes:%v}",
+		msg.Username, msg.AuthorizedApp, msg.GrantLevel, msg.Scopes)
+}
+
+// GetPermission - scopes can be broadened so it requires the same permission as grant
+func (msg UpdateGrantScopesMsg) GetPermission() types.Permission {
+	return types.GrantAppPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg UpdateGrantScopesMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg UpdateGrantScopesMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg UpdateGrantScopesMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// validateGrantScopes - scopes can't be too many, each scope must be a msg type
+// handled by the app and can only appear once
+func validateGrantScopes(scopes []string) sdk.Error {
+	if len(scopes) > types.MaximumNumOfGrantScopes {
+		return ErrInvalidGrantScopes(fmt.Sprintf("at most %v scopes", types.MaximumNumOfGrantScopes))
+	}
+	seen := map[string]bool{}
+	for _, scope := range scopes {
+		if len(scope) == 0 || len(scope) > types.MaximumLengthOfGrantScope {
+			return ErrInvalidGrantScopes(fmt.Sprintf("invalid scope %v", scope))
+		}
+		if !types.IsGrantableMsgType(scope) {
+			return ErrInvalidGrantScopes(fmt.Sprintf("unknown msg type %v", scope))
+		}
+		if seen[scope] {
+			return ErrInvalidGrantScopes(fmt.Sprintf("duplicate scope %v", scope))
+		}
+		seen[scope] = true
+	}
+	return nil
+}
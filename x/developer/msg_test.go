@@ -1,6 +1,7 @@
 package developer
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -215,49 +216,66 @@ func TestGrantPermissionMsgMsg(t *testing.T) {
 	}{
 		{
 			testName:           "app permission",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.AppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.AppPermission, nil),
 			expectError:        nil,
 		},
 		{
 			testName:           "reset permission is too high",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.ResetPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.ResetPermission, nil),
 			expectError:        ErrGrantPermissionTooHigh(),
 		},
 		{
 			testName:           "transaction permission is too high",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.TransactionPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.TransactionPermission, nil),
 			expectError:        ErrGrantPermissionTooHigh(),
 		},
 		{
 			testName:           "grant app permission is too high",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.GrantAppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.GrantAppPermission, nil),
 			expectError:        ErrGrantPermissionTooHigh(),
 		},
 		{
 			testName:           "invalid validity period",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", -1, types.AppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", -1, types.AppPermission, nil),
 			expectError:        ErrInvalidValidityPeriod(),
 		},
 		{
 			testName:           "invalid username",
-			grantPermissionMsg: NewGrantPermissionMsg("us", "app", 1, types.AppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("us", "app", 1, types.AppPermission, nil),
 			expectError:        ErrInvalidUsername(),
 		},
 		{
 			testName:           "invalid authenticate app, app name is too short",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "ap", 1, types.AppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "ap", 1, types.AppPermission, nil),
 			expectError:        ErrInvalidAuthorizedApp(),
 		},
 		{
 			testName:           "invalid username",
-			grantPermissionMsg: NewGrantPermissionMsg("user1user1user1user1user1", "app", 1, types.AppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1user1user1user1user1", "app", 1, types.AppPermission, nil),
 			expectError:        ErrInvalidUsername(),
 		},
 		{
 			testName:           "invalid authenticate app, app name is too long",
-			grantPermissionMsg: NewGrantPermissionMsg("user1", "appappappappappappapp", 1, types.AppPermission),
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "appappappappappappapp", 1, types.AppPermission, nil),
 			expectError:        ErrInvalidAuthorizedApp(),
 		},
+		{
+			testName:           "app permission with scopes",
+			grantPermissionMsg: NewGrantPermissionMsg("user1", "app", 10, types.AppPermission, []string{"FollowMsg"}),
+			expectError:        nil,
+		},
+		{
+			testName: "duplicate scopes",
+			grantPermissionMsg: NewGrantPermissionMsg(
+				"user1", "app", 10, types.AppPermission, []string{"FollowMsg", "FollowMsg"}),
+			expectError: ErrInvalidGrantScopes("duplicate scope FollowMsg"),
+		},
+		{
+			testName: "scope isn't a msg type",
+			grantPermissionMsg: NewGrantPermissionMsg(
+				"user1", "app", 10, types.AppPermission, []string{"FollowMsg", "followmsg"}),
+			expectError: ErrInvalidGrantScopes("unknown msg type followmsg"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -298,6 +316,68 @@ func TestRevokePermissionMsgMsg(t *testing.T) {
 		}
 	}
 }
+func TestRevokeAppPermissionMsgMsg(t *testing.T) {
+	testCases := []struct {
+		testName               string
+		revokeAppPermissionMsg RevokeAppPermissionMsg
+		expectError            sdk.Error
+	}{
+		{
+			testName:               "revoke app permission",
+			revokeAppPermissionMsg: NewRevokeAppPermissionMsg("user1", "app"),
+			expectError:            nil,
+		},
+		{
+			testName:               "username is too short",
+			revokeAppPermissionMsg: NewRevokeAppPermissionMsg("us", "app"),
+			expectError:            ErrInvalidUsername(),
+		},
+		{
+			testName:               "app name is too short",
+			revokeAppPermissionMsg: NewRevokeAppPermissionMsg("user1", "ap"),
+			expectError:            ErrInvalidAuthorizedApp(),
+		},
+		{
+			testName:               "app name is too long",
+			revokeAppPermissionMsg: NewRevokeAppPermissionMsg("user1", "appapppappappappappappappapp"),
+			expectError:            ErrInvalidAuthorizedApp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.revokeAppPermissionMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectError)
+		}
+	}
+}
+
+func TestRevokeAllPermissionsMsgMsg(t *testing.T) {
+	testCases := []struct {
+		testName                string
+		revokeAllPermissionsMsg RevokeAllPermissionsMsg
+		expectError             sdk.Error
+	}{
+		{
+			testName:                "revoke all permissions",
+			revokeAllPermissionsMsg: NewRevokeAllPermissionsMsg("user1"),
+			expectError:             nil,
+		},
+		{
+			testName:                "username is too long",
+			revokeAllPermissionsMsg: NewRevokeAllPermissionsMsg("user1user1user1user1user1"),
+			expectError:             ErrInvalidUsername(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.revokeAllPermissionsMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectError)
+		}
+	}
+}
+
 func TestPreAuthorizationMsgMsg(t *testing.T) {
 	testCases := []struct {
 		testName            string
@@ -339,6 +419,21 @@ func TestPreAuthorizationMsgMsg(t *testing.T) {
 			preAuthorizationMsg: NewPreAuthorizationMsg("user1", "appappappappappappappapp", 1000, "1"),
 			expectError:         ErrInvalidAuthorizedApp(),
 		},
+		{
+			testName:            "periodic preauthorization",
+			preAuthorizationMsg: NewPeriodicPreAuthorizationMsg("user1", "app", 1000, "1", 100),
+			expectError:         nil,
+		},
+		{
+			testName:            "negative period",
+			preAuthorizationMsg: NewPeriodicPreAuthorizationMsg("user1", "app", 1000, "1", -1),
+			expectError:         ErrInvalidPreAuthPeriod(),
+		},
+		{
+			testName:            "period longer than validity period",
+			preAuthorizationMsg: NewPeriodicPreAuthorizationMsg("user1", "app", 1000, "1", 1001),
+			expectError:         ErrInvalidPreAuthPeriod(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -372,7 +467,7 @@ func TestMsgPermission(t *testing.T) {
 		},
 		{
 			testName:         "grant developer app permission msg",
-			msg:              NewGrantPermissionMsg("test", "app", 24*3600, types.AppPermission),
+			msg:              NewGrantPermissionMsg("test", "app", 24*3600, types.AppPermission, nil),
 			expectPermission: types.GrantAppPermission,
 		},
 		{
@@ -385,6 +480,21 @@ func TestMsgPermission(t *testing.T) {
 			msg:              NewPreAuthorizationMsg("test", "app", 1000, "1"),
 			expectPermission: types.TransactionPermission,
 		},
+		{
+			testName:         "update grant scopes msg",
+			msg:              NewUpdateGrantScopesMsg("test", "app", types.AppPermission, []string{"FollowMsg"}),
+			expectPermission: types.GrantAppPermission,
+		},
+		{
+			testName:         "revoke app permission msg",
+			msg:              NewRevokeAppPermissionMsg("test", "app"),
+			expectPermission: types.TransactionPermission,
+		},
+		{
+			testName:         "revoke all permissions msg",
+			msg:              NewRevokeAllPermissionsMsg("test"),
+			expectPermission: types.TransactionPermission,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -397,6 +507,66 @@ func TestMsgPermission(t *testing.T) {
 	}
 }
 
+func TestUpdateGrantScopesMsg(t *testing.T) {
+	tooManyScopes := []string{}
+	for i := 0; i <= types.MaximumNumOfGrantScopes; i++ {
+		tooManyScopes = append(tooManyScopes, fmt.Sprintf("Msg%d", i))
+	}
+	testCases := []struct {
+		testName    string
+		msg         UpdateGrantScopesMsg
+		expectError sdk.Error
+	}{
+		{
+			testName:    "normal case",
+			msg:         NewUpdateGrantScopesMsg("user1", "app", types.AppPermission, []string{"FollowMsg", "UpdateProfileMsg"}),
+			expectError: nil,
+		},
+		{
+			testName:    "empty scopes allow all msgs",
+			msg:         NewUpdateGrantScopesMsg("user1", "app", types.PreAuthorizationPermission, nil),
+			expectError: nil,
+		},
+		{
+			testName:    "invalid username",
+			msg:         NewUpdateGrantScopesMsg("us", "app", types.AppPermission, nil),
+			expectError: ErrInvalidUsername(),
+		},
+		{
+			testName:    "invalid authorized app",
+			msg:         NewUpdateGrantScopesMsg("user1", "ap", types.AppPermission, nil),
+			expectError: ErrInvalidAuthorizedApp(),
+		},
+		{
+			testName:    "transaction permission can't be granted",
+			msg:         NewUpdateGrantScopesMsg("user1", "app", types.TransactionPermission, nil),
+			expectError: ErrGrantPermissionTooHigh(),
+		},
+		{
+			testName:    "empty scope",
+			msg:         NewUpdateGrantScopesMsg("user1", "app", types.AppPermission, []string{""}),
+			expectError: ErrInvalidGrantScopes("invalid scope "),
+		},
+		{
+			testName:    "unknown msg type",
+			msg:         NewUpdateGrantScopesMsg("user1", "app", types.AppPermission, []string{"RewardEvent"}),
+			expectError: ErrInvalidGrantScopes("unknown msg type RewardEvent"),
+		},
+		{
+			testName:    "too many scopes",
+			msg:         NewUpdateGrantScopesMsg("user1", "app", types.AppPermission, tooManyScopes),
+			expectError: ErrInvalidGrantScopes(fmt.Sprintf("at most %v scopes", types.MaximumNumOfGrantScopes)),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.msg.ValidateBasic()
+		if !assert.Equal(t, tc.expectError, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectError)
+		}
+	}
+}
+
 func TestGetSigners(t *testing.T) {
 	testCases := []struct {
 		testName      string
@@ -420,7 +590,7 @@ func TestGetSigners(t *testing.T) {
 		},
 		{
 			testName:      "grant developer app permission msg",
-			msg:           NewGrantPermissionMsg("test", "app", 24*3600, types.AppPermission),
+			msg:           NewGrantPermissionMsg("test", "app", 24*3600, types.AppPermission, nil),
 			expectSigners: []types.AccountKey{"test"},
 		},
 		{
@@ -433,6 +603,21 @@ func TestGetSigners(t *testing.T) {
 			msg:           NewPreAuthorizationMsg("test", "app", 1000, "1"),
 			expectSigners: []types.AccountKey{"test"},
 		},
+		{
+			testName:      "update grant scopes msg",
+			msg:           NewUpdateGrantScopesMsg("test", "app", types.AppPermission, []string{"FollowMsg"}),
+			expectSigners: []types.AccountKey{"test"},
+		},
+		{
+			testName:      "revoke app permission msg",
+			msg:           NewRevokeAppPermissionMsg("test", "app"),
+			expectSigners: []types.AccountKey{"test"},
+		},
+		{
+			testName:      "revoke all permissions msg",
+			msg:           NewRevokeAllPermissionsMsg("test"),
+			expectSigners: []types.AccountKey{"test"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -468,7 +653,7 @@ func TestGetSignBytes(t *testing.T) {
 		},
 		{
 			testName: "grant developer app permission msg",
-			msg:      NewGrantPermissionMsg("test", "app", 24*3600, types.AppPermission),
+			msg:      NewGrantPermissionMsg("test", "app", 24*3600, types.AppPermission, nil),
 		},
 		{
 			testName: "revoke developer post permission msg",
@@ -478,6 +663,18 @@ func TestGetSignBytes(t *testing.T) {
 			testName: "preauth msg",
 			msg:      NewPreAuthorizationMsg("test", "app", 1000, "1"),
 		},
+		{
+			testName: "update grant scopes msg",
+			msg:      NewUpdateGrantScopesMsg("test", "app", types.AppPermission, []string{"FollowMsg"}),
+		},
+		{
+			testName: "revoke app permission msg",
+			msg:      NewRevokeAppPermissionMsg("test", "app"),
+		},
+		{
+			testName: "revoke all permissions msg",
+			msg:      NewRevokeAllPermissionsMsg("test"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2,16 +2,20 @@ package developer
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(DeveloperRegisterMsg{}, "lino/devRegister", nil)
-	cdc.RegisterConcrete(DeveloperUpdateMsg{}, "lino/devUpdate", nil)
-	cdc.RegisterConcrete(DeveloperRevokeMsg{}, "lino/devRevoke", nil)
-	cdc.RegisterConcrete(GrantPermissionMsg{}, "lino/grantPermission", nil)
-	cdc.RegisterConcrete(RevokePermissionMsg{}, "lino/revokePermission", nil)
-	cdc.RegisterConcrete(PreAuthorizationMsg{}, "lino/preAuthorizationPermission", nil)
+	types.RegisterMsg(cdc, DeveloperRegisterMsg{}, "lino/devRegister")
+	types.RegisterMsg(cdc, DeveloperUpdateMsg{}, "lino/devUpdate")
+	types.RegisterMsg(cdc, DeveloperRevokeMsg{}, "lino/devRevoke")
+	types.RegisterMsg(cdc, GrantPermissionMsg{}, "lino/grantPermission")
+	types.RegisterMsg(cdc, RevokePermissionMsg{}, "lino/revokePermission")
+	types.RegisterMsg(cdc, PreAuthorizationMsg{}, "lino/preAuthorizationPermission")
+	types.RegisterMsg(cdc, UpdateGrantScopesMsg{}, "lino/updateGrantScopes")
+	types.RegisterMsg(cdc, RevokeAppPermissionMsg{}, "lino/revokeAppPermission")
+	types.RegisterMsg(cdc, RevokeAllPermissionsMsg{}, "lino/revokeAllPermissions")
 }
 
 var msgCdc = wire.NewCodec()
@@ -140,6 +140,36 @@ func (gm GlobalManager) RemoveTimeEventList(ctx sdk.Context, unixTime int64) sdk
 	return gm.storage.RemoveTimeEventList(ctx, unixTime)
 }
 
+// RemoveTimeEvents - remove events match the filter from time event list at given time,
+// return removed events
+func (gm GlobalManager) RemoveTimeEvents(
+	ctx sdk.Context, unixTime int64, match func(types.Event) bool) ([]types.Event, sdk.Error) {
+	eventList, err := gm.storage.GetTimeEventList(ctx, unixTime)
+	if err != nil {
+		return nil, err
+	}
+	removed := []types.Event{}
+	if eventList == nil {
+		return removed, nil
+	}
+	remain := []types.Event{}
+	for _, event := range eventList.Events {
+		if match(event) {
+			removed = append(removed, event)
+		} else {
+			remain = append(remain, event)
+		}
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+	if len(remain) == 0 {
+		return removed, gm.storage.RemoveTimeEventList(ctx, unixTime)
+	}
+	eventList.Events = remain
+	return removed, gm.storage.SetTimeEventList(ctx, unixTime, eventList)
+}
+
 // GetConsumptionFrictionRate - get consumption friction rate
 func (gm GlobalManager) GetConsumptionFrictionRate(ctx sdk.Context) (sdk.Rat, sdk.Error) {
 	consumptionMeta, err := gm.storage.GetConsumptionMeta(ctx)
@@ -158,36 +188,84 @@ func (gm GlobalManager) GetConsumption(ctx sdk.Context) (types.Coin, sdk.Error)
 	return globalMeta.CumulativeConsumption, nil
 }
 
-// AddFrictionAndRegisterContentRewardEvent - register reward calculation event at 7 days later
+// AddFrictionAndRegisterContentRewardEvent - register reward calculation event at 7 days later,
+// return the time reward event is registered at
 func (gm GlobalManager) AddFrictionAndRegisterContentRewardEvent(
-	ctx sdk.Context, event types.Event, friction types.Coin, evaluate types.Coin) sdk.Error {
+	ctx sdk.Context, event types.Event, friction types.Coin, evaluate types.Coin) (int64, sdk.Error) {
 	consumptionMeta, err := gm.storage.GetConsumptionMeta(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	pastDay, err := gm.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
 	if err != nil {
-		return err
+		return 0, err
 	}
 	linoStakeStat, err := gm.storage.GetLinoStakeStat(ctx, pastDay)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	// consumptionMeta.ConsumptionRewardPool = consumptionMeta.ConsumptionRewardPool.Plus(friction)
 	consumptionMeta.ConsumptionWindow = consumptionMeta.ConsumptionWindow.Plus(evaluate)
 	linoStakeStat.TotalConsumptionFriction = linoStakeStat.TotalConsumptionFriction.Plus(friction)
 	linoStakeStat.UnclaimedFriction = linoStakeStat.UnclaimedFriction.Plus(friction)
-	if err := gm.registerEventAtTime(
-		ctx, ctx.BlockHeader().Time.Unix()+consumptionMeta.ConsumptionFreezingPeriodSec, event); err != nil {
-		return err
+	eventTime := ctx.BlockHeader().Time.Unix() + consumptionMeta.ConsumptionFreezingPeriodSec
+	if err := gm.registerEventAtTime(ctx, eventTime, event); err != nil {
+		return 0, err
 	}
 	if err := gm.storage.SetConsumptionMeta(ctx, consumptionMeta); err != nil {
-		return err
+		return 0, err
 	}
 	if err := gm.storage.SetLinoStakeStat(ctx, pastDay, linoStakeStat); err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return eventTime, nil
+}
+
+// CancelFrictionAndContentReward - revert friction and evaluate added by a cancelled content
+// reward event. Friction is taken back from unclaimed friction of the day it was added in
+// proportion to lino stake hasn't claimed that day, return the friction can be refunded since
+// friction claimed by stake holders can't be taken back
+func (gm GlobalManager) CancelFrictionAndContentReward(
+	ctx sdk.Context, addedAt int64, friction types.Coin, evaluate types.Coin) (types.Coin, sdk.Error) {
+	consumptionMeta, err := gm.storage.GetConsumptionMeta(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	pastDay, err := gm.GetPastDay(ctx, addedAt)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	linoStakeStat, err := gm.storage.GetLinoStakeStat(ctx, pastDay)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	if consumptionMeta.ConsumptionWindow.IsGTE(evaluate) {
+		consumptionMeta.ConsumptionWindow = consumptionMeta.ConsumptionWindow.Minus(evaluate)
+	} else {
+		consumptionMeta.ConsumptionWindow = types.NewCoinFromInt64(0)
+	}
+	// stake holders claim friction of the day in proportion to their stake,
+	// take back the same share of this friction from the stake hasn't claimed
+	refund := friction
+	if !linoStakeStat.TotalLinoStake.IsZero() {
+		refund = types.RatToCoin(friction.ToRat().Mul(
+			linoStakeStat.UnclaimedLinoStake.ToRat().Quo(linoStakeStat.TotalLinoStake.ToRat())))
+	}
+	if !linoStakeStat.UnclaimedFriction.IsGTE(refund) {
+		refund = linoStakeStat.UnclaimedFriction
+	}
+	if !linoStakeStat.TotalConsumptionFriction.IsGTE(refund) {
+		refund = linoStakeStat.TotalConsumptionFriction
+	}
+	linoStakeStat.TotalConsumptionFriction = linoStakeStat.TotalConsumptionFriction.Minus(refund)
+	linoStakeStat.UnclaimedFriction = linoStakeStat.UnclaimedFriction.Minus(refund)
+	if err := gm.storage.SetConsumptionMeta(ctx, consumptionMeta); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	if err := gm.storage.SetLinoStakeStat(ctx, pastDay, linoStakeStat); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return refund, nil
 }
 
 // AddLinoStakeToStat - add lino power to total lino power at current day
@@ -301,6 +379,12 @@ func (gm GlobalManager) RegisterProposalDecideEvent(
 	return nil
 }
 
+// RegisterRecoveryEvent - register social recovery event at the time recovery takes effect
+func (gm GlobalManager) RegisterRecoveryEvent(
+	ctx sdk.Context, executeAt int64, event types.Event) sdk.Error {
+	return gm.registerEventAtTime(ctx, executeAt, event)
+}
+
 // RegisterParamChangeEvent - register parameter change event
 func (gm GlobalManager) RegisterParamChangeEvent(ctx sdk.Context, event types.Event) sdk.Error {
 	// param will be changed in one day
@@ -457,6 +541,28 @@ func (gm GlobalManager) AddToDeveloperInflationPool(ctx sdk.Context, coin types.
 	return nil
 }
 
+// WithdrawFromDeveloperInflationPool - withdraw coin from developer inflation pool,
+// returns the actual amount withdrawn which is capped by the pool
+func (gm GlobalManager) WithdrawFromDeveloperInflationPool(
+	ctx sdk.Context, coin types.Coin) (types.Coin, sdk.Error) {
+	inflationPool, err := gm.storage.GetInflationPool(ctx)
+	if err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	resCoin := coin
+	if resCoin.IsGT(inflationPool.DeveloperInflationPool) {
+		resCoin = inflationPool.DeveloperInflationPool
+	}
+	inflationPool.DeveloperInflationPool = inflationPool.DeveloperInflationPool.Minus(resCoin)
+	if err := gm.addTotalLinoCoin(ctx, resCoin); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	if err := gm.storage.SetInflationPool(ctx, inflationPool); err != nil {
+		return types.NewCoinFromInt64(0), err
+	}
+	return resCoin, nil
+}
+
 // AddToValidatorInflationPool - add validator inflation to pool
 func (gm GlobalManager) AddToValidatorInflationPool(ctx sdk.Context, coin types.Coin) sdk.Error {
 	pool, err := gm.storage.GetInflationPool(ctx)
@@ -22,6 +22,10 @@ const (
 
 type testEvent struct{}
 
+type testIDEvent struct {
+	ID int64 `json:"id"`
+}
+
 // Construct some global addrs and txs for tests.
 var (
 	TestGlobalKVStoreKey = sdk.NewKVStoreKey("global")
@@ -51,6 +55,7 @@ func setupTest(t *testing.T) (sdk.Context, GlobalManager) {
 	cdc := globalManager.WireCodec()
 	cdc.RegisterInterface((*types.Event)(nil), nil)
 	cdc.RegisterConcrete(testEvent{}, "test", nil)
+	cdc.RegisterConcrete(testIDEvent{}, "testID", nil)
 	err := InitGlobalManager(ctx, globalManager)
 	assert.Nil(t, err)
 	return ctx, globalManager
@@ -339,11 +344,14 @@ func TestAddFrictionAndRegisterContentRewardEvent(t *testing.T) {
 
 	for _, tc := range testCases {
 		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(tc.registerBaseTime, 0)})
-		err := gm.AddFrictionAndRegisterContentRewardEvent(
+		eventTime, err := gm.AddFrictionAndRegisterContentRewardEvent(
 			ctx, testEvent{}, tc.frictionCoin, tc.evaluateCoin)
 		if err != nil {
 			t.Errorf("%s: failed to add friction and register event, got err %v", tc.testName, err)
 		}
+		if eventTime != tc.registerBaseTime+24*7*3600 {
+			t.Errorf("%s: diff event time, got %v, want %v", tc.testName, eventTime, tc.registerBaseTime+24*7*3600)
+		}
 
 		consumptionMeta, err := gm.storage.GetConsumptionMeta(ctx)
 		if err != nil {
@@ -378,6 +386,127 @@ func TestAddFrictionAndRegisterContentRewardEvent(t *testing.T) {
 	}
 }
 
+func TestCancelFrictionAndContentReward(t *testing.T) {
+	ctx, gm := setupTest(t)
+	baseTime := ctx.BlockHeader().Time.Unix()
+	_, err := gm.AddFrictionAndRegisterContentRewardEvent(
+		ctx, testEvent{}, types.NewCoinFromInt64(100), types.NewCoinFromInt64(10))
+	assert.Nil(t, err)
+	assert.Nil(t, gm.AddLinoStakeToStat(ctx, types.NewCoinFromInt64(100)))
+
+	testCases := []struct {
+		testName              string
+		friction              types.Coin
+		evaluate              types.Coin
+		claimedLinoStake      types.Coin
+		claimedFriction       types.Coin
+		wantRefund            types.Coin
+		wantUnclaimedFriction types.Coin
+		wantConsumptionWindow types.Coin
+	}{
+		{
+			testName:              "cancel part of friction",
+			friction:              types.NewCoinFromInt64(30),
+			evaluate:              types.NewCoinFromInt64(3),
+			claimedLinoStake:      types.NewCoinFromInt64(0),
+			claimedFriction:       types.NewCoinFromInt64(0),
+			wantRefund:            types.NewCoinFromInt64(30),
+			wantUnclaimedFriction: types.NewCoinFromInt64(70),
+			wantConsumptionWindow: types.NewCoinFromInt64(7),
+		},
+		{
+			testName:              "friction claimed by stake holders can't be refunded",
+			friction:              types.NewCoinFromInt64(70),
+			evaluate:              types.NewCoinFromInt64(7),
+			claimedLinoStake:      types.NewCoinFromInt64(40),
+			claimedFriction:       types.NewCoinFromInt64(28),
+			wantRefund:            types.NewCoinFromInt64(42),
+			wantUnclaimedFriction: types.NewCoinFromInt64(0),
+			wantConsumptionWindow: types.NewCoinFromInt64(0),
+		},
+	}
+	for _, tc := range testCases {
+		linoStakeStat, err := gm.storage.GetLinoStakeStat(ctx, 0)
+		assert.Nil(t, err)
+		linoStakeStat.UnclaimedLinoStake = linoStakeStat.UnclaimedLinoStake.Minus(tc.claimedLinoStake)
+		linoStakeStat.UnclaimedFriction = linoStakeStat.UnclaimedFriction.Minus(tc.claimedFriction)
+		assert.Nil(t, gm.storage.SetLinoStakeStat(ctx, 0, linoStakeStat))
+
+		refund, err := gm.CancelFrictionAndContentReward(ctx, baseTime, tc.friction, tc.evaluate)
+		if err != nil {
+			t.Errorf("%s: failed to cancel friction, got err %v", tc.testName, err)
+		}
+		if !refund.IsEqual(tc.wantRefund) {
+			t.Errorf("%s: diff refund, got %v, want %v", tc.testName, refund, tc.wantRefund)
+		}
+		linoStakeStat, err = gm.storage.GetLinoStakeStat(ctx, 0)
+		assert.Nil(t, err)
+		if !linoStakeStat.UnclaimedFriction.IsEqual(tc.wantUnclaimedFriction) {
+			t.Errorf("%s: diff unclaimed friction, got %v, want %v",
+				tc.testName, linoStakeStat.UnclaimedFriction, tc.wantUnclaimedFriction)
+		}
+		consumptionMeta, err := gm.storage.GetConsumptionMeta(ctx)
+		assert.Nil(t, err)
+		if !consumptionMeta.ConsumptionWindow.IsEqual(tc.wantConsumptionWindow) {
+			t.Errorf("%s: diff consumption window, got %v, want %v",
+				tc.testName, consumptionMeta.ConsumptionWindow, tc.wantConsumptionWindow)
+		}
+	}
+}
+
+func TestRemoveTimeEvents(t *testing.T) {
+	ctx, gm := setupTest(t)
+	eventTime := ctx.BlockHeader().Time.Unix() + 100
+	for i := int64(0); i < 3; i++ {
+		assert.Nil(t, gm.registerEventAtTime(ctx, eventTime, testIDEvent{ID: i}))
+	}
+	matchID := func(id int64) func(types.Event) bool {
+		return func(event types.Event) bool {
+			e, ok := event.(testIDEvent)
+			return ok && e.ID == id
+		}
+	}
+
+	testCases := []struct {
+		testName      string
+		match         func(types.Event) bool
+		wantRemoved   []types.Event
+		wantEventList *types.TimeEventList
+	}{
+		{
+			testName:      "no event matches",
+			match:         matchID(3),
+			wantRemoved:   []types.Event{},
+			wantEventList: &types.TimeEventList{Events: []types.Event{testIDEvent{0}, testIDEvent{1}, testIDEvent{2}}},
+		},
+		{
+			testName:      "remove one event",
+			match:         matchID(1),
+			wantRemoved:   []types.Event{testIDEvent{1}},
+			wantEventList: &types.TimeEventList{Events: []types.Event{testIDEvent{0}, testIDEvent{2}}},
+		},
+		{
+			testName:      "remove all events",
+			match:         func(types.Event) bool { return true },
+			wantRemoved:   []types.Event{testIDEvent{0}, testIDEvent{2}},
+			wantEventList: nil,
+		},
+	}
+	for _, tc := range testCases {
+		removed, err := gm.RemoveTimeEvents(ctx, eventTime, tc.match)
+		if err != nil {
+			t.Errorf("%s: failed to remove events, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantRemoved, removed) {
+			t.Errorf("%s: diff removed events, got %v, want %v", tc.testName, removed, tc.wantRemoved)
+		}
+		eventList := gm.GetTimeEventListAtTime(ctx, eventTime)
+		if !assert.Equal(t, tc.wantEventList, eventList) {
+			t.Errorf("%s: diff event list, got %v, want %v", tc.testName, eventList, tc.wantEventList)
+		}
+	}
+}
+
 func TestGetRewardAndPopFromWindow(t *testing.T) {
 	ctx, gm := setupTest(t)
 	testCases := []struct {
@@ -1467,6 +1596,69 @@ func TestAddToDeveloperInflationPool(t *testing.T) {
 	}
 }
 
+func TestWithdrawFromDeveloperInflationPool(t *testing.T) {
+	ctx, gm := setupTest(t)
+
+	testCases := []struct {
+		testName       string
+		inflationPool  model.InflationPool
+		withdrawCoin   types.Coin
+		expectWithdraw types.Coin
+	}{
+		{
+			testName: "withdraw 10 LNO from a pool with 10000 LNO",
+			inflationPool: model.InflationPool{
+				DeveloperInflationPool: types.NewCoinFromInt64(10000 * types.Decimals),
+			},
+			withdrawCoin:   types.NewCoinFromInt64(10 * types.Decimals),
+			expectWithdraw: types.NewCoinFromInt64(10 * types.Decimals),
+		},
+		{
+			testName: "withdraw is capped by the pool",
+			inflationPool: model.InflationPool{
+				DeveloperInflationPool: types.NewCoinFromInt64(1 * types.Decimals),
+			},
+			withdrawCoin:   types.NewCoinFromInt64(10 * types.Decimals),
+			expectWithdraw: types.NewCoinFromInt64(1 * types.Decimals),
+		},
+		{
+			testName: "withdraw from empty pool",
+			inflationPool: model.InflationPool{
+				DeveloperInflationPool: types.NewCoinFromInt64(0),
+			},
+			withdrawCoin:   types.NewCoinFromInt64(10 * types.Decimals),
+			expectWithdraw: types.NewCoinFromInt64(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		err := gm.storage.SetInflationPool(ctx, &tc.inflationPool)
+		assert.Nil(t, err)
+		globalMeta, err := gm.storage.GetGlobalMeta(ctx)
+		assert.Nil(t, err)
+		withdraw, err := gm.WithdrawFromDeveloperInflationPool(ctx, tc.withdrawCoin)
+		assert.Nil(t, err)
+		if !withdraw.IsEqual(tc.expectWithdraw) {
+			t.Errorf("%s: diff withdraw, got %v, want %v", tc.testName, withdraw, tc.expectWithdraw)
+		}
+
+		inflationPool, err := gm.storage.GetInflationPool(ctx)
+		assert.Nil(t, err)
+		if !inflationPool.DeveloperInflationPool.IsEqual(
+			tc.inflationPool.DeveloperInflationPool.Minus(tc.expectWithdraw)) {
+			t.Errorf("%s: diff developer inflation pool, got %v, want %v",
+				tc.testName, inflationPool.DeveloperInflationPool,
+				tc.inflationPool.DeveloperInflationPool.Minus(tc.expectWithdraw))
+		}
+		newGlobalMeta, err := gm.storage.GetGlobalMeta(ctx)
+		assert.Nil(t, err)
+		if !newGlobalMeta.TotalLinoCoin.IsEqual(globalMeta.TotalLinoCoin.Plus(tc.expectWithdraw)) {
+			t.Errorf("%s: diff total lino, got %v, want %v",
+				tc.testName, newGlobalMeta.TotalLinoCoin, globalMeta.TotalLinoCoin.Plus(tc.expectWithdraw))
+		}
+	}
+}
+
 func TestGetInterestSince(t *testing.T) {
 	ctx, gm := setupTest(t)
 
@@ -42,6 +42,8 @@ func NewGlobalStorage(key sdk.StoreKey) GlobalStorage {
 	cdc.RegisterConcrete(param.BandwidthParam{}, "param/bandwidth", nil)
 	cdc.RegisterConcrete(param.AccountParam{}, "param/account", nil)
 	cdc.RegisterConcrete(param.PostParam{}, "param/post", nil)
+	cdc.RegisterConcrete(param.ReputationParam{}, "param/reputation", nil)
+	cdc.RegisterConcrete(param.ParamFieldsPatch{}, "param/fieldsPatch", nil)
 
 	wire.RegisterCrypto(cdc)
 	return GlobalStorage{
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lino-network/lino/client"
+	infra "github.com/lino-network/lino/x/infra"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// ContentAttestationTxCmd - attest availability of off-chain content referenced by a post
+func ContentAttestationTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attest-content",
+		Short: "provider attests availability of post content",
+		RunE:  sendContentAttestationTx(cdc),
+	}
+	cmd.Flags().String(client.FlagProvider, "", "attester of this transaction")
+	cmd.Flags().String(client.FlagAuthor, "", "author of the post")
+	cmd.Flags().String(client.FlagPostID, "", "post id of the post")
+	cmd.Flags().String(client.FlagContentRef, "", "IPFS CID or sha256 referenced by the post")
+	cmd.Flags().Bool(client.FlagAvailable, true, "availability of the content")
+	return cmd
+}
+
+// send content attestation transaction to the blockchain
+func sendContentAttestationTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := infra.NewContentAttestationMsg(
+			viper.GetString(client.FlagProvider), viper.GetString(client.FlagAuthor),
+			viper.GetString(client.FlagPostID), viper.GetString(client.FlagContentRef),
+			viper.GetBool(client.FlagAvailable))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
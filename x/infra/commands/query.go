@@ -40,6 +40,19 @@ func GetInfraProvidersCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 }
 
+// GetContentAttestationsCmd returns content attestations of a post
+func GetContentAttestationsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "content-attestations <author> <postID>",
+		Short: "Query content attestations of a post",
+		RunE:  cmdr.getContentAttestationsCmd,
+	}
+}
+
 type commander struct {
 	storeName string
 	cdc       *wire.Codec
@@ -92,3 +105,31 @@ func (c commander) getInfraProvidersCmd(cmd *cobra.Command, args []string) error
 
 	return nil
 }
+
+func (c commander) getContentAttestationsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide an valid author and post id")
+	}
+
+	permlink := types.GetPermlink(types.AccountKey(args[0]), args[1])
+	resKVs, err := ctx.QuerySubspace(c.cdc, model.GetContentAttestationPrefix(permlink), c.storeName)
+	if err != nil {
+		return err
+	}
+	attestations := []model.ContentAttestation{}
+	for _, KV := range resKVs {
+		var attestation model.ContentAttestation
+		if err := c.cdc.UnmarshalJSON(KV.Value, &attestation); err != nil {
+			return err
+		}
+		attestations = append(attestations, attestation)
+	}
+
+	output, err := json.MarshalIndent(attestations, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
@@ -21,3 +21,13 @@ func ErrInvalidUsername() sdk.Error {
 func ErrInvalidUsage() sdk.Error {
 	return types.NewError(types.CodeInvalidUsage, fmt.Sprintf("invalid Usage"))
 }
+
+// ErrInvalidContentAttestation - error if content attestation target is invalid
+func ErrInvalidContentAttestation() sdk.Error {
+	return types.NewError(types.CodeInvalidContentAttestation, fmt.Sprintf("invalid content attestation"))
+}
+
+// ErrContentRefNotFound - error if post doesn't reference the attested content
+func ErrContentRefNotFound(permlink types.Permlink, ref string) sdk.Error {
+	return types.NewError(types.CodeContentRefNotFound, fmt.Sprintf("post %v doesn't reference %v", permlink, ref))
+}
@@ -5,14 +5,18 @@ import (
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/post"
 )
 
 // NewHandler - Handle all "infra" type messages.
-func NewHandler(im InfraManager) sdk.Handler {
+func NewHandler(im InfraManager, pm post.PostManager) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case ProviderReportMsg:
 			return handleProviderReportMsg(ctx, im, msg)
+		case ContentAttestationMsg:
+			return handleContentAttestationMsg(ctx, im, pm, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized infra msg type: %v", reflect.TypeOf(msg).Name())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -30,3 +34,20 @@ func handleProviderReportMsg(ctx sdk.Context, im InfraManager, msg ProviderRepor
 	}
 	return sdk.Result{}
 }
+
+func handleContentAttestationMsg(
+	ctx sdk.Context, im InfraManager, pm post.PostManager, msg ContentAttestationMsg) sdk.Result {
+	if !im.DoesInfraProviderExist(ctx, msg.Username) {
+		return ErrProviderNotFound().Result()
+	}
+
+	permlink := types.GetPermlink(msg.Author, msg.PostID)
+	if !pm.HasContentRef(ctx, permlink, msg.Ref) {
+		return ErrContentRefNotFound(permlink, msg.Ref).Result()
+	}
+
+	if err := im.AttestContent(ctx, msg.Username, permlink, msg.Ref, msg.Available); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
@@ -5,12 +5,13 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/infra/model"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestReportBasic(t *testing.T) {
-	ctx, im := setupTest(t, 0)
-	handler := NewHandler(im)
+	ctx, im, pm := setupTest(t, 0)
+	handler := NewHandler(im, pm)
 	im.InitGenesis(ctx)
 
 	user1 := types.AccountKey("user1")
@@ -30,3 +31,77 @@ func TestReportBasic(t *testing.T) {
 	assert.Equal(t, usage, provider.Usage)
 
 }
+
+func TestContentAttestation(t *testing.T) {
+	ctx, im, pm := setupTest(t, 0)
+	handler := NewHandler(im, pm)
+	im.InitGenesis(ctx)
+
+	provider := types.AccountKey("provider")
+	author := types.AccountKey("author")
+	postID := "postID"
+	permlink := types.GetPermlink(author, postID)
+	ref := "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+	im.RegisterInfraProvider(ctx, provider)
+	err := pm.CreatePost(ctx, author, postID, "", "", "", "", "content", "title", sdk.ZeroRat(), nil)
+	assert.Nil(t, err)
+	err = pm.SetPostContentRefs(ctx, permlink, []types.ContentReference{
+		{RefType: types.IPFSContentRef, Ref: ref, Size: 100, MimeType: "image/png"},
+	})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName         string
+		msg              ContentAttestationMsg
+		wantResult       sdk.Result
+		wantAttestations []model.ContentAttestation
+	}{
+		{
+			testName:         "provider doesn't exist",
+			msg:              NewContentAttestationMsg("user1", string(author), postID, ref, true),
+			wantResult:       ErrProviderNotFound().Result(),
+			wantAttestations: []model.ContentAttestation{},
+		},
+		{
+			testName:         "post doesn't reference the content",
+			msg:              NewContentAttestationMsg(string(provider), string(author), postID, "invalid", true),
+			wantResult:       ErrContentRefNotFound(permlink, "invalid").Result(),
+			wantAttestations: []model.ContentAttestation{},
+		},
+		{
+			testName:         "post doesn't exist",
+			msg:              NewContentAttestationMsg(string(provider), string(author), "invalid", ref, true),
+			wantResult:       ErrContentRefNotFound(types.GetPermlink(author, "invalid"), ref).Result(),
+			wantAttestations: []model.ContentAttestation{},
+		},
+		{
+			testName:   "attest content is available",
+			msg:        NewContentAttestationMsg(string(provider), string(author), postID, ref, true),
+			wantResult: sdk.Result{},
+			wantAttestations: []model.ContentAttestation{
+				{Provider: provider, Permlink: permlink, Ref: ref, Available: true, AttestedAt: ctx.BlockHeader().Time.Unix()},
+			},
+		},
+		{
+			testName:   "attest content is unavailable",
+			msg:        NewContentAttestationMsg(string(provider), string(author), postID, ref, false),
+			wantResult: sdk.Result{},
+			wantAttestations: []model.ContentAttestation{
+				{Provider: provider, Permlink: permlink, Ref: ref, Available: false, AttestedAt: ctx.BlockHeader().Time.Unix()},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		res := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.wantResult, res) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, res, tc.wantResult)
+		}
+		attestations, err := im.GetContentAttestations(ctx, permlink)
+		if err != nil {
+			t.Errorf("%s: failed to get attestations, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantAttestations, attestations) {
+			t.Errorf("%s: diff attestations, got %v, want %v", tc.testName, attestations, tc.wantAttestations)
+		}
+	}
+}
@@ -127,6 +127,25 @@ func (im *InfraManager) GetInfraProviderList(ctx sdk.Context) (*model.InfraProvi
 	return im.storage.GetInfraProviderList(ctx)
 }
 
+// AttestContent - record the latest availability of off-chain content attested by infra provider
+func (im InfraManager) AttestContent(
+	ctx sdk.Context, username types.AccountKey, permlink types.Permlink, ref string, available bool) sdk.Error {
+	attestation := &model.ContentAttestation{
+		Provider:   username,
+		Permlink:   permlink,
+		Ref:        ref,
+		Available:  available,
+		AttestedAt: ctx.BlockHeader().Time.Unix(),
+	}
+	return im.storage.SetContentAttestation(ctx, attestation)
+}
+
+// GetContentAttestations - get all content attestations of the post
+func (im InfraManager) GetContentAttestations(
+	ctx sdk.Context, permlink types.Permlink) ([]model.ContentAttestation, sdk.Error) {
+	return im.storage.GetContentAttestations(ctx, permlink)
+}
+
 // ClearUsage - clear all infra provider report usage
 func (im *InfraManager) ClearUsage(ctx sdk.Context) sdk.Error {
 	lst, err := im.storage.GetInfraProviderList(ctx)
@@ -9,7 +9,7 @@ import (
 )
 
 func TestRegister(t *testing.T) {
-	ctx, im := setupTest(t, 0)
+	ctx, im, _ := setupTest(t, 0)
 	im.InitGenesis(ctx)
 
 	user1 := types.AccountKey("user1")
@@ -21,7 +21,7 @@ func TestRegister(t *testing.T) {
 }
 
 func TestInfraProviderList(t *testing.T) {
-	ctx, im := setupTest(t, 0)
+	ctx, im, _ := setupTest(t, 0)
 	im.InitGenesis(ctx)
 
 	user1 := types.AccountKey("user1")
@@ -43,7 +43,7 @@ func TestInfraProviderList(t *testing.T) {
 }
 
 func TestReportUsage(t *testing.T) {
-	ctx, im := setupTest(t, 0)
+	ctx, im, _ := setupTest(t, 0)
 	im.InitGenesis(ctx)
 
 	user1 := types.AccountKey("user1")
@@ -36,3 +36,18 @@ func ErrFailedToUnmarshalInfraProvider(err error) sdk.Error {
 func ErrFailedToUnmarshalInfraProviderList(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalInfraProviderList, fmt.Sprintf("failed to unmarshal infra provider list: %s", err.Error()))
 }
+
+// ErrContentAttestationNotFound - error if content attestation is not found
+func ErrContentAttestationNotFound() sdk.Error {
+	return types.NewError(types.CodeContentAttestationNotFound, fmt.Sprintf("content attestation is not found"))
+}
+
+// ErrFailedToMarshalContentAttestation - error if marshal content attestation failed
+func ErrFailedToMarshalContentAttestation(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalContentAttestation, fmt.Sprintf("failed to marshal content attestation: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalContentAttestation - error if unmarshal content attestation failed
+func ErrFailedToUnmarshalContentAttestation(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalContentAttestation, fmt.Sprintf("failed to unmarshal content attestation: %s", err.Error()))
+}
@@ -14,3 +14,12 @@ type InfraProvider struct {
 type InfraProviderList struct {
 	AllInfraProviders []types.AccountKey `json:"all_infra_providers"`
 }
+
+// ContentAttestation - infra provider attests availability of off-chain content referenced by a post
+type ContentAttestation struct {
+	Provider   types.AccountKey `json:"provider"`
+	Permlink   types.Permlink   `json:"permlink"`
+	Ref        string           `json:"ref"`
+	Available  bool             `json:"available"`
+	AttestedAt int64            `json:"attested_at"`
+}
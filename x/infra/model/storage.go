@@ -7,8 +7,9 @@ import (
 )
 
 var (
-	infraProviderSubstore     = []byte{0x00}
-	infraProviderListSubstore = []byte{0x01}
+	infraProviderSubstore      = []byte{0x00}
+	infraProviderListSubstore  = []byte{0x01}
+	contentAttestationSubstore = []byte{0x02}
 )
 
 // InfraProviderStorage - infra provider storage
@@ -93,6 +94,49 @@ func (is InfraProviderStorage) SetInfraProviderList(ctx sdk.Context, lst *InfraP
 	return nil
 }
 
+// GetContentAttestation - get content attestation of given provider from KVStore
+func (is InfraProviderStorage) GetContentAttestation(
+	ctx sdk.Context, permlink types.Permlink, ref string, accKey types.AccountKey) (*ContentAttestation, sdk.Error) {
+	store := ctx.KVStore(is.key)
+	attestationByte := store.Get(GetContentAttestationKey(permlink, ref, accKey))
+	if attestationByte == nil {
+		return nil, ErrContentAttestationNotFound()
+	}
+	attestation := new(ContentAttestation)
+	if err := is.cdc.UnmarshalJSON(attestationByte, attestation); err != nil {
+		return nil, ErrFailedToUnmarshalContentAttestation(err)
+	}
+	return attestation, nil
+}
+
+// SetContentAttestation - set content attestation to KVStore
+func (is InfraProviderStorage) SetContentAttestation(ctx sdk.Context, attestation *ContentAttestation) sdk.Error {
+	store := ctx.KVStore(is.key)
+	attestationByte, err := is.cdc.MarshalJSON(*attestation)
+	if err != nil {
+		return ErrFailedToMarshalContentAttestation(err)
+	}
+	store.Set(GetContentAttestationKey(attestation.Permlink, attestation.Ref, attestation.Provider), attestationByte)
+	return nil
+}
+
+// GetContentAttestations - get all content attestations of a post from KVStore
+func (is InfraProviderStorage) GetContentAttestations(
+	ctx sdk.Context, permlink types.Permlink) ([]ContentAttestation, sdk.Error) {
+	store := ctx.KVStore(is.key)
+	iter := sdk.KVStorePrefixIterator(store, GetContentAttestationPrefix(permlink))
+	defer iter.Close()
+	attestations := []ContentAttestation{}
+	for ; iter.Valid(); iter.Next() {
+		attestation := new(ContentAttestation)
+		if err := is.cdc.UnmarshalJSON(iter.Value(), attestation); err != nil {
+			return nil, ErrFailedToUnmarshalContentAttestation(err)
+		}
+		attestations = append(attestations, *attestation)
+	}
+	return attestations, nil
+}
+
 // GetInfraProviderKey - get infra provider key in infra provider substore
 func GetInfraProviderKey(accKey types.AccountKey) []byte {
 	return append(infraProviderSubstore, accKey...)
@@ -102,3 +146,13 @@ func GetInfraProviderKey(accKey types.AccountKey) []byte {
 func GetInfraProviderListKey() []byte {
 	return infraProviderListSubstore
 }
+
+// GetContentAttestationPrefix - "content attestation substore" + "permlink" + "separator"
+func GetContentAttestationPrefix(permlink types.Permlink) []byte {
+	return append(append(contentAttestationSubstore, permlink...), types.KeySeparator...)
+}
+
+// GetContentAttestationKey - "content attestation prefix" + "ref" + "separator" + "provider"
+func GetContentAttestationKey(permlink types.Permlink, ref string, accKey types.AccountKey) []byte {
+	return append(append(append(GetContentAttestationPrefix(permlink), ref...), types.KeySeparator...), accKey...)
+}
@@ -50,6 +50,33 @@ func TestInfraProviderList(t *testing.T) {
 
 }
 
+func TestContentAttestation(t *testing.T) {
+	permlink := types.GetPermlink("author", "postID")
+	attestation1 := ContentAttestation{
+		Provider: "p1", Permlink: permlink, Ref: "ref1", Available: true, AttestedAt: 1}
+	attestation2 := ContentAttestation{
+		Provider: "p2", Permlink: permlink, Ref: "ref1", Available: false, AttestedAt: 2}
+	otherAttestation := ContentAttestation{
+		Provider: "p1", Permlink: types.GetPermlink("author", "postID2"), Ref: "ref1", Available: true, AttestedAt: 3}
+
+	runTest(t, func(env TestEnv) {
+		_, err := env.is.GetContentAttestation(env.ctx, permlink, "ref1", "p1")
+		assert.Equal(t, ErrContentAttestationNotFound(), err)
+
+		for _, attestation := range []ContentAttestation{attestation1, attestation2, otherAttestation} {
+			err := env.is.SetContentAttestation(env.ctx, &attestation)
+			assert.Nil(t, err)
+		}
+		resultPtr, err := env.is.GetContentAttestation(env.ctx, permlink, "ref1", "p1")
+		assert.Nil(t, err)
+		assert.Equal(t, attestation1, *resultPtr, "content attestation should be equal")
+
+		attestations, err := env.is.GetContentAttestations(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []ContentAttestation{attestation1, attestation2}, attestations)
+	})
+}
+
 //
 // Test Environment setup
 //
@@ -9,6 +9,7 @@ import (
 )
 
 var _ types.Msg = ProviderReportMsg{}
+var _ types.Msg = ContentAttestationMsg{}
 
 // ProviderReportMsg - infra provider report infra usage to blockchain
 type ProviderReportMsg struct {
@@ -16,6 +17,15 @@ type ProviderReportMsg struct {
 	Usage    int64            `json:"usage"`
 }
 
+// ContentAttestationMsg - infra provider attests availability of off-chain content referenced by a post
+type ContentAttestationMsg struct {
+	Username  types.AccountKey `json:"username"`
+	Author    types.AccountKey `json:"author"`
+	PostID    string           `json:"post_id"`
+	Ref       string           `json:"ref"`
+	Available bool             `json:"available"`
+}
+
 //----------------------------------------
 // ReportMsg Msg Implementations
 // NewProviderReportMsg - new ProviderReportMsg
@@ -70,3 +80,63 @@ func (msg ProviderReportMsg) GetSigners() []sdk.AccAddress {
 func (msg ProviderReportMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
+
+//----------------------------------------
+// ContentAttestationMsg Msg Implementations
+// NewContentAttestationMsg - new ContentAttestationMsg
+func NewContentAttestationMsg(provider, author, postID, ref string, available bool) ContentAttestationMsg {
+	return ContentAttestationMsg{
+		Username:  types.AccountKey(provider),
+		Author:    types.AccountKey(author),
+		PostID:    postID,
+		Ref:       ref,
+		Available: available,
+	}
+}
+
+// Type - implements sdk.Msg
+func (msg ContentAttestationMsg) Type() string { return types.InfraRouterName }
+
+// ValidateBasic - implements sdk.Msg
+func (msg ContentAttestationMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) < types.MinimumUsernameLength ||
+		len(msg.Username) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if len(msg.Author) == 0 || len(msg.PostID) == 0 ||
+		len(msg.Ref) == 0 || len(msg.Ref) > types.MaximumLengthOfContentRef {
+		return ErrInvalidContentAttestation()
+	}
+
+	return nil
+}
+
+func (msg ContentAttestationMsg) String() string {
+	return fmt.Sprintf("ContentAttestationMsg{Username:%v, Author:%v, PostID:%v, Ref:%v, Available:%v}",
+		msg.Username, msg.Author, msg.PostID, msg.Ref, msg.Available)
+}
+
+// GetPermission - implements types.Msg
+func (msg ContentAttestationMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg ContentAttestationMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implements sdk.Msg
+func (msg ContentAttestationMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg ContentAttestationMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
@@ -40,6 +40,48 @@ func TestProviderReportMsg(t *testing.T) {
 	}
 }
 
+func TestContentAttestationMsg(t *testing.T) {
+	testCases := []struct {
+		testName              string
+		contentAttestationMsg ContentAttestationMsg
+		expectError           sdk.Error
+	}{
+		{
+			testName:              "normal case",
+			contentAttestationMsg: NewContentAttestationMsg("user1", "author", "postID", "ref", true),
+			expectError:           nil,
+		},
+		{
+			testName:              "invalid username",
+			contentAttestationMsg: NewContentAttestationMsg("", "author", "postID", "ref", true),
+			expectError:           ErrInvalidUsername(),
+		},
+		{
+			testName:              "no post id",
+			contentAttestationMsg: NewContentAttestationMsg("user1", "author", "", "ref", true),
+			expectError:           ErrInvalidContentAttestation(),
+		},
+		{
+			testName:              "no ref",
+			contentAttestationMsg: NewContentAttestationMsg("user1", "author", "postID", "", false),
+			expectError:           ErrInvalidContentAttestation(),
+		},
+		{
+			testName: "ref is too long",
+			contentAttestationMsg: NewContentAttestationMsg(
+				"user1", "author", "postID", string(make([]byte, types.MaximumLengthOfContentRef+1)), true),
+			expectError: ErrInvalidContentAttestation(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.contentAttestationMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectError)
+		}
+	}
+}
+
 func TestMsgPermission(t *testing.T) {
 	testCases := map[string]struct {
 		msg              types.Msg
@@ -49,6 +91,10 @@ func TestMsgPermission(t *testing.T) {
 			msg:              NewProviderReportMsg("test", 1),
 			expectPermission: types.TransactionPermission,
 		},
+		"content attestation msg": {
+			msg:              NewContentAttestationMsg("test", "author", "postID", "ref", true),
+			expectPermission: types.TransactionPermission,
+		},
 	}
 
 	for testName, tc := range testCases {
@@ -67,6 +113,9 @@ func TestGetSignBytes(t *testing.T) {
 		"provider report msg": {
 			msg: NewProviderReportMsg("test", 1),
 		},
+		"content attestation msg": {
+			msg: NewContentAttestationMsg("test", "author", "postID", "ref", true),
+		},
 	}
 
 	for testName, tc := range testCases {
@@ -83,6 +132,10 @@ func TestGetSigners(t *testing.T) {
 			msg:           NewProviderReportMsg("test", 1),
 			expectSigners: []types.AccountKey{"test"},
 		},
+		"content attestation msg": {
+			msg:           NewContentAttestationMsg("test", "author", "postID", "ref", true),
+			expectSigners: []types.AccountKey{"test"},
+		},
 	}
 
 	for testName, tc := range testCases {
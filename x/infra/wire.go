@@ -2,11 +2,13 @@ package infra
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // RegisterWire - register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(ProviderReportMsg{}, "lino/providerReport", nil)
+	types.RegisterMsg(cdc, ProviderReportMsg{}, "lino/providerReport")
+	types.RegisterMsg(cdc, ContentAttestationMsg{}, "lino/contentAttestation")
 }
 
 var msgCdc = wire.NewCodec()
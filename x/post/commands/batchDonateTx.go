@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	post "github.com/lino-network/lino/x/post"
+)
+
+// BatchDonateTxCmd will create a batch donate tx and sign it with the given key
+func BatchDonateTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-donate",
+		Short: "donate to many posts in one transaction",
+		RunE:  sendBatchDonateTx(cdc),
+	}
+	cmd.Flags().String(client.FlagDonator, "", "donator of this transaction")
+	cmd.Flags().StringSlice(client.FlagDonations, nil,
+		"comma separated donations in author:postID:amount[:memo] format")
+	return cmd
+}
+
+// send batch donate transaction to the blockchain
+func sendBatchDonateTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		username := viper.GetString(client.FlagDonator)
+		donations := []post.BatchDonation{}
+		for _, donation := range viper.GetStringSlice(client.FlagDonations) {
+			fields := strings.SplitN(donation, ":", 4)
+			if len(fields) < 3 {
+				return errors.New("donation must be in author:postID:amount[:memo] format")
+			}
+			batchDonation := post.BatchDonation{
+				Author: types.AccountKey(fields[0]),
+				PostID: fields[1],
+				Amount: types.LNO(fields[2]),
+			}
+			if len(fields) == 4 {
+				batchDonation.Memo = fields[3]
+			}
+			donations = append(donations, batchDonation)
+		}
+		msg := post.NewBatchDonateMsg(username, "", donations)
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
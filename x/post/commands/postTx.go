@@ -1,11 +1,13 @@
 package commands
 
 import (
-	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -29,6 +31,13 @@ func PostTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagSourceAuthor, "", "source post author name")
 	cmd.Flags().String(client.FlagSourcePostID, "", "source post id")
 	cmd.Flags().String(client.FlagRedistributionSplitRate, "0", "redistribution split rate")
+	cmd.Flags().Int(client.FlagReplyPermission, 0, "who can reply: 0 everyone, 1 followers only, 2 nobody")
+	cmd.Flags().StringSlice(client.FlagTags, nil, "comma separated tags of the post")
+	cmd.Flags().Int(client.FlagPaywallType, 0, "paywall of the post: 0 free, 1 one time unlock, 2 pay per view")
+	cmd.Flags().String(client.FlagUnlockPrice, "", "unlock price of paywalled post")
+	cmd.Flags().StringSlice(client.FlagBeneficiaries, nil, "comma separated beneficiaries in username:weight format, weights sum to 1")
+	cmd.Flags().StringSlice(client.FlagContentRefs, nil,
+		"comma separated content references in type:ref:size:mime format, type 0 is IPFS CID and 1 is sha256")
 	return cmd
 }
 
@@ -47,16 +56,45 @@ func sendPostTx(cdc *wire.Codec) client.CommandTxCallback {
 			SourceAuthor:            types.AccountKey(viper.GetString(client.FlagSourceAuthor)),
 			SourcePostID:            viper.GetString(client.FlagSourcePostID),
 			RedistributionSplitRate: viper.GetString(client.FlagRedistributionSplitRate),
+			ReplyPermission:         types.ReplyPermission(viper.GetInt(client.FlagReplyPermission)),
+			PaywallType:             types.PaywallType(viper.GetInt(client.FlagPaywallType)),
+			UnlockPrice:             types.LNO(viper.GetString(client.FlagUnlockPrice)),
 		}
-
-		// build and sign the transaction, then broadcast to Tendermint
-		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if err != nil {
-			return err
+		for _, tag := range viper.GetStringSlice(client.FlagTags) {
+			msg.Tags = append(msg.Tags, post.NormalizeTag(tag))
+		}
+		for _, beneficiary := range viper.GetStringSlice(client.FlagBeneficiaries) {
+			pair := strings.SplitN(beneficiary, ":", 2)
+			if len(pair) != 2 {
+				return errors.New("beneficiary must be in username:weight format")
+			}
+			msg.Beneficiaries = append(msg.Beneficiaries, types.Beneficiary{
+				Username: types.AccountKey(pair[0]),
+				Weight:   pair[1],
+			})
+		}
+		for _, contentRef := range viper.GetStringSlice(client.FlagContentRefs) {
+			fields := strings.SplitN(contentRef, ":", 4)
+			if len(fields) != 4 {
+				return errors.New("content reference must be in type:ref:size:mime format")
+			}
+			refType, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return errors.Wrap(err, "invalid content reference type")
+			}
+			size, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid content reference size")
+			}
+			msg.ContentRefs = append(msg.ContentRefs, types.ContentReference{
+				RefType:  types.ContentRefType(refType),
+				Ref:      fields[1],
+				Size:     size,
+				MimeType: fields[3],
+			})
 		}
 
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
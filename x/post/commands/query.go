@@ -1,12 +1,18 @@
 package commands
 
 import (
+	"sort"
+	"strconv"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
+	globalModel "github.com/lino-network/lino/x/global/model"
+	"github.com/lino-network/lino/x/post"
 	"github.com/lino-network/lino/x/post/model"
 )
 
@@ -107,3 +113,382 @@ func (c commander) getPostsCmd(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// GetPostCensorshipCmd returns a query command that will display the
+// censorship history and censored content of the post at a given author and postID
+func GetPostCensorshipCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "censorship <author> <postID>",
+		Short: "Query censorship history of a post",
+		RunE:  cmdr.getPostCensorshipCmd,
+	}
+}
+
+func (c commander) getPostCensorshipCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide an valid author and post id")
+	}
+
+	postKey := types.GetPermlink(types.AccountKey(args[0]), args[1])
+
+	res, err := ctx.Query(model.GetPostMetaKey(postKey), c.storeName)
+	if err != nil {
+		return err
+	}
+	postMeta := new(model.PostMeta)
+	if err := c.cdc.UnmarshalJSON(res, postMeta); err != nil {
+		return err
+	}
+	if err := client.PrintIndent(postMeta.CensorshipHistory); err != nil {
+		return err
+	}
+
+	// censored content only exists when the post is currently censored
+	res, err = ctx.Query(model.GetPostTombstoneKey(postKey), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	censoredPost := new(model.CensoredPost)
+	if err := c.cdc.UnmarshalJSON(res, censoredPost); err != nil {
+		return err
+	}
+	return client.PrintIndent(censoredPost)
+}
+
+// GetPostRevisionsCmd returns a query command that will display all stored
+// revisions of the post, or a specific revision if revision number is given
+func GetPostRevisionsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "revisions <author> <postID> [revision]",
+		Short: "Query revisions of a post",
+		RunE:  cmdr.getPostRevisionsCmd,
+	}
+}
+
+func (c commander) getPostRevisionsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) < 2 || len(args) > 3 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide an valid author and post id")
+	}
+
+	postKey := types.GetPermlink(types.AccountKey(args[0]), args[1])
+
+	if len(args) == 3 {
+		revision, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return errors.New("You must provide an valid revision number")
+		}
+		res, err := ctx.Query(model.GetPostRevisionKey(postKey, revision), c.storeName)
+		if err != nil {
+			return err
+		}
+		if len(res) == 0 {
+			return errors.Errorf("revision %d of post %s not found", revision, postKey)
+		}
+		postRevision := new(model.PostRevision)
+		if err := c.cdc.UnmarshalJSON(res, postRevision); err != nil {
+			return err
+		}
+		return client.PrintIndent(postRevision)
+	}
+
+	resKVs, err := ctx.QuerySubspace(c.cdc, model.GetPostRevisionPrefix(postKey), c.storeName)
+	if err != nil {
+		return err
+	}
+	revisions := []model.PostRevision{}
+	for _, KV := range resKVs {
+		var postRevision model.PostRevision
+		if err := c.cdc.UnmarshalJSON(KV.Value, &postRevision); err != nil {
+			return err
+		}
+		revisions = append(revisions, postRevision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return client.PrintIndent(revisions)
+}
+
+// GetTaggedPostsCmd returns a query command that will display posts with
+// the given tag page by page, newest first
+func GetTaggedPostsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "tag-posts <tag>",
+		Short: "Query posts with a tag",
+		RunE:  cmdr.getTaggedPostsCmd,
+	}
+	cmd.Flags().Int(client.FlagPage, 1, "page number, starts from 1")
+	cmd.Flags().Int(client.FlagPageSize, 20, "number of posts per page")
+	return cmd
+}
+
+func (c commander) getTaggedPostsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide a tag")
+	}
+
+	query, err := c.cdc.MarshalJSON(post.TaggedPostsQuery{
+		Tag:      post.NormalizeTag(args[0]),
+		Page:     viper.GetInt(client.FlagPage),
+		PageSize: viper.GetInt(client.FlagPageSize),
+	})
+	if err != nil {
+		return err
+	}
+	taggedPosts := []model.TaggedPost{}
+	if err := ctx.QueryApp(c.cdc, types.TaggedPostsQueryPath, query, &taggedPosts); err != nil {
+		return err
+	}
+	return client.PrintIndent(taggedPosts)
+}
+
+// GetTrendingTagsCmd returns a query command that will display tags
+// received most donations in recent days
+func GetTrendingTagsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "trending-tags",
+		Short: "Query tags received most donations in recent days",
+		RunE:  cmdr.getTrendingTagsCmd,
+	}
+	cmd.Flags().Int64(client.FlagDays, 7, "number of recent days")
+	cmd.Flags().Int(client.FlagLimit, 20, "maximum number of tags")
+	return cmd
+}
+
+func (c commander) getTrendingTagsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	query, err := c.cdc.MarshalJSON(post.TrendingTagsQuery{
+		Days:  viper.GetInt64(client.FlagDays),
+		Limit: viper.GetInt(client.FlagLimit),
+	})
+	if err != nil {
+		return err
+	}
+	trendingTags := []post.TrendingTag{}
+	if err := ctx.QueryApp(c.cdc, types.TrendingTagsQueryPath, query, &trendingTags); err != nil {
+		return err
+	}
+	return client.PrintIndent(trendingTags)
+}
+
+// GetPostAccessCmd returns a query command that will display whether
+// a user has access to a paywalled post
+func GetPostAccessCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "post-access <author> <postID> <username>",
+		Short: "Query if a user has access to a paywalled post",
+		RunE:  cmdr.getPostAccessCmd,
+	}
+}
+
+func (c commander) getPostAccessCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 3 || len(args[0]) == 0 || len(args[1]) == 0 || len(args[2]) == 0 {
+		return errors.New("You must provide an valid author, post id and username")
+	}
+	author := types.AccountKey(args[0])
+	user := types.AccountKey(args[2])
+	postKey := types.GetPermlink(author, args[1])
+
+	paywall := &model.Paywall{PaywallType: types.NoPaywall, Price: types.NewCoinFromInt64(0)}
+	res, err := ctx.Query(model.GetPostPaywallKey(postKey), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) != 0 {
+		if err := c.cdc.UnmarshalJSON(res, paywall); err != nil {
+			return err
+		}
+	}
+
+	var postUnlock *model.PostUnlock
+	res, err = ctx.Query(model.GetPostUnlockKey(postKey, user), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) != 0 {
+		postUnlock = new(model.PostUnlock)
+		if err := c.cdc.UnmarshalJSON(res, postUnlock); err != nil {
+			return err
+		}
+	}
+	return client.PrintIndent(post.NewPostAccess(author, paywall, postUnlock, user))
+}
+
+// GetReportQueueCmd returns a query command that will display most reported
+// posts ordered by reporters' reputation
+func GetReportQueueCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "report-queue",
+		Short: "Query most reported posts",
+		RunE:  cmdr.getReportQueueCmd,
+	}
+	cmd.Flags().Int(client.FlagLimit, 20, "maximum number of posts")
+	return cmd
+}
+
+func (c commander) getReportQueueCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	resKVs, err := ctx.QuerySubspace(c.cdc, model.GetPostReportStatPrefix(), c.storeName)
+	if err != nil {
+		return err
+	}
+	stats := []model.PostReportStat{}
+	for _, KV := range resKVs {
+		var stat model.PostReportStat
+		if err := c.cdc.UnmarshalJSON(KV.Value, &stat); err != nil {
+			return err
+		}
+		stats = append(stats, stat)
+	}
+	return client.PrintIndent(post.GetReportQueue(stats, viper.GetInt(client.FlagLimit)))
+}
+
+// GetPostReportStatCmd returns a query command that will display
+// report aggregation of a post
+func GetPostReportStatCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "report-stat <author> <postID>",
+		Short: "Query report stat of a post",
+		RunE:  cmdr.getPostReportStatCmd,
+	}
+}
+
+func (c commander) getPostReportStatCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide an valid author and post id")
+	}
+
+	postKey := types.GetPermlink(types.AccountKey(args[0]), args[1])
+	res, err := ctx.Query(model.GetPostReportStatKey(postKey), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return errors.Errorf("post %s has no report", postKey)
+	}
+	stat := new(model.PostReportStat)
+	if err := c.cdc.UnmarshalJSON(res, stat); err != nil {
+		return err
+	}
+	return client.PrintIndent(stat)
+}
+
+// GetPostStatsCmd returns a query command that will display daily, weekly
+// and monthly stat buckets of a post in recent days
+func GetPostStatsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "post-stats <author> <postID>",
+		Short: "Query donations, views, upvote coin day and reward of a post over time",
+		RunE:  cmdr.getPostStatsCmd,
+	}
+	cmd.Flags().Int64(client.FlagDays, 30, "number of recent days")
+	return cmd
+}
+
+func (c commander) getPostStatsCmd(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return errors.New("You must provide an valid author and post id")
+	}
+	postKey := types.GetPermlink(types.AccountKey(args[0]), args[1])
+	return c.printPostStats(model.GetPostStatPrefix(postKey))
+}
+
+// GetAuthorStatsCmd returns a query command that will display daily, weekly
+// and monthly stat buckets of all posts of an author in recent days
+func GetAuthorStatsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "author-stats <author>",
+		Short: "Query donations, views, upvote coin day and reward of an author over time",
+		RunE:  cmdr.getAuthorStatsCmd,
+	}
+	cmd.Flags().Int64(client.FlagDays, 30, "number of recent days")
+	return cmd
+}
+
+func (c commander) getAuthorStatsCmd(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 || len(args[0]) == 0 {
+		return errors.New("You must provide an author name")
+	}
+	return c.printPostStats(model.GetAuthorStatPrefix(types.AccountKey(args[0])))
+}
+
+func (c commander) printPostStats(prefix []byte) error {
+	ctx := client.NewCoreContextFromViper()
+	node, err := ctx.GetNode()
+	if err != nil {
+		return err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return err
+	}
+
+	resKVs, err := ctx.QuerySubspace(c.cdc, prefix, c.storeName)
+	if err != nil {
+		return err
+	}
+	stats := []model.PostStat{}
+	for _, KV := range resKVs {
+		var stat model.PostStat
+		if err := c.cdc.UnmarshalJSON(KV.Value, &stat); err != nil {
+			return err
+		}
+		stats = append(stats, stat)
+	}
+	// stat buckets are counted in days since chain start
+	res, err := ctx.Query(globalModel.GetTimeKey(), types.GlobalKVStoreKey)
+	if err != nil {
+		return err
+	}
+	globalTime := new(globalModel.GlobalTime)
+	if err := c.cdc.UnmarshalJSON(res, globalTime); err != nil {
+		return err
+	}
+	today := (status.SyncInfo.LatestBlockTime.Unix() - globalTime.ChainStartTime) / types.SecondsPerDay
+	return client.PrintIndent(
+		post.FilterPostStats(stats, today-viper.GetInt64(client.FlagDays)+1, today))
+}
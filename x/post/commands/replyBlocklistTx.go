@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	post "github.com/lino-network/lino/x/post"
+)
+
+// UpdateReplyBlocklistTxCmd blocks or unblocks a user from replying and sign it with the given key
+func UpdateReplyBlocklistTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reply-blocklist",
+		Short: "block or unblock a user from replying a post or all posts of the author",
+		RunE:  sendUpdateReplyBlocklistTx(cdc),
+	}
+	cmd.Flags().String(client.FlagAuthor, "", "author of the post")
+	cmd.Flags().String(client.FlagPostID, "", "post id, leave empty to apply to all posts of the author")
+	cmd.Flags().String(client.FlagUser, "", "user to block or unblock")
+	cmd.Flags().Bool(client.FlagIsBlocked, true, "block or unblock the user")
+	return cmd
+}
+
+// send update reply blocklist transaction to the blockchain
+func sendUpdateReplyBlocklistTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := post.NewUpdateReplyBlocklistMsg(
+			viper.GetString(client.FlagAuthor), viper.GetString(client.FlagPostID),
+			viper.GetString(client.FlagUser), viper.GetBool(client.FlagIsBlocked))
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
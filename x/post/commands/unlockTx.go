@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	post "github.com/lino-network/lino/x/post"
+)
+
+// UnlockPostTxCmd will create a unlock post tx and sign it with the given key
+func UnlockPostTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "pay unlock price of a paywalled post",
+		RunE:  sendUnlockPostTx(cdc),
+	}
+	cmd.Flags().String(client.FlagUser, "", "user who unlocks the post")
+	cmd.Flags().String(client.FlagAuthor, "", "author of the target post")
+	cmd.Flags().String(client.FlagPostID, "", "post id of the target post")
+	cmd.Flags().String(client.FlagAmount, "", "unlock price of the post")
+	return cmd
+}
+
+// send unlock post transaction to the blockchain
+func sendUnlockPostTx(cdc *wire.Codec) client.CommandTxCallback {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := client.NewCoreContextFromViper()
+		msg := post.NewUnlockPostMsg(
+			viper.GetString(client.FlagUser), types.LNO(viper.GetString(client.FlagAmount)),
+			viper.GetString(client.FlagAuthor), viper.GetString(client.FlagPostID), "")
+
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
+	}
+}
@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
 	"github.com/lino-network/lino/types"
@@ -24,6 +22,7 @@ func UpdatePostTxCmd(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagPostID, "", "post id to identify this post for the author")
 	cmd.Flags().String(client.FlagTitle, "", "title for the post")
 	cmd.Flags().String(client.FlagContent, "", "content for the post")
+	cmd.Flags().Int(client.FlagReplyPermission, 0, "who can reply: 0 everyone, 1 followers only, 2 nobody, unchanged if omitted")
 	return cmd
 }
 
@@ -36,15 +35,12 @@ func sendUpdatePostTx(cdc *wire.Codec) client.CommandTxCallback {
 			viper.GetString(client.FlagAuthor), viper.GetString(client.FlagPostID),
 			viper.GetString(client.FlagTitle), viper.GetString(client.FlagContent),
 			[]types.IDToURLMapping(nil))
-
-		// build and sign the transaction, then broadcast to Tendermint
-		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if err != nil {
-			return err
+		if cmd.Flags().Changed(client.FlagReplyPermission) {
+			replyPermission := types.ReplyPermission(viper.GetInt(client.FlagReplyPermission))
+			msg.ReplyPermission = &replyPermission
 		}
 
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
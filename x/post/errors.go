@@ -147,3 +147,103 @@ func ErrNoUsername() sdk.Error {
 func ErrInvalidMemo() sdk.Error {
 	return types.NewError(types.CodeInvalidMemo, fmt.Sprintf("invalid memo"))
 }
+
+// ErrInvalidReplyPermission - error when reply permission is invalid
+func ErrInvalidReplyPermission() sdk.Error {
+	return types.NewError(types.CodeInvalidReplyPermission, fmt.Sprintf("invalid reply permission"))
+}
+
+// ErrReplyNotAllowed - error when user is not allowed to reply the post
+func ErrReplyNotAllowed(permlink types.Permlink, user types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeReplyNotAllowed, fmt.Sprintf("%v is not allowed to reply post %v", user, permlink))
+}
+
+// ErrCannotBlockSelf - error when author blocks himself from replying
+func ErrCannotBlockSelf(user types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeCannotBlockSelf, fmt.Sprintf("%v can't block self from replying", user))
+}
+
+// ErrTooManyTags - error when posting with too many tags
+func ErrTooManyTags() sdk.Error {
+	return types.NewError(types.CodeTooManyTags, fmt.Sprintf("too many tags"))
+}
+
+// ErrInvalidTag - error when post tag is invalid or duplicate
+func ErrInvalidTag(tag string) sdk.Error {
+	return types.NewError(types.CodeInvalidTag, fmt.Sprintf("invalid tag: %v", tag))
+}
+
+// ErrTooManyBeneficiaries - error when posting with too many beneficiaries
+func ErrTooManyBeneficiaries() sdk.Error {
+	return types.NewError(types.CodeTooManyBeneficiaries, fmt.Sprintf("too many beneficiaries"))
+}
+
+// ErrInvalidBeneficiary - error when beneficiary username or weight is invalid or duplicate
+func ErrInvalidBeneficiary(username types.AccountKey) sdk.Error {
+	return types.NewError(types.CodeInvalidBeneficiary, fmt.Sprintf("invalid beneficiary: %v", username))
+}
+
+// ErrInvalidBeneficiaryWeights - error when beneficiary weights don't sum to 1
+func ErrInvalidBeneficiaryWeights() sdk.Error {
+	return types.NewError(types.CodeInvalidBeneficiaryWeights, fmt.Sprintf("beneficiary weights must sum to 1"))
+}
+
+// ErrInvalidPaywall - error when paywall type or unlock price is invalid
+func ErrInvalidPaywall() sdk.Error {
+	return types.NewError(types.CodeInvalidPaywall, fmt.Sprintf("invalid paywall"))
+}
+
+// ErrPostNotPaywalled - error when unlocking a post without paywall
+func ErrPostNotPaywalled(permlink types.Permlink) sdk.Error {
+	return types.NewError(types.CodePostNotPaywalled, fmt.Sprintf("post %v is not paywalled", permlink))
+}
+
+// ErrUnlockPriceMismatch - error when unlock amount is different from post unlock price
+func ErrUnlockPriceMismatch(price types.Coin) sdk.Error {
+	return types.NewError(types.CodeUnlockPriceMismatch, fmt.Sprintf("unlock price mismatch, price is %v", price))
+}
+
+// ErrPostAlreadyUnlocked - error when unlocking a one time unlock post again
+func ErrPostAlreadyUnlocked(permlink types.Permlink, user types.AccountKey) sdk.Error {
+	return types.NewError(types.CodePostAlreadyUnlocked, fmt.Sprintf("post %v already unlocked by %v", permlink, user))
+}
+
+// ErrCannotUnlockOwnPost - error when author unlocks own post
+func ErrCannotUnlockOwnPost() sdk.Error {
+	return types.NewError(types.CodeCannotUnlockOwnPost, fmt.Sprintf("author can't unlock own post"))
+}
+
+// ErrInvalidReportReason - error when report reason is invalid or upvote has a reason
+func ErrInvalidReportReason() sdk.Error {
+	return types.NewError(types.CodeInvalidReportReason, fmt.Sprintf("invalid report reason"))
+}
+
+// ErrTooManyContentRefs - error when post has too many content references
+func ErrTooManyContentRefs() sdk.Error {
+	return types.NewError(types.CodeTooManyContentRefs, fmt.Sprintf("too many content references"))
+}
+
+// ErrInvalidContentRef - error when content reference is invalid or duplicate
+func ErrInvalidContentRef(ref string) sdk.Error {
+	return types.NewError(types.CodeInvalidContentRef, fmt.Sprintf("invalid content reference %v", ref))
+}
+
+// ErrNoDonationInBatch - error when batch donate msg has no donation
+func ErrNoDonationInBatch() sdk.Error {
+	return types.NewError(types.CodeNoDonationInBatch, fmt.Sprintf("no donation in batch"))
+}
+
+// ErrTooManyDonationsInBatch - error when batch donate msg has too many donations
+func ErrTooManyDonationsInBatch() sdk.Error {
+	return types.NewError(types.CodeTooManyDonationsInBatch, fmt.Sprintf("too many donations in batch"))
+}
+
+// ErrDuplicateDonationInBatch - error when batch donate msg donates to a post more than once
+func ErrDuplicateDonationInBatch(permlink types.Permlink) sdk.Error {
+	return types.NewError(types.CodeDuplicateDonationInBatch, fmt.Sprintf("duplicate donation to post %v in batch", permlink))
+}
+
+// ErrPostDeletedByAuthor - error when censor or restore a post deleted by its author
+func ErrPostDeletedByAuthor(permlink types.Permlink) sdk.Error {
+	return types.NewError(types.CodePostDeletedByAuthor, fmt.Sprintf("post %v is deleted by author", permlink))
+}
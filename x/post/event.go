@@ -1,6 +1,8 @@
 package post
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/wire"
 
 	"github.com/lino-network/lino/types"
@@ -40,6 +42,9 @@ func (event RewardEvent) Execute(
 	vm vote.VoteManager, rm rep.ReputationManager) sdk.Error {
 
 	permlink := types.GetPermlink(event.PostAuthor, event.PostID)
+	if err := pm.PrunePendingRewards(ctx, permlink); err != nil {
+		return err
+	}
 	// check if post is deleted
 	rep, err := rm.GetSumRep(ctx, permlink)
 	if err != nil {
@@ -67,20 +72,95 @@ func (event RewardEvent) Execute(
 		return ErrPostNotFound(permlink)
 	}
 	// add donation information to post
-	if err := pm.AddDonation(ctx, permlink, event.Consumer, reward, types.Inflation); err != nil {
+	pastDay, err := gm.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
+	if err != nil {
+		return err
+	}
+	if err := pm.AddDonation(ctx, permlink, event.Consumer, reward, types.Inflation, pastDay); err != nil {
+		return err
+	}
+	// split reward, original donation and friction among beneficiaries,
+	// each beneficiary gets half of its reward and the other half is added to stake
+	rewardShares, err := pm.GetDonationShares(ctx, permlink, reward)
+	if err != nil {
 		return err
 	}
-	// add half reward to user
-	addToReward := types.RatToCoin(reward.ToRat().Mul(sdk.NewRat(1, 2)))
-	addToStake := reward.Minus(addToReward)
-	if err := am.AddIncomeAndReward(
-		ctx, event.PostAuthor, event.Original, event.Friction, addToReward, event.Consumer, event.PostAuthor, event.PostID); err != nil {
+	originalShares, err := pm.GetDonationShares(ctx, permlink, event.Original)
+	if err != nil {
 		return err
 	}
-	if !addToStake.IsZero() {
-		if err := vote.AddStake(ctx, event.PostAuthor, addToStake, vm, gm, am, rm); err != nil {
+	frictionShares, err := pm.GetDonationShares(ctx, permlink, event.Friction)
+	if err != nil {
+		return err
+	}
+	for i, share := range rewardShares {
+		// share of a beneficiary whose account doesn't exist anymore goes to the author
+		receiver := share.Username
+		if !am.DoesAccountExist(ctx, receiver) {
+			receiver = event.PostAuthor
+		}
+		addToReward := types.RatToCoin(share.Amount.ToRat().Mul(sdk.NewRat(1, 2)))
+		addToStake := share.Amount.Minus(addToReward)
+		// referrer of the beneficiary gets a share of the reward
+		addToReward, err = am.ShareRewardWithReferrer(ctx, receiver, addToReward)
+		if err != nil {
 			return err
 		}
+		if err := am.AddIncomeAndReward(
+			ctx, receiver, originalShares[i].Amount, frictionShares[i].Amount, addToReward,
+			event.Consumer, event.PostAuthor, event.PostID); err != nil {
+			return err
+		}
+		if !addToStake.IsZero() {
+			if err := vote.AddStake(ctx, receiver, addToStake, vm, gm, am, rm); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CancelPendingRewardEvents - remove reward events of a censored post which are still
+// in the consumption freezing period, friction hasn't been claimed by stake holders
+// is refunded to the consumer and the post gets no inflation from these donations.
+// Friction of a closed consumer account is kept by stake holders. Reward events registered
+// at or after since are cancelled, since is the time of the event censoring the post
+func CancelPendingRewardEvents(
+	ctx sdk.Context, permlink types.Permlink, since int64, pm PostManager,
+	am acc.AccountManager, gm global.GlobalManager) sdk.Error {
+	pendingRewards, err := pm.GetPendingRewardsSince(ctx, permlink, since)
+	if err != nil {
+		return err
+	}
+	for _, pendingReward := range pendingRewards {
+		events, err := gm.RemoveTimeEvents(ctx, pendingReward.EventTime, func(e types.Event) bool {
+			rewardEvent, ok := e.(RewardEvent)
+			return ok && types.GetPermlink(rewardEvent.PostAuthor, rewardEvent.PostID) == permlink
+		})
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			rewardEvent := e.(RewardEvent)
+			friction := rewardEvent.Friction
+			if !am.DoesAccountExist(ctx, rewardEvent.Consumer) {
+				friction = types.NewCoinFromInt64(0)
+			}
+			refund, err := gm.CancelFrictionAndContentReward(
+				ctx, pendingReward.DonatedAt, friction, rewardEvent.Evaluate)
+			if err != nil {
+				return err
+			}
+			if refund.IsZero() {
+				continue
+			}
+			if err := am.AddSavingCoin(
+				ctx, rewardEvent.Consumer, refund, "",
+				fmt.Sprintf("refund donation to censored post: %v", permlink), types.DonationRefund); err != nil {
+				return err
+			}
+		}
+		pm.DeletePendingReward(ctx, permlink, pendingReward.EventTime)
 	}
 	return nil
 }
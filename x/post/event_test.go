@@ -2,6 +2,7 @@ package post
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lino-network/lino/types"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,7 @@ import (
 	accModel "github.com/lino-network/lino/x/account/model"
 	globalModel "github.com/lino-network/lino/x/global/model"
 	postModel "github.com/lino-network/lino/x/post/model"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func TestRewardEvent(t *testing.T) {
@@ -209,3 +211,229 @@ func TestRewardEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestRewardEventWithBeneficiaries(t *testing.T) {
+	ctx, am, _, pm, gm, dm, vm, rm := setupTest(t, 1)
+	gs := globalModel.NewGlobalStorage(testGlobalKVStoreKey)
+	as := accModel.NewAccountStorage(testAccountKVStoreKey)
+
+	user, postID := createTestPost(t, ctx, "user", "postID", am, pm, "0")
+	coauthor := createTestAccount(t, ctx, am, "coauthor")
+	consumer := createTestAccount(t, ctx, am, "consumer")
+	err := pm.SetPostBeneficiaries(ctx, types.GetPermlink(user, postID), []postModel.Beneficiary{
+		{Username: user, Weight: sdk.NewRat(3, 5)},
+		{Username: coauthor, Weight: sdk.NewRat(2, 5)},
+	})
+	assert.Nil(t, err)
+	gs.SetConsumptionMeta(ctx, &globalModel.ConsumptionMeta{
+		ConsumptionRewardPool: types.NewCoinFromInt64(100),
+		ConsumptionWindow:     types.NewCoinFromInt64(100),
+	})
+	as.SetReward(ctx, user, &accModel.Reward{})
+	as.SetReward(ctx, coauthor, &accModel.Reward{})
+
+	rewardEvent := RewardEvent{
+		PostAuthor: user,
+		PostID:     postID,
+		Consumer:   consumer,
+		Evaluate:   types.NewCoinFromInt64(100),
+		Original:   types.NewCoinFromInt64(100),
+		Friction:   types.NewCoinFromInt64(15),
+	}
+	err = rewardEvent.Execute(ctx, pm, am, gm, dm, vm, rm)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName          string
+		username          types.AccountKey
+		expectReward      accModel.Reward
+		expectVotingPower types.Coin
+	}{
+		{
+			testName: "author gets 60% of reward",
+			username: user,
+			expectReward: accModel.Reward{
+				TotalIncome:     types.NewCoinFromInt64(30),
+				OriginalIncome:  types.NewCoinFromInt64(9),
+				FrictionIncome:  types.NewCoinFromInt64(9),
+				InflationIncome: types.NewCoinFromInt64(30),
+				UnclaimReward:   types.NewCoinFromInt64(30),
+			},
+			expectVotingPower: types.NewCoinFromInt64(30),
+		},
+		{
+			testName: "coauthor gets 40% of reward",
+			username: coauthor,
+			expectReward: accModel.Reward{
+				TotalIncome:     types.NewCoinFromInt64(20),
+				OriginalIncome:  types.NewCoinFromInt64(6),
+				FrictionIncome:  types.NewCoinFromInt64(6),
+				InflationIncome: types.NewCoinFromInt64(20),
+				UnclaimReward:   types.NewCoinFromInt64(20),
+			},
+			expectVotingPower: types.NewCoinFromInt64(20),
+		},
+	}
+	for _, tc := range testCases {
+		reward, err := as.GetReward(ctx, tc.username)
+		if err != nil {
+			t.Errorf("%s: failed to get reward, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.expectReward, *reward) {
+			t.Errorf("%s: diff reward, got %v, want %v", tc.testName, *reward, tc.expectReward)
+		}
+		votingPower, err := vm.GetVotingPower(ctx, tc.username)
+		if err != nil {
+			t.Errorf("%s: failed to get voting power, got err %v", tc.testName, err)
+		}
+		if !votingPower.IsEqual(tc.expectVotingPower) {
+			t.Errorf("%s: diff voting power, got %v, want %v", tc.testName, votingPower, tc.expectVotingPower)
+		}
+	}
+}
+
+func TestRewardEventWithMissingBeneficiary(t *testing.T) {
+	ctx, am, _, pm, gm, dm, vm, rm := setupTest(t, 1)
+	gs := globalModel.NewGlobalStorage(testGlobalKVStoreKey)
+	as := accModel.NewAccountStorage(testAccountKVStoreKey)
+
+	user, postID := createTestPost(t, ctx, "user", "postID", am, pm, "0")
+	coauthor := createTestAccount(t, ctx, am, "coauthor")
+	consumer := createTestAccount(t, ctx, am, "consumer")
+	err := pm.SetPostBeneficiaries(ctx, types.GetPermlink(user, postID), []postModel.Beneficiary{
+		{Username: user, Weight: sdk.NewRat(3, 5)},
+		{Username: coauthor, Weight: sdk.NewRat(2, 5)},
+	})
+	assert.Nil(t, err)
+	gs.SetConsumptionMeta(ctx, &globalModel.ConsumptionMeta{
+		ConsumptionRewardPool: types.NewCoinFromInt64(100),
+		ConsumptionWindow:     types.NewCoinFromInt64(100),
+	})
+	as.SetReward(ctx, user, &accModel.Reward{})
+	as.DeleteAccount(ctx, coauthor)
+
+	// share of the missing coauthor goes to the author instead of failing the event
+	rewardEvent := RewardEvent{
+		PostAuthor: user,
+		PostID:     postID,
+		Consumer:   consumer,
+		Evaluate:   types.NewCoinFromInt64(100),
+		Original:   types.NewCoinFromInt64(100),
+		Friction:   types.NewCoinFromInt64(15),
+	}
+	err = rewardEvent.Execute(ctx, pm, am, gm, dm, vm, rm)
+	assert.Nil(t, err)
+
+	reward, err := as.GetReward(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, accModel.Reward{
+		TotalIncome:     types.NewCoinFromInt64(50),
+		OriginalIncome:  types.NewCoinFromInt64(15),
+		FrictionIncome:  types.NewCoinFromInt64(15),
+		InflationIncome: types.NewCoinFromInt64(50),
+		UnclaimReward:   types.NewCoinFromInt64(50),
+	}, *reward)
+	votingPower, err := vm.GetVotingPower(ctx, user)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(50), votingPower)
+	assert.False(t, am.DoesAccountExist(ctx, coauthor))
+}
+
+func TestCancelPendingRewardEvents(t *testing.T) {
+	ctx, am, ph, pm, gm, dm, _, rm := setupTest(t, 1)
+	handler := NewHandler(pm, am, gm, dm, rm)
+	accParam, err := ph.GetAccountParam(ctx)
+	assert.Nil(t, err)
+
+	author1, postID1 := createTestPost(t, ctx, "author1", "postID1", am, pm, "0")
+	author2, postID2 := createTestPost(t, ctx, "author2", "postID2", am, pm, "0")
+	author3, postID3 := createTestPost(t, ctx, "author3", "postID3", am, pm, "0")
+	permlink1 := types.GetPermlink(author1, postID1)
+	permlink2 := types.GetPermlink(author2, postID2)
+	permlink3 := types.GetPermlink(author3, postID3)
+	author4, postID4 := createTestPost(t, ctx, "author4", "postID4", am, pm, "0")
+	permlink4 := types.GetPermlink(author4, postID4)
+	donator := createTestAccount(t, ctx, am, "donator")
+	err = am.AddSavingCoin(
+		ctx, donator, types.NewCoinFromInt64(100*types.Decimals), referrer, "", types.TransferIn)
+	assert.Nil(t, err)
+	donatorSaving := accParam.RegisterFee.Plus(types.NewCoinFromInt64(100 * types.Decimals))
+
+	for _, postKey := range []struct{ author, postID string }{
+		{string(author1), postID1}, {string(author2), postID2},
+		{string(author3), postID3}, {string(author4), postID4}} {
+		result := handler(ctx, NewDonateMsg(
+			string(donator), types.LNO("20"), postKey.author, postKey.postID, "", ""))
+		assert.Equal(t, sdk.Result{}, result)
+	}
+	// 5% friction of each donation is pending in freezing period
+	donatorSaving = donatorSaving.Minus(types.NewCoinFromInt64(80 * types.Decimals))
+	pendingRewards, err := pm.GetPendingRewards(ctx, permlink1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(pendingRewards))
+	eventTime := pendingRewards[0].EventTime
+	assert.Equal(t, 4, len(gm.GetTimeEventListAtTime(ctx, eventTime).Events))
+
+	testCases := []struct {
+		testName          string
+		permlink          types.Permlink
+		blockTime         int64
+		since             int64
+		wantDonatorSaving types.Coin
+		wantNumOfEvents   int
+	}{
+		{
+			testName:          "cancel reward event in freezing period",
+			permlink:          permlink1,
+			blockTime:         eventTime - 1,
+			since:             eventTime - 1,
+			wantDonatorSaving: donatorSaving.Plus(types.NewCoinFromInt64(1 * types.Decimals)),
+			wantNumOfEvents:   3,
+		},
+		{
+			testName:          "reward event at current block time hasn't been executed",
+			permlink:          permlink2,
+			blockTime:         eventTime,
+			since:             eventTime,
+			wantDonatorSaving: donatorSaving.Plus(types.NewCoinFromInt64(2 * types.Decimals)),
+			wantNumOfEvents:   2,
+		},
+		{
+			testName:          "reward event after freezing period can't be cancelled",
+			permlink:          permlink3,
+			blockTime:         eventTime + 1,
+			since:             eventTime + 1,
+			wantDonatorSaving: donatorSaving.Plus(types.NewCoinFromInt64(2 * types.Decimals)),
+			wantNumOfEvents:   2,
+		},
+		{
+			testName:          "reward event later than censoring event in the same block is cancelled",
+			permlink:          permlink4,
+			blockTime:         eventTime + 1,
+			since:             eventTime,
+			wantDonatorSaving: donatorSaving.Plus(types.NewCoinFromInt64(3 * types.Decimals)),
+			wantNumOfEvents:   1,
+		},
+	}
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(tc.blockTime, 0)})
+		err := CancelPendingRewardEvents(ctx, tc.permlink, tc.since, pm, am, gm)
+		if err != nil {
+			t.Errorf("%s: failed to cancel pending reward events, got err %v", tc.testName, err)
+		}
+		saving, _ := am.GetSavingFromBank(ctx, donator)
+		if !tc.wantDonatorSaving.IsEqual(saving) {
+			t.Errorf("%s: diff donator saving, got %v, want %v", tc.testName, saving, tc.wantDonatorSaving)
+		}
+		eventList := gm.GetTimeEventListAtTime(ctx, eventTime)
+		if len(eventList.Events) != tc.wantNumOfEvents {
+			t.Errorf("%s: diff num of events, got %v, want %v",
+				tc.testName, len(eventList.Events), tc.wantNumOfEvents)
+		}
+		pendingRewards, err := pm.GetPendingRewards(ctx, tc.permlink)
+		assert.Nil(t, err)
+		if len(pendingRewards) != 0 {
+			t.Errorf("%s: pending rewards should be empty, got %v", tc.testName, pendingRewards)
+		}
+	}
+}
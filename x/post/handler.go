@@ -23,6 +23,8 @@ func NewHandler(
 			return handleCreatePostMsg(ctx, msg, pm, am, gm)
 		case DonateMsg:
 			return handleDonateMsg(ctx, msg, pm, am, gm, dm, rm)
+		case BatchDonateMsg:
+			return handleBatchDonateMsg(ctx, msg, pm, am, gm, dm, rm)
 		case ReportOrUpvoteMsg:
 			return handleReportOrUpvoteMsg(ctx, msg, pm, am, gm, rm)
 		case ViewMsg:
@@ -31,6 +33,10 @@ func NewHandler(
 			return handleUpdatePostMsg(ctx, msg, pm, am)
 		case DeletePostMsg:
 			return handleDeletePostMsg(ctx, msg, pm, am)
+		case UpdateReplyBlocklistMsg:
+			return handleUpdateReplyBlocklistMsg(ctx, msg, pm, am)
+		case UnlockPostMsg:
+			return handleUnlockPostMsg(ctx, msg, pm, am, gm, dm, rm)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized post msg type: %v", reflect.TypeOf(msg).Name())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -63,6 +69,9 @@ func handleCreatePostMsg(ctx sdk.Context, msg CreatePostMsg, pm PostManager, am
 		if !pm.DoesPostExist(ctx, parentPostKey) {
 			return ErrPostNotFound(parentPostKey).Result()
 		}
+		if err := checkReplyPermission(ctx, msg.ParentAuthor, msg.ParentPostID, msg.Author, pm, am); err != nil {
+			return err.Result()
+		}
 		if err := pm.AddComment(ctx, parentPostKey, msg.Author, msg.PostID); err != nil {
 			return err.Result()
 		}
@@ -72,6 +81,19 @@ func handleCreatePostMsg(ctx sdk.Context, msg CreatePostMsg, pm PostManager, am
 	if err != nil {
 		return ErrInvalidPostRedistributionSplitRate().Result()
 	}
+	beneficiaries, err := parseBeneficiaries(msg.Beneficiaries)
+	if err != nil {
+		return err.Result()
+	}
+	for _, beneficiary := range beneficiaries {
+		if !am.DoesAccountExist(ctx, beneficiary.Username) {
+			return ErrAccountNotFound(beneficiary.Username).Result()
+		}
+	}
+	unlockPrice, err := parsePaywall(msg.PaywallType, msg.UnlockPrice)
+	if err != nil {
+		return err.Result()
+	}
 
 	if err := pm.CreatePost(
 		ctx, msg.Author, msg.PostID, msg.SourceAuthor, msg.SourcePostID,
@@ -79,6 +101,29 @@ func handleCreatePostMsg(ctx sdk.Context, msg CreatePostMsg, pm PostManager, am
 		splitRate, msg.Links); err != nil {
 		return err.Result()
 	}
+	if err := pm.SetReplyPermission(ctx, permlink, msg.ReplyPermission); err != nil {
+		return err.Result()
+	}
+	if len(msg.Tags) > 0 {
+		if err := pm.SetPostTags(ctx, permlink, msg.Tags); err != nil {
+			return err.Result()
+		}
+	}
+	if len(beneficiaries) > 0 {
+		if err := pm.SetPostBeneficiaries(ctx, permlink, beneficiaries); err != nil {
+			return err.Result()
+		}
+	}
+	if msg.PaywallType != types.NoPaywall {
+		if err := pm.SetPaywall(ctx, permlink, msg.PaywallType, unlockPrice); err != nil {
+			return err.Result()
+		}
+	}
+	if len(msg.ContentRefs) > 0 {
+		if err := pm.SetPostContentRefs(ctx, permlink, msg.ContentRefs); err != nil {
+			return err.Result()
+		}
+	}
 
 	if err := am.UpdateLastPostAt(ctx, msg.Author); err != nil {
 		return err.Result()
@@ -95,7 +140,21 @@ func handleViewMsg(ctx sdk.Context, msg ViewMsg, pm PostManager, am acc.AccountM
 	if !pm.DoesPostExist(ctx, permlink) {
 		return ErrPostNotFound(permlink).Result()
 	}
-	if err := pm.AddOrUpdateViewToPost(ctx, permlink, msg.Username); err != nil {
+	hasAccess, err := pm.ConsumePostAccess(ctx, permlink, msg.Username)
+	if err != nil {
+		return err.Result()
+	}
+	if !hasAccess {
+		if err := pm.AddOrUpdatePreviewToPost(ctx, permlink, msg.Username); err != nil {
+			return err.Result()
+		}
+		return sdk.Result{}
+	}
+	pastDay, err := gm.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
+	if err != nil {
+		return err.Result()
+	}
+	if err := pm.AddOrUpdateViewToPost(ctx, permlink, msg.Username, pastDay); err != nil {
 		return err.Result()
 	}
 
@@ -114,65 +173,175 @@ func handleDonateMsg(
 	if !am.DoesAccountExist(ctx, msg.Username) {
 		return ErrAccountNotFound(msg.Username).Result()
 	}
+	if err := checkDonationTarget(ctx, msg.Username, msg.Author, msg.PostID, pm); err != nil {
+		return err.Result()
+	}
+	if msg.FromApp != "" {
+		if !dm.DoesDeveloperExist(ctx, msg.FromApp) {
+			return ErrDeveloperNotFound(msg.FromApp).Result()
+		}
+	}
+
+	if err := processDonation(
+		ctx, msg.Username, coin, msg.Author, msg.PostID, msg.FromApp,
+		fmt.Sprintf("donate to post: %v, memo: %v", string(permlink), msg.Memo), am, pm, gm, rm); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle BatchDonateMsg, all donations are checked before any coin is moved
+// so an invalid donation fails the whole batch
+func handleBatchDonateMsg(
+	ctx sdk.Context, msg BatchDonateMsg, pm PostManager, am acc.AccountManager,
+	gm global.GlobalManager, dm dev.DeveloperManager, rm rep.ReputationManager) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if msg.FromApp != "" {
+		if !dm.DoesDeveloperExist(ctx, msg.FromApp) {
+			return ErrDeveloperNotFound(msg.FromApp).Result()
+		}
+	}
+	coins := []types.Coin{}
+	totalCoin := types.NewCoinFromInt64(0)
+	for _, donation := range msg.Donations {
+		coin, err := types.LinoToCoin(donation.Amount)
+		if err != nil {
+			return err.Result()
+		}
+		if err := checkDonationTarget(ctx, msg.Username, donation.Author, donation.PostID, pm); err != nil {
+			return err.Result()
+		}
+		coins = append(coins, coin)
+		totalCoin = totalCoin.Plus(coin)
+	}
+	if err := am.CheckSavingCoinEnough(ctx, msg.Username, totalCoin); err != nil {
+		return err.Result()
+	}
+
+	for i, donation := range msg.Donations {
+		permlink := types.GetPermlink(donation.Author, donation.PostID)
+		if err := processDonation(
+			ctx, msg.Username, coins[i], donation.Author, donation.PostID, msg.FromApp,
+			fmt.Sprintf("donate to post: %v, memo: %v", string(permlink), donation.Memo), am, pm, gm, rm); err != nil {
+			return err.Result()
+		}
+	}
+	return sdk.Result{}
+}
+
+// checkDonationTarget - post must exist and not be deleted, user can't donate to self
+func checkDonationTarget(
+	ctx sdk.Context, username, author types.AccountKey, postID string, pm PostManager) sdk.Error {
+	permlink := types.GetPermlink(author, postID)
+	if !pm.DoesPostExist(ctx, permlink) {
+		return ErrPostNotFound(permlink)
+	}
+	if isDeleted, err := pm.IsDeleted(ctx, permlink); isDeleted || err != nil {
+		return ErrDonatePostIsDeleted(permlink)
+	}
+	if username == author {
+		return ErrCannotDonateToSelf(username)
+	}
+	return nil
+}
+
+// Handle UnlockPostMsg
+func handleUnlockPostMsg(
+	ctx sdk.Context, msg UnlockPostMsg, pm PostManager, am acc.AccountManager,
+	gm global.GlobalManager, dm dev.DeveloperManager, rm rep.ReputationManager) sdk.Result {
+	permlink := types.GetPermlink(msg.Author, msg.PostID)
+	coin, err := types.LinoToCoin(msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
 	if !pm.DoesPostExist(ctx, permlink) {
 		return ErrPostNotFound(permlink).Result()
 	}
 	if isDeleted, err := pm.IsDeleted(ctx, permlink); isDeleted || err != nil {
 		return ErrDonatePostIsDeleted(permlink).Result()
 	}
-
 	if msg.Username == msg.Author {
-		return ErrCannotDonateToSelf(msg.Username).Result()
+		return ErrCannotUnlockOwnPost().Result()
 	}
 	if msg.FromApp != "" {
 		if !dm.DoesDeveloperExist(ctx, msg.FromApp) {
 			return ErrDeveloperNotFound(msg.FromApp).Result()
 		}
 	}
-
-	coinDayBeforeDonate, err := am.GetCoinDay(ctx, msg.Username)
+	paywall, err := pm.GetPaywall(ctx, permlink)
 	if err != nil {
 		return err.Result()
 	}
+	if paywall.PaywallType == types.NoPaywall {
+		return ErrPostNotPaywalled(permlink).Result()
+	}
+	if !coin.IsEqual(paywall.Price) {
+		return ErrUnlockPriceMismatch(paywall.Price).Result()
+	}
+	if err := pm.UnlockPost(ctx, permlink, msg.Username); err != nil {
+		return err.Result()
+	}
+	if err := processDonation(
+		ctx, msg.Username, coin, msg.Author, msg.PostID, msg.FromApp,
+		fmt.Sprintf("unlock post: %v", string(permlink)), am, pm, gm, rm); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// processDonation - minus coin from consumer and send it to the post, if the post is a repost
+// part of the coin is sent to the source post based on redistribution split rate
+func processDonation(
+	ctx sdk.Context, consumer types.AccountKey, coin types.Coin, author types.AccountKey, postID string,
+	fromApp types.AccountKey, memo string, am acc.AccountManager, pm PostManager,
+	gm global.GlobalManager, rm rep.ReputationManager) sdk.Error {
+	permlink := types.GetPermlink(author, postID)
+	coinDayBeforeDonate, err := am.GetCoinDay(ctx, consumer)
+	if err != nil {
+		return err
+	}
 
 	if err := am.MinusSavingCoinWithFullCoinDay(
-		ctx, msg.Username, coin, msg.Author,
-		fmt.Sprintf("donate to post: %v, memo: %v", string(permlink), msg.Memo),
-		types.DonationOut); err != nil {
-		return err.Result()
+		ctx, consumer, coin, author, memo, types.DonationOut); err != nil {
+		return err
 	}
 
-	coinDayAfterDonate, err := am.GetCoinDay(ctx, msg.Username)
+	coinDayAfterDonate, err := am.GetCoinDay(ctx, consumer)
 	if err != nil {
-		return err.Result()
+		return err
 	}
 
 	totalCoinDayDonated := coinDayBeforeDonate.Minus(coinDayAfterDonate)
 	sourceAuthor, sourcePostID, err := pm.GetSourcePost(ctx, permlink)
 	if err != nil {
-		return err.Result()
+		return err
 	}
 	if sourceAuthor != types.AccountKey("") && sourcePostID != "" {
 		sourcePermlink := types.GetPermlink(sourceAuthor, sourcePostID)
 
 		redistributionSplitRate, err := pm.GetRedistributionSplitRate(ctx, sourcePermlink)
 		if err != nil {
-			return err.Result()
+			return err
 		}
 		sourceIncome := types.RatToCoin(coin.ToRat().Mul(sdk.OneRat().Sub(redistributionSplitRate)))
 		coin = coin.Minus(sourceIncome)
 		sourceCoinDayGained := types.RatToCoin(totalCoinDayDonated.ToRat().Mul(sdk.OneRat().Sub(redistributionSplitRate)))
 		totalCoinDayDonated = totalCoinDayDonated.Minus(sourceCoinDayGained)
 		if err := processDonationFriction(
-			ctx, msg.Username, sourceIncome, sourceCoinDayGained, sourceAuthor, sourcePostID, msg.FromApp, am, pm, gm, rm); err != nil {
-			return ErrProcessSourceDonation(sourcePermlink).Result()
+			ctx, consumer, sourceIncome, sourceCoinDayGained, sourceAuthor, sourcePostID, fromApp, am, pm, gm, rm); err != nil {
+			return ErrProcessSourceDonation(sourcePermlink)
 		}
 	}
 	if err := processDonationFriction(
-		ctx, msg.Username, coin, totalCoinDayDonated, msg.Author, msg.PostID, msg.FromApp, am, pm, gm, rm); err != nil {
-		return ErrProcessDonation(permlink).Result()
+		ctx, consumer, coin, totalCoinDayDonated, author, postID, fromApp, am, pm, gm, rm); err != nil {
+		return ErrProcessDonation(permlink)
 	}
-	return sdk.Result{}
+	return nil
 }
 
 func processDonationFriction(
@@ -209,22 +378,42 @@ func processDonationFriction(
 		Friction:   frictionCoin,
 		FromApp:    fromApp,
 	}
-	if err := gm.AddFrictionAndRegisterContentRewardEvent(
-		ctx, rewardEvent, frictionCoin, evaluateResult); err != nil {
+	eventTime, err := gm.AddFrictionAndRegisterContentRewardEvent(
+		ctx, rewardEvent, frictionCoin, evaluateResult)
+	if err != nil {
+		return err
+	}
+	if err := pm.AddPendingReward(ctx, postKey, eventTime); err != nil {
 		return err
 	}
 
-	directDeposit := coin.Minus(frictionCoin)
-	if err := pm.AddDonation(ctx, postKey, consumer, directDeposit, types.DirectDeposit); err != nil {
+	pastDay, err := gm.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
+	if err != nil {
 		return err
 	}
-	if err := am.AddSavingCoin(
-		ctx, postAuthor, directDeposit, consumer, string(postKey), types.DonationIn); err != nil {
+	directDeposit := coin.Minus(frictionCoin)
+	if err := pm.AddDonation(
+		ctx, postKey, consumer, directDeposit, types.DirectDeposit, pastDay); err != nil {
 		return err
 	}
-	if err := am.AddDirectDeposit(ctx, postAuthor, directDeposit); err != nil {
+	shares, err := pm.GetDonationShares(ctx, postKey, directDeposit)
+	if err != nil {
 		return err
 	}
+	for _, share := range shares {
+		// share of a beneficiary whose account doesn't exist anymore goes to the author
+		receiver := share.Username
+		if !am.DoesAccountExist(ctx, receiver) {
+			receiver = postAuthor
+		}
+		if err := am.AddSavingCoin(
+			ctx, receiver, share.Amount, consumer, string(postKey), types.DonationIn); err != nil {
+			return err
+		}
+		if err := am.AddDirectDeposit(ctx, receiver, share.Amount); err != nil {
+			return err
+		}
+	}
 	if err := gm.AddConsumption(ctx, coin); err != nil {
 		return err
 	}
@@ -274,10 +463,30 @@ func handleReportOrUpvoteMsg(
 	if lastReportOrUpvoteAt+postParam.ReportOrUpvoteIntervalSec > ctx.BlockHeader().Time.Unix() {
 		return ErrReportOrUpvoteTooOften().Result()
 	}
+	reportWeight := types.NewCoinFromInt64(0)
 	if msg.IsReport {
-		if _, err := rm.ReportAt(ctx, msg.Username, permlink); err != nil {
+		sumRepBeforeReport, err := rm.GetSumRep(ctx, permlink)
+		if err != nil {
 			return err.Result()
 		}
+		sumRepAfterReport, err := rm.ReportAt(ctx, msg.Username, permlink)
+		if err != nil {
+			return err.Result()
+		}
+		reportWeight = sumRepBeforeReport.Minus(sumRepAfterReport)
+	}
+	coinDay, err := am.GetCoinDay(ctx, msg.Username)
+	if err != nil {
+		return err.Result()
+	}
+	pastDay, err := gm.GetPastDay(ctx, ctx.BlockHeader().Time.Unix())
+	if err != nil {
+		return err.Result()
+	}
+	if err := pm.AddReportOrUpvote(
+		ctx, permlink, msg.Username, coinDay, msg.IsReport, msg.Reason, msg.Memo,
+		reportWeight, pastDay); err != nil {
+		return err.Result()
 	}
 	if err := pm.UpdateLastActivityAt(ctx, permlink); err != nil {
 		return err.Result()
@@ -306,6 +515,11 @@ func handleUpdatePostMsg(
 		ctx, msg.Author, msg.PostID, msg.Title, msg.Content, msg.Links); err != nil {
 		return err.Result()
 	}
+	if msg.ReplyPermission != nil {
+		if err := pm.SetReplyPermission(ctx, permlink, *msg.ReplyPermission); err != nil {
+			return err.Result()
+		}
+	}
 	return sdk.Result{}
 }
 
@@ -324,3 +538,55 @@ func handleDeletePostMsg(
 	}
 	return sdk.Result{}
 }
+
+func handleUpdateReplyBlocklistMsg(
+	ctx sdk.Context, msg UpdateReplyBlocklistMsg, pm PostManager, am acc.AccountManager) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Author) {
+		return ErrAccountNotFound(msg.Author).Result()
+	}
+	if !am.DoesAccountExist(ctx, msg.Username) {
+		return ErrAccountNotFound(msg.Username).Result()
+	}
+	if msg.PostID != "" {
+		permlink := types.GetPermlink(msg.Author, msg.PostID)
+		if !pm.DoesPostExist(ctx, permlink) {
+			return ErrPostNotFound(permlink).Result()
+		}
+	}
+
+	if !msg.IsBlocked {
+		pm.UnblockReplier(ctx, msg.Author, msg.PostID, msg.Username)
+		return sdk.Result{}
+	}
+	if err := pm.BlockReplier(ctx, msg.Author, msg.PostID, msg.Username); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// checkReplyPermission - author can always reply his own post, others are checked
+// against the reply permission of the post and the blocklist of the author
+func checkReplyPermission(
+	ctx sdk.Context, author types.AccountKey, postID string, replier types.AccountKey,
+	pm PostManager, am acc.AccountManager) sdk.Error {
+	if replier == author {
+		return nil
+	}
+	permlink := types.GetPermlink(author, postID)
+	replyPermission, err := pm.GetReplyPermission(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	switch replyPermission {
+	case types.ReplyFromNobody:
+		return ErrReplyNotAllowed(permlink, replier)
+	case types.ReplyFromFollower:
+		if !am.IsMyFollower(ctx, author, replier) {
+			return ErrReplyNotAllowed(permlink, replier)
+		}
+	}
+	if pm.IsReplyBlocked(ctx, author, postID, replier) {
+		return ErrReplyNotAllowed(permlink, replier)
+	}
+	return nil
+}
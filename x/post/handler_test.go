@@ -119,6 +119,7 @@ func TestHandlerUpdatePost(t *testing.T) {
 			TotalReward:             types.NewCoinFromInt64(0),
 			TotalReportCoinDay:      types.NewCoinFromInt64(0),
 			RedistributionSplitRate: sdk.ZeroRat(),
+			NumOfRevisions:          1,
 		}
 		checkPostKVStore(t, ctx,
 			types.GetPermlink(tc.msg.Author, tc.msg.PostID), postInfo, postMeta)
@@ -1027,3 +1028,438 @@ func TestHandlerView(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlerReplyPermission(t *testing.T) {
+	ctx, am, ph, pm, gm, dm, _, rm := setupTest(t, 1)
+	handler := NewHandler(pm, am, gm, dm, rm)
+	postParam, err := ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+
+	baseTime := time.Now()
+	baseTime1 := baseTime.Add(time.Duration(postParam.PostIntervalSec) * time.Second)
+	baseTime2 := baseTime1.Add(time.Duration(postParam.PostIntervalSec) * time.Second)
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: baseTime})
+	author := createTestAccount(t, ctx, am, "author")
+	follower1 := createTestAccount(t, ctx, am, "follower1")
+	follower2 := createTestAccount(t, ctx, am, "follower2")
+	stranger := createTestAccount(t, ctx, am, "stranger")
+	assert.Nil(t, am.SetFollower(ctx, author, follower1))
+	assert.Nil(t, am.SetFollower(ctx, author, follower2))
+
+	postID := "postID"
+	permlink := types.GetPermlink(author, postID)
+	replyFromNobody := types.ReplyFromNobody
+	result := handler(ctx, CreatePostMsg{
+		Author:                  author,
+		PostID:                  postID,
+		Title:                   "title",
+		Content:                 "content",
+		RedistributionSplitRate: "0",
+		ReplyPermission:         types.ReplyFromFollower,
+	})
+	assert.Equal(t, sdk.Result{}, result)
+	replyPermission, err := pm.GetReplyPermission(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, types.ReplyFromFollower, replyPermission)
+
+	testCases := []struct {
+		testName   string
+		blockTime  time.Time
+		msg        sdk.Msg
+		wantResult sdk.Result
+	}{
+		{
+			testName:   "stranger can't reply followers only post",
+			blockTime:  baseTime,
+			msg:        NewCreatePostMsg("stranger", "comment", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: ErrReplyNotAllowed(permlink, stranger).Result(),
+		},
+		{
+			testName:   "follower replies followers only post",
+			blockTime:  baseTime,
+			msg:        NewCreatePostMsg("follower1", "comment", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "block follower1 from replying the post",
+			blockTime:  baseTime,
+			msg:        NewUpdateReplyBlocklistMsg("author", postID, "follower1", true),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "block follower2 from replying all posts of author",
+			blockTime:  baseTime,
+			msg:        NewUpdateReplyBlocklistMsg("author", "", "follower2", true),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "post blocked follower can't reply",
+			blockTime:  baseTime1,
+			msg:        NewCreatePostMsg("follower1", "comment1", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: ErrReplyNotAllowed(permlink, follower1).Result(),
+		},
+		{
+			testName:   "author blocked follower can't reply",
+			blockTime:  baseTime1,
+			msg:        NewCreatePostMsg("follower2", "comment", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: ErrReplyNotAllowed(permlink, follower2).Result(),
+		},
+		{
+			testName:   "unblock follower1 from replying the post",
+			blockTime:  baseTime1,
+			msg:        NewUpdateReplyBlocklistMsg("author", postID, "follower1", false),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "unblocked follower replies again",
+			blockTime:  baseTime1,
+			msg:        NewCreatePostMsg("follower1", "comment1", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:  "author disables replies",
+			blockTime: baseTime1,
+			msg: UpdatePostMsg{
+				Author:          author,
+				PostID:          postID,
+				Title:           "title",
+				Content:         "content",
+				ReplyPermission: &replyFromNobody,
+			},
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "content only update keeps reply permission",
+			blockTime:  baseTime1,
+			msg:        NewUpdatePostMsg("author", postID, "new title", "new content", nil),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "follower can't reply post disabled replies",
+			blockTime:  baseTime2,
+			msg:        NewCreatePostMsg("follower1", "comment2", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: ErrReplyNotAllowed(permlink, follower1).Result(),
+		},
+		{
+			testName:   "author can always reply own post",
+			blockTime:  baseTime2,
+			msg:        NewCreatePostMsg("author", "comment", "", "content", "author", postID, "", "", "0", nil),
+			wantResult: sdk.Result{},
+		},
+		{
+			testName:   "block user from replying a post doesn't exist",
+			blockTime:  baseTime2,
+			msg:        NewUpdateReplyBlocklistMsg("author", "invalid", "stranger", true),
+			wantResult: ErrPostNotFound(types.GetPermlink(author, "invalid")).Result(),
+		},
+		{
+			testName:   "block user doesn't exist",
+			blockTime:  baseTime2,
+			msg:        NewUpdateReplyBlocklistMsg("author", postID, "invalid", true),
+			wantResult: ErrAccountNotFound(types.AccountKey("invalid")).Result(),
+		},
+	}
+	for _, tc := range testCases {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: tc.blockTime})
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.wantResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantResult)
+		}
+	}
+
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	assert.Nil(t, err)
+	assert.False(t, postMeta.AllowReplies)
+	assert.Equal(t, types.ReplyFromNobody, postMeta.ReplyPermission)
+}
+
+func TestHandlerBeneficiaryDonate(t *testing.T) {
+	ctx, am, ph, pm, gm, dm, _, rm := setupTest(t, 1)
+	handler := NewHandler(pm, am, gm, dm, rm)
+	accParam, err := ph.GetAccountParam(ctx)
+	assert.Nil(t, err)
+	postParam, err := ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+
+	author := createTestAccount(t, ctx, am, "author")
+	coauthor := createTestAccount(t, ctx, am, "coauthor")
+	donator := createTestAccount(t, ctx, am, "donator")
+	err = am.AddSavingCoin(
+		ctx, donator, types.NewCoinFromInt64(100*types.Decimals), referrer, "", types.TransferIn)
+	assert.Nil(t, err)
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(postParam.PostIntervalSec, 0)})
+	msg := CreatePostMsg{
+		Author:                  author,
+		PostID:                  "postID",
+		Title:                   "title",
+		Content:                 "content",
+		RedistributionSplitRate: "0",
+		Beneficiaries: []types.Beneficiary{
+			{Username: author, Weight: "0.6"},
+			{Username: "nobody", Weight: "0.4"},
+		},
+	}
+	result := handler(ctx, msg)
+	assert.Equal(t, ErrAccountNotFound("nobody").Result(), result)
+
+	msg.Beneficiaries[1].Username = coauthor
+	result = handler(ctx, msg)
+	assert.Equal(t, sdk.Result{}, result)
+
+	result = handler(ctx, NewDonateMsg(string(donator), types.LNO("100"), string(author), "postID", "", ""))
+	assert.Equal(t, sdk.Result{}, result)
+
+	// 5% friction is deducted, direct deposit is split by beneficiary weight
+	authorSaving, err := am.GetSavingFromBank(ctx, author)
+	assert.Nil(t, err)
+	assert.Equal(t, accParam.RegisterFee.Plus(types.NewCoinFromInt64(57*types.Decimals)), authorSaving)
+	coauthorSaving, err := am.GetSavingFromBank(ctx, coauthor)
+	assert.Nil(t, err)
+	assert.Equal(t, accParam.RegisterFee.Plus(types.NewCoinFromInt64(38*types.Decimals)), coauthorSaving)
+
+	as := accmodel.NewAccountStorage(testAccountKVStoreKey)
+	coauthorReward, err := as.GetReward(ctx, coauthor)
+	assert.Nil(t, err)
+	assert.Equal(t, types.NewCoinFromInt64(38*types.Decimals), coauthorReward.OriginalIncome)
+
+	// share of a beneficiary whose account doesn't exist goes to the author
+	err = pm.SetPostBeneficiaries(ctx, types.GetPermlink(author, "postID"), []model.Beneficiary{
+		{Username: coauthor, Weight: sdk.NewRat(1, 2)},
+		{Username: "nobody", Weight: sdk.NewRat(1, 2)},
+	})
+	assert.Nil(t, err)
+	err = am.AddSavingCoin(
+		ctx, donator, types.NewCoinFromInt64(10*types.Decimals), referrer, "", types.TransferIn)
+	assert.Nil(t, err)
+	result = handler(ctx, NewDonateMsg(string(donator), types.LNO("10"), string(author), "postID", "", ""))
+	assert.Equal(t, sdk.Result{}, result)
+	authorSaving, err = am.GetSavingFromBank(ctx, author)
+	assert.Nil(t, err)
+	assert.Equal(t, accParam.RegisterFee.Plus(types.NewCoinFromInt64(6175*types.Decimals/100)), authorSaving)
+	coauthorSaving, err = am.GetSavingFromBank(ctx, coauthor)
+	assert.Nil(t, err)
+	assert.Equal(t, accParam.RegisterFee.Plus(types.NewCoinFromInt64(4275*types.Decimals/100)), coauthorSaving)
+}
+
+
+func TestHandlerBatchDonate(t *testing.T) {
+	ctx, am, ph, pm, gm, dm, _, rm := setupTest(t, 1)
+	handler := NewHandler(pm, am, gm, dm, rm)
+	accParam, err := ph.GetAccountParam(ctx)
+	assert.Nil(t, err)
+
+	author1, postID1 := createTestPost(t, ctx, "author1", "postID1", am, pm, "0")
+	author2, postID2 := createTestPost(t, ctx, "author2", "postID2", am, pm, "0")
+	donator, postID3 := createTestPost(t, ctx, "donator", "postID3", am, pm, "0")
+	err = am.AddSavingCoin(
+		ctx, donator, types.NewCoinFromInt64(100*types.Decimals), referrer, "", types.TransferIn)
+	assert.Nil(t, err)
+	donatorSaving := accParam.RegisterFee.Plus(types.NewCoinFromInt64(100 * types.Decimals))
+	invalidPermlink := types.GetPermlink(author2, "invalid")
+
+	testCases := []struct {
+		testName           string
+		donations          []BatchDonation
+		wantResult         sdk.Result
+		wantDonatorSaving  types.Coin
+		wantAuthor1Saving  types.Coin
+		wantAuthor2Saving  types.Coin
+		wantTotalDonations int64
+	}{
+		{
+			testName: "donate to post doesn't exist",
+			donations: []BatchDonation{
+				{Author: author1, PostID: postID1, Amount: types.LNO("10")},
+				{Author: author2, PostID: "invalid", Amount: types.LNO("10")},
+			},
+			wantResult:         ErrPostNotFound(invalidPermlink).Result(),
+			wantDonatorSaving:  donatorSaving,
+			wantAuthor1Saving:  accParam.RegisterFee,
+			wantAuthor2Saving:  accParam.RegisterFee,
+			wantTotalDonations: 0,
+		},
+		{
+			testName: "donate to self",
+			donations: []BatchDonation{
+				{Author: author1, PostID: postID1, Amount: types.LNO("10")},
+				{Author: donator, PostID: postID3, Amount: types.LNO("10")},
+			},
+			wantResult:         ErrCannotDonateToSelf(donator).Result(),
+			wantDonatorSaving:  donatorSaving,
+			wantAuthor1Saving:  accParam.RegisterFee,
+			wantAuthor2Saving:  accParam.RegisterFee,
+			wantTotalDonations: 0,
+		},
+		{
+			testName: "total amount exceeds saving",
+			donations: []BatchDonation{
+				{Author: author1, PostID: postID1, Amount: types.LNO("60")},
+				{Author: author2, PostID: postID2, Amount: types.LNO("60")},
+			},
+			wantResult:         acc.ErrAccountSavingCoinNotEnough().Result(),
+			wantDonatorSaving:  donatorSaving,
+			wantAuthor1Saving:  accParam.RegisterFee,
+			wantAuthor2Saving:  accParam.RegisterFee,
+			wantTotalDonations: 0,
+		},
+		{
+			testName: "donate to two posts",
+			donations: []BatchDonation{
+				{Author: author1, PostID: postID1, Amount: types.LNO("20")},
+				{Author: author2, PostID: postID2, Amount: types.LNO("40"), Memo: "memo"},
+			},
+			wantResult:         sdk.Result{},
+			wantDonatorSaving:  donatorSaving.Minus(types.NewCoinFromInt64(60 * types.Decimals)),
+			wantAuthor1Saving:  accParam.RegisterFee.Plus(types.NewCoinFromInt64(19 * types.Decimals)),
+			wantAuthor2Saving:  accParam.RegisterFee.Plus(types.NewCoinFromInt64(38 * types.Decimals)),
+			wantTotalDonations: 1,
+		},
+	}
+	for _, tc := range testCases {
+		result := handler(ctx, NewBatchDonateMsg(string(donator), "", tc.donations))
+		if !assert.Equal(t, tc.wantResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantResult)
+		}
+		for user, wantSaving := range map[types.AccountKey]types.Coin{
+			donator: tc.wantDonatorSaving, author1: tc.wantAuthor1Saving, author2: tc.wantAuthor2Saving} {
+			saving, _ := am.GetSavingFromBank(ctx, user)
+			if !wantSaving.IsEqual(saving) {
+				t.Errorf("%s: diff saving of %v, got %v, want %v", tc.testName, user, saving, wantSaving)
+			}
+		}
+		for _, permlink := range []types.Permlink{
+			types.GetPermlink(author1, postID1), types.GetPermlink(author2, postID2)} {
+			postMeta, _ := pm.postStorage.GetPostMeta(ctx, permlink)
+			if postMeta.TotalDonateCount != tc.wantTotalDonations {
+				t.Errorf("%s: diff donate count of %v, got %v, want %v",
+					tc.testName, permlink, postMeta.TotalDonateCount, tc.wantTotalDonations)
+			}
+		}
+	}
+}
+
+func TestHandlerUnlockPost(t *testing.T) {
+	ctx, am, ph, pm, gm, dm, _, rm := setupTest(t, 1)
+	handler := NewHandler(pm, am, gm, dm, rm)
+	postParam, err := ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+
+	author := createTestAccount(t, ctx, am, "author")
+	user := createTestAccount(t, ctx, am, "user")
+	err = am.AddSavingCoin(
+		ctx, user, types.NewCoinFromInt64(100*types.Decimals), referrer, "", types.TransferIn)
+	assert.Nil(t, err)
+	freeAuthor, freePostID := createTestPost(t, ctx, "freeAuthor", "free", am, pm, "0")
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(postParam.PostIntervalSec, 0)})
+	permlink := types.GetPermlink(author, "paid")
+	result := handler(ctx, CreatePostMsg{
+		Author:                  author,
+		PostID:                  "paid",
+		Title:                   "title",
+		Content:                 "content",
+		RedistributionSplitRate: "0",
+		PaywallType:             types.PayPerView,
+		UnlockPrice:             types.LNO("10"),
+	})
+	assert.Equal(t, sdk.Result{}, result)
+
+	testCases := []struct {
+		testName         string
+		msg              sdk.Msg
+		wantResult       sdk.Result
+		wantAccess       bool
+		wantViewCount    int64
+		wantPreviewCount int64
+		wantAuthorSaving types.Coin
+	}{
+		{
+			testName:         "view before unlock is preview",
+			msg:              NewViewMsg(string(user), string(author), "paid"),
+			wantResult:       sdk.Result{},
+			wantAccess:       false,
+			wantViewCount:    0,
+			wantPreviewCount: 1,
+			wantAuthorSaving: initCoin,
+		},
+		{
+			testName:         "unlock with wrong amount",
+			msg:              NewUnlockPostMsg(string(user), types.LNO("5"), string(author), "paid", ""),
+			wantResult:       ErrUnlockPriceMismatch(types.NewCoinFromInt64(10 * types.Decimals)).Result(),
+			wantAccess:       false,
+			wantViewCount:    0,
+			wantPreviewCount: 1,
+			wantAuthorSaving: initCoin,
+		},
+		{
+			testName:         "unlock free post",
+			msg:              NewUnlockPostMsg(string(user), types.LNO("10"), string(freeAuthor), freePostID, ""),
+			wantResult:       ErrPostNotPaywalled(types.GetPermlink(freeAuthor, freePostID)).Result(),
+			wantAccess:       false,
+			wantViewCount:    0,
+			wantPreviewCount: 1,
+			wantAuthorSaving: initCoin,
+		},
+		{
+			testName:         "unlock pay per view post",
+			msg:              NewUnlockPostMsg(string(user), types.LNO("10"), string(author), "paid", ""),
+			wantResult:       sdk.Result{},
+			wantAccess:       true,
+			wantViewCount:    0,
+			wantPreviewCount: 1,
+			wantAuthorSaving: initCoin.Plus(types.NewCoinFromInt64(95 * types.Decimals / 10)),
+		},
+		{
+			testName:         "view after unlock uses the paid view",
+			msg:              NewViewMsg(string(user), string(author), "paid"),
+			wantResult:       sdk.Result{},
+			wantAccess:       false,
+			wantViewCount:    1,
+			wantPreviewCount: 1,
+			wantAuthorSaving: initCoin.Plus(types.NewCoinFromInt64(95 * types.Decimals / 10)),
+		},
+		{
+			testName:         "view again is preview",
+			msg:              NewViewMsg(string(user), string(author), "paid"),
+			wantResult:       sdk.Result{},
+			wantAccess:       false,
+			wantViewCount:    1,
+			wantPreviewCount: 2,
+			wantAuthorSaving: initCoin.Plus(types.NewCoinFromInt64(95 * types.Decimals / 10)),
+		},
+	}
+	for _, tc := range testCases {
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.wantResult, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantResult)
+		}
+		access, err := pm.GetPostAccess(ctx, permlink, user)
+		if err != nil {
+			t.Errorf("%s: failed to get post access, got err %v", tc.testName, err)
+		}
+		if access.HasAccess != tc.wantAccess {
+			t.Errorf("%s: diff access, got %v, want %v", tc.testName, access.HasAccess, tc.wantAccess)
+		}
+		postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+		if err != nil {
+			t.Errorf("%s: failed to get post meta, got err %v", tc.testName, err)
+		}
+		if postMeta.TotalViewCount != tc.wantViewCount || postMeta.TotalPreviewCount != tc.wantPreviewCount {
+			t.Errorf("%s: diff view count, got %v and %v, want %v and %v", tc.testName,
+				postMeta.TotalViewCount, postMeta.TotalPreviewCount, tc.wantViewCount, tc.wantPreviewCount)
+		}
+		saving, err := am.GetSavingFromBank(ctx, author)
+		if err != nil {
+			t.Errorf("%s: failed to get saving, got err %v", tc.testName, err)
+		}
+		if !saving.IsEqual(tc.wantAuthorSaving) {
+			t.Errorf("%s: diff author saving, got %v, want %v", tc.testName, saving, tc.wantAuthorSaving)
+		}
+	}
+
+	// author always has access without unlock
+	access, err := pm.GetPostAccess(ctx, permlink, author)
+	assert.Nil(t, err)
+	assert.True(t, access.HasAccess)
+}
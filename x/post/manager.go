@@ -1,6 +1,10 @@
 package post
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
 	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/post/model"
@@ -13,6 +17,12 @@ type PostManager struct {
 	paramHolder param.ParamHolder
 }
 
+// DonationShare - part of a donation or reward belongs to a beneficiary of the post
+type DonationShare struct {
+	Username types.AccountKey `json:"username"`
+	Amount   types.Coin       `json:"amount"`
+}
+
 // NewPostManager - create a new post manager
 func NewPostManager(key sdk.StoreKey, holder param.ParamHolder) PostManager {
 	return PostManager{
@@ -110,6 +120,7 @@ func (pm PostManager) CreatePost(
 		LastUpdatedAt:           ctx.BlockHeader().Time.Unix(),
 		LastActivityAt:          ctx.BlockHeader().Time.Unix(),
 		AllowReplies:            true, // Default
+		ReplyPermission:         types.ReplyFromAll,
 		IsDeleted:               false,
 		RedistributionSplitRate: redistributionSplitRate.Round(types.PrecisionFactor),
 	}
@@ -119,7 +130,8 @@ func (pm PostManager) CreatePost(
 	return nil
 }
 
-// UpdatePost - update post title, content and links. Can't update a deleted post
+// UpdatePost - update post title, content and links, previous version is kept as
+// a revision. Can't update a deleted post
 func (pm PostManager) UpdatePost(
 	ctx sdk.Context, author types.AccountKey, postID, title, content string,
 	links []types.IDToURLMapping) sdk.Error {
@@ -132,6 +144,9 @@ func (pm PostManager) UpdatePost(
 	if err != nil {
 		return err
 	}
+	if err := pm.addPostRevision(ctx, permlink, postInfo, postMeta); err != nil {
+		return err
+	}
 
 	postInfo.Title = title
 	postInfo.Content = content
@@ -148,9 +163,68 @@ func (pm PostManager) UpdatePost(
 	return nil
 }
 
-// AddOrUpdateViewToPost - add or update view from the user if view exists
+// addPostRevision - keep current title, content and links as a new revision and
+// remove the oldest revisions exceed the limitation in post param
+func (pm PostManager) addPostRevision(
+	ctx sdk.Context, permlink types.Permlink, postInfo *model.PostInfo, postMeta *model.PostMeta) sdk.Error {
+	postParam, err := pm.paramHolder.GetPostParam(ctx)
+	if err != nil {
+		return err
+	}
+	postMeta.NumOfRevisions++
+	if postParam.MaxNumOfRevisions > 0 {
+		postRevision := &model.PostRevision{
+			Revision:    postMeta.NumOfRevisions,
+			Title:       postInfo.Title,
+			Content:     postInfo.Content,
+			Links:       postInfo.Links,
+			ContentHash: GetContentHash(postInfo.Content),
+			CreatedAt:   postMeta.LastUpdatedAt,
+			ReplacedAt:  ctx.BlockHeader().Time.Unix(),
+		}
+		if err := pm.postStorage.SetPostRevision(ctx, permlink, postRevision); err != nil {
+			return err
+		}
+	}
+	return pm.pruneRevisions(ctx, permlink, postMeta.NumOfRevisions-postParam.MaxNumOfRevisions)
+}
+
+// pruneRevisions - remove all revisions not newer than the given revision
+func (pm PostManager) pruneRevisions(ctx sdk.Context, permlink types.Permlink, revision int64) sdk.Error {
+	revisions, err := pm.postStorage.GetPostRevisions(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	for _, postRevision := range revisions {
+		if postRevision.Revision > revision {
+			break
+		}
+		pm.postStorage.DeletePostRevision(ctx, permlink, postRevision.Revision)
+	}
+	return nil
+}
+
+// GetPostRevisions - get all stored revisions of the post, oldest first
+func (pm PostManager) GetPostRevisions(ctx sdk.Context, permlink types.Permlink) ([]model.PostRevision, sdk.Error) {
+	return pm.postStorage.GetPostRevisions(ctx, permlink)
+}
+
+// GetPostRevision - get a specific revision of the post
+func (pm PostManager) GetPostRevision(
+	ctx sdk.Context, permlink types.Permlink, revision int64) (*model.PostRevision, sdk.Error) {
+	return pm.postStorage.GetPostRevision(ctx, permlink, revision)
+}
+
+// GetContentHash - hex encoded sha256 of post content
+func GetContentHash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+// AddOrUpdateViewToPost - add or update view from the user if view exists,
+// past day is the day since chain start the view is counted to
 func (pm PostManager) AddOrUpdateViewToPost(
-	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) sdk.Error {
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey, pastDay int64) sdk.Error {
 	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
 	if err != nil {
 		return err
@@ -169,9 +243,213 @@ func (pm PostManager) AddOrUpdateViewToPost(
 	if err := pm.postStorage.SetPostMeta(ctx, permlink, postMeta); err != nil {
 		return err
 	}
+	return pm.addPostStat(ctx, permlink, pastDay, func(stat *model.PostStat) {
+		stat.ViewCount++
+	})
+}
+
+// AddOrUpdatePreviewToPost - add or update preview from the user who has no access to paywalled post
+func (pm PostManager) AddOrUpdatePreviewToPost(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) sdk.Error {
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	view, _ := pm.postStorage.GetPostView(ctx, permlink, user)
+	if view == nil {
+		view = &model.View{Username: user}
+	}
+	postMeta.TotalPreviewCount++
+	view.PreviewTimes++
+	view.LastViewAt = ctx.BlockHeader().Time.Unix()
+	if err := pm.postStorage.SetPostView(ctx, permlink, view); err != nil {
+		return err
+	}
+	if err := pm.postStorage.SetPostMeta(ctx, permlink, postMeta); err != nil {
+		return err
+	}
 	return nil
 }
 
+// SetPaywall - set paywall type and unlock price of the post
+func (pm PostManager) SetPaywall(
+	ctx sdk.Context, permlink types.Permlink, paywallType types.PaywallType, price types.Coin) sdk.Error {
+	paywall := &model.Paywall{
+		PaywallType: paywallType,
+		Price:       price,
+	}
+	return pm.postStorage.SetPostPaywall(ctx, permlink, paywall)
+}
+
+// GetPaywall - get paywall of the post, post without paywall is free
+func (pm PostManager) GetPaywall(ctx sdk.Context, permlink types.Permlink) (*model.Paywall, sdk.Error) {
+	if !pm.postStorage.DoesPostPaywallExist(ctx, permlink) {
+		return &model.Paywall{PaywallType: types.NoPaywall, Price: types.NewCoinFromInt64(0)}, nil
+	}
+	return pm.postStorage.GetPostPaywall(ctx, permlink)
+}
+
+// UnlockPost - record the user paid the unlock price, one time unlock post
+// can only be unlocked once and pay per view post gets one more view
+func (pm PostManager) UnlockPost(ctx sdk.Context, permlink types.Permlink, user types.AccountKey) sdk.Error {
+	paywall, err := pm.GetPaywall(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	if paywall.PaywallType == types.NoPaywall {
+		return ErrPostNotPaywalled(permlink)
+	}
+	postUnlock, _ := pm.postStorage.GetPostUnlock(ctx, permlink, user)
+	if postUnlock == nil {
+		postUnlock = &model.PostUnlock{Username: user}
+	} else if paywall.PaywallType == types.OneTimeUnlock {
+		return ErrPostAlreadyUnlocked(permlink, user)
+	}
+	postUnlock.UnlockedAt = ctx.BlockHeader().Time.Unix()
+	postUnlock.Times++
+	if paywall.PaywallType == types.PayPerView {
+		postUnlock.RemainingViews++
+	}
+	return pm.postStorage.SetPostUnlock(ctx, permlink, postUnlock)
+}
+
+// GetPostAccess - get access of the user to the post
+func (pm PostManager) GetPostAccess(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) (*PostAccess, sdk.Error) {
+	paywall, err := pm.GetPaywall(ctx, permlink)
+	if err != nil {
+		return nil, err
+	}
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return nil, err
+	}
+	postUnlock, _ := pm.postStorage.GetPostUnlock(ctx, permlink, user)
+	return NewPostAccess(postInfo.Author, paywall, postUnlock, user), nil
+}
+
+// ConsumePostAccess - check if the user can view the post and use one
+// remaining view if the user views pay per view post with unlock
+func (pm PostManager) ConsumePostAccess(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) (bool, sdk.Error) {
+	access, err := pm.GetPostAccess(ctx, permlink, user)
+	if err != nil {
+		return false, err
+	}
+	if !access.HasAccess || access.RemainingViews == 0 {
+		return access.HasAccess, nil
+	}
+	postUnlock, err := pm.postStorage.GetPostUnlock(ctx, permlink, user)
+	if err != nil {
+		return false, err
+	}
+	postUnlock.RemainingViews--
+	if err := pm.postStorage.SetPostUnlock(ctx, permlink, postUnlock); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddReportOrUpvote - record the latest report or upvote from the user and update report stat
+// of the post. Each reporter is counted once with the latest reason, report weight is the change
+// of post reputation sum caused by the report so it's not reverted by a later upvote, a repeat
+// report replaces the user's previous report weight instead of adding to it
+func (pm PostManager) AddReportOrUpvote(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey, coinDay types.Coin,
+	isReport bool, reason types.ReportReason, memo string, reportWeight types.Coin, pastDay int64) sdk.Error {
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	prev, _ := pm.postStorage.GetPostReportOrUpvote(ctx, permlink, user)
+	prevWeight := types.NewCoinFromInt64(0)
+	if prev != nil && prev.ReportWeight != (types.Coin{}) {
+		prevWeight = prev.ReportWeight
+	}
+	userWeight := prevWeight
+	if isReport {
+		userWeight = reportWeight
+	}
+	reportOrUpvote := &model.ReportOrUpvote{
+		Username:     user,
+		CoinDay:      coinDay,
+		CreatedAt:    ctx.BlockHeader().Time.Unix(),
+		IsReport:     isReport,
+		Reason:       reason,
+		Memo:         memo,
+		ReportWeight: userWeight,
+	}
+	if err := pm.postStorage.SetPostReportOrUpvote(ctx, permlink, reportOrUpvote); err != nil {
+		return err
+	}
+	isHidden := postMeta.IsDeleted || postMeta.IsCensored
+	if !isReport && !isHidden {
+		if err := pm.addPostStat(ctx, permlink, pastDay, func(stat *model.PostStat) {
+			stat.UpvoteCoinDay = stat.UpvoteCoinDay.Plus(coinDay)
+		}); err != nil {
+			return err
+		}
+	}
+	prevReported := prev != nil && prev.IsReport
+	if isHidden || (!isReport && !prevReported) {
+		return nil
+	}
+
+	stat, _ := pm.postStorage.GetPostReportStat(ctx, permlink)
+	if stat == nil {
+		stat = &model.PostReportStat{
+			Permlink:     permlink,
+			ReportWeight: types.NewCoinFromInt64(0),
+			ReasonCounts: []model.ReportReasonCount{},
+		}
+	}
+	if prevReported {
+		stat.ReportCount--
+		stat.ReasonCounts = addReportReasonCount(stat.ReasonCounts, prev.Reason, -1)
+	}
+	if isReport {
+		stat.ReportCount++
+		stat.ReasonCounts = addReportReasonCount(stat.ReasonCounts, reason, 1)
+		stat.ReportWeight = stat.ReportWeight.Minus(prevWeight).Plus(reportWeight)
+		stat.LastReportAt = ctx.BlockHeader().Time.Unix()
+	}
+	return pm.postStorage.SetPostReportStat(ctx, stat)
+}
+
+func addReportReasonCount(
+	reasonCounts []model.ReportReasonCount, reason types.ReportReason, delta int64) []model.ReportReasonCount {
+	res := []model.ReportReasonCount{}
+	found := false
+	for _, reasonCount := range reasonCounts {
+		if reasonCount.Reason == reason {
+			reasonCount.Count += delta
+			found = true
+		}
+		if reasonCount.Count > 0 {
+			res = append(res, reasonCount)
+		}
+	}
+	if !found && delta > 0 {
+		res = append(res, model.ReportReasonCount{Reason: reason, Count: delta})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Reason < res[j].Reason })
+	return res
+}
+
+// GetReportStat - get report stat of the post
+func (pm PostManager) GetReportStat(ctx sdk.Context, permlink types.Permlink) (*model.PostReportStat, sdk.Error) {
+	return pm.postStorage.GetPostReportStat(ctx, permlink)
+}
+
+// GetReportQueue - get most reported posts ordered by report weight
+func (pm PostManager) GetReportQueue(ctx sdk.Context, limit int) ([]model.PostReportStat, sdk.Error) {
+	stats, err := pm.postStorage.GetPostReportStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return GetReportQueue(stats, limit), nil
+}
+
 // add comment to post comment list
 func (pm PostManager) AddComment(
 	ctx sdk.Context, permlink types.Permlink, commentAuthor types.AccountKey, commentPostID string) sdk.Error {
@@ -194,10 +472,11 @@ func (pm PostManager) AddComment(
 	return nil
 }
 
-// AddDonation - add donation to post donation list
+// AddDonation - add donation to post donation list,
+// past day is the day since chain start the donation is counted to in post stats
 func (pm PostManager) AddDonation(
 	ctx sdk.Context, permlink types.Permlink, donator types.AccountKey,
-	amount types.Coin, donationType types.DonationType) sdk.Error {
+	amount types.Coin, donationType types.DonationType, pastDay int64) sdk.Error {
 	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
 	if err != nil {
 		return err
@@ -211,6 +490,19 @@ func (pm PostManager) AddDonation(
 	if err := pm.postStorage.SetPostDonations(ctx, permlink, donations); err != nil {
 		return err
 	}
+	if err := pm.addTagDonationStats(ctx, permlink, amount); err != nil {
+		return err
+	}
+	if err := pm.addPostStat(ctx, permlink, pastDay, func(stat *model.PostStat) {
+		if donationType == types.Inflation {
+			stat.Reward = stat.Reward.Plus(amount)
+			return
+		}
+		stat.DonateCount++
+		stat.Donation = stat.Donation.Plus(amount)
+	}); err != nil {
+		return err
+	}
 	postMeta.TotalReward = postMeta.TotalReward.Plus(amount)
 	postMeta.TotalDonateCount = postMeta.TotalDonateCount + 1
 	postMeta.LastActivityAt = ctx.BlockHeader().Time.Unix()
@@ -220,13 +512,102 @@ func (pm PostManager) AddDonation(
 	return nil
 }
 
-// DeletePost - delete post by author or content censorship
+// AddPendingReward - record a content reward event of the post registered at event time,
+// donations in the same block share one record since their events are registered at the same time
+func (pm PostManager) AddPendingReward(
+	ctx sdk.Context, permlink types.Permlink, eventTime int64) sdk.Error {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	users := []types.AccountKey{postInfo.Author}
+	for _, beneficiary := range postInfo.Beneficiaries {
+		users = append(users, beneficiary.Username)
+	}
+	return pm.postStorage.SetPendingReward(ctx, permlink, &model.PendingReward{
+		EventTime: eventTime,
+		DonatedAt: ctx.BlockHeader().Time.Unix(),
+		Users:     users,
+	})
+}
+
+// GetPendingRewards - get content reward events of the post haven't been executed, oldest first.
+// Time events are executed in the first block later than event time, so the event registered
+// at current block time is still pending
+func (pm PostManager) GetPendingRewards(
+	ctx sdk.Context, permlink types.Permlink) ([]model.PendingReward, sdk.Error) {
+	return pm.GetPendingRewardsSince(ctx, permlink, ctx.BlockHeader().Time.Unix())
+}
+
+// GetPendingRewardsSince - get content reward events of the post registered at or after since,
+// oldest first. Events of the current block are executed in time order, events later than the
+// executing event haven't been executed even if they are earlier than current block time
+func (pm PostManager) GetPendingRewardsSince(
+	ctx sdk.Context, permlink types.Permlink, since int64) ([]model.PendingReward, sdk.Error) {
+	pendingRewards, err := pm.postStorage.GetPendingRewards(ctx, permlink)
+	if err != nil {
+		return nil, err
+	}
+	res := []model.PendingReward{}
+	for _, pendingReward := range pendingRewards {
+		if pendingReward.EventTime >= since {
+			res = append(res, pendingReward)
+		}
+	}
+	return res, nil
+}
+
+// HasPendingRewards - returns true if user is author or beneficiary of a post
+// with content reward events haven't been executed
+func (pm PostManager) HasPendingRewards(ctx sdk.Context, username types.AccountKey) (bool, sdk.Error) {
+	hasPendingRewards := false
+	if err := pm.postStorage.IterateUserPendingRewards(
+		ctx, username, func(pendingReward model.PendingReward) bool {
+			// event registered at current block time is still pending
+			hasPendingRewards = pendingReward.EventTime >= ctx.BlockHeader().Time.Unix()
+			return hasPendingRewards
+		}); err != nil {
+		return false, err
+	}
+	return hasPendingRewards, nil
+}
+
+// DeletePendingReward - remove the pending reward record of the post at event time
+func (pm PostManager) DeletePendingReward(ctx sdk.Context, permlink types.Permlink, eventTime int64) {
+	pm.postStorage.DeletePendingReward(ctx, permlink, eventTime)
+}
+
+// PrunePendingRewards - remove all pending reward records earlier than current time
+func (pm PostManager) PrunePendingRewards(ctx sdk.Context, permlink types.Permlink) sdk.Error {
+	pendingRewards, err := pm.postStorage.GetPendingRewards(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	for _, pendingReward := range pendingRewards {
+		if pendingReward.EventTime >= ctx.BlockHeader().Time.Unix() {
+			break
+		}
+		pm.postStorage.DeletePendingReward(ctx, permlink, pendingReward.EventTime)
+	}
+	return nil
+}
+
+// DeletePost - delete post by author, revisions are removed as well
 func (pm PostManager) DeletePost(ctx sdk.Context, permlink types.Permlink) sdk.Error {
 	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
 	if err != nil {
 		return err
 	}
 	postMeta.IsDeleted = true
+	return pm.hidePost(ctx, permlink, postMeta)
+}
+
+// hidePost - clear content of a post deleted by author or censored and stop its redistribution.
+// Revision history is dropped in both cases, otherwise censored content stays readable from old revisions.
+func (pm PostManager) hidePost(ctx sdk.Context, permlink types.Permlink, postMeta *model.PostMeta) sdk.Error {
+	if err := pm.pruneRevisions(ctx, permlink, postMeta.NumOfRevisions); err != nil {
+		return err
+	}
 	postMeta.RedistributionSplitRate = sdk.OneRat()
 	postMeta.LastUpdatedAt = ctx.BlockHeader().Time.Unix()
 	if err := pm.postStorage.SetPostMeta(ctx, permlink, postMeta); err != nil {
@@ -236,9 +617,13 @@ func (pm PostManager) DeletePost(ctx sdk.Context, permlink types.Permlink) sdk.E
 	if err != nil {
 		return err
 	}
+	pm.removeFromTagIndex(ctx, permlink, postInfo.Tags, postMeta.CreatedAt)
+	pm.postStorage.DeletePostReportStat(ctx, permlink)
 	postInfo.Title = ""
 	postInfo.Content = ""
 	postInfo.Links = nil
+	postInfo.Tags = nil
+	postInfo.ContentRefs = nil
 
 	if err := pm.postStorage.SetPostInfo(ctx, postInfo); err != nil {
 		return err
@@ -246,8 +631,107 @@ func (pm PostManager) DeletePost(ctx sdk.Context, permlink types.Permlink) sdk.E
 	return nil
 }
 
-// IsDeleted - check if a post is deleted or not
+// CensorPost - keep post content in tombstone, record censorship in post meta and hide the post,
+// a post deleted by author can't be censored
+func (pm PostManager) CensorPost(
+	ctx sdk.Context, permlink types.Permlink, proposalID types.ProposalKey, reason string) sdk.Error {
+	// post has been censored by other proposal
+	if pm.postStorage.DoesCensoredPostExist(ctx, permlink) {
+		return nil
+	}
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	if postMeta.IsDeleted {
+		return ErrPostDeletedByAuthor(permlink)
+	}
+	censoredPost := &model.CensoredPost{
+		PostInfo:                *postInfo,
+		RedistributionSplitRate: postMeta.RedistributionSplitRate,
+		ProposalID:              proposalID,
+		CensoredAt:              ctx.BlockHeader().Time.Unix(),
+	}
+	if err := pm.postStorage.SetCensoredPost(ctx, permlink, censoredPost); err != nil {
+		return err
+	}
+	postMeta.CensorshipHistory = append(postMeta.CensorshipHistory, model.CensorshipRecord{
+		ProposalID: proposalID,
+		Reason:     reason,
+		CensoredAt: ctx.BlockHeader().Time.Unix(),
+	})
+	postMeta.IsCensored = true
+	return pm.hidePost(ctx, permlink, postMeta)
+}
+
+// RestorePost - restore censored post content from tombstone, revisions dropped by
+// censorship are not restored. A post deleted by author after censorship can't be restored
+func (pm PostManager) RestorePost(
+	ctx sdk.Context, permlink types.Permlink, proposalID types.ProposalKey) sdk.Error {
+	censoredPost, err := pm.postStorage.GetCensoredPost(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	if postMeta.IsDeleted {
+		return ErrPostDeletedByAuthor(permlink)
+	}
+	if err := pm.postStorage.SetPostInfo(ctx, &censoredPost.PostInfo); err != nil {
+		return err
+	}
+	if err := pm.addToTagIndex(ctx, permlink, censoredPost.PostInfo.Tags, postMeta.CreatedAt); err != nil {
+		return err
+	}
+	postMeta.IsCensored = false
+	postMeta.RedistributionSplitRate = censoredPost.RedistributionSplitRate
+	postMeta.LastUpdatedAt = ctx.BlockHeader().Time.Unix()
+	for i := len(postMeta.CensorshipHistory) - 1; i >= 0; i-- {
+		if postMeta.CensorshipHistory[i].ProposalID == censoredPost.ProposalID {
+			postMeta.CensorshipHistory[i].RestoreProposalID = proposalID
+			postMeta.CensorshipHistory[i].RestoredAt = ctx.BlockHeader().Time.Unix()
+			break
+		}
+	}
+	if err := pm.postStorage.SetPostMeta(ctx, permlink, postMeta); err != nil {
+		return err
+	}
+	pm.postStorage.DeleteCensoredPost(ctx, permlink)
+	return nil
+}
+
+// IsCensored - check if a post is censored and can be restored
+func (pm PostManager) IsCensored(ctx sdk.Context, permlink types.Permlink) bool {
+	return pm.postStorage.DoesCensoredPostExist(ctx, permlink)
+}
+
+// GetCensorshipHistory - get censorship history of a post
+func (pm PostManager) GetCensorshipHistory(
+	ctx sdk.Context, permlink types.Permlink) ([]model.CensorshipRecord, sdk.Error) {
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return nil, err
+	}
+	return postMeta.CensorshipHistory, nil
+}
+
+// IsDeleted - check if a post is deleted by author or censored
 func (pm PostManager) IsDeleted(ctx sdk.Context, permlink types.Permlink) (bool, sdk.Error) {
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return false, err
+	}
+	return postMeta.IsDeleted || postMeta.IsCensored, nil
+}
+
+// IsDeletedByAuthor - check if a post is deleted by its author
+func (pm PostManager) IsDeletedByAuthor(ctx sdk.Context, permlink types.Permlink) (bool, sdk.Error) {
 	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
 	if err != nil {
 		return false, err
@@ -255,6 +739,225 @@ func (pm PostManager) IsDeleted(ctx sdk.Context, permlink types.Permlink) (bool,
 	return postMeta.IsDeleted, nil
 }
 
+// SetPostTags - set tags of the post and add the post to tag index
+func (pm PostManager) SetPostTags(ctx sdk.Context, permlink types.Permlink, tags []string) sdk.Error {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	pm.removeFromTagIndex(ctx, permlink, postInfo.Tags, postMeta.CreatedAt)
+	postInfo.Tags = tags
+	if err := pm.postStorage.SetPostInfo(ctx, postInfo); err != nil {
+		return err
+	}
+	return pm.addToTagIndex(ctx, permlink, tags, postMeta.CreatedAt)
+}
+
+// SetPostBeneficiaries - set beneficiaries who share donations of the post
+func (pm PostManager) SetPostBeneficiaries(
+	ctx sdk.Context, permlink types.Permlink, beneficiaries []model.Beneficiary) sdk.Error {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postInfo.Beneficiaries = beneficiaries
+	return pm.postStorage.SetPostInfo(ctx, postInfo)
+}
+
+// SetPostContentRefs - set off-chain content references of the post, references
+// can't be changed by post update so clients can verify content hasn't been swapped
+func (pm PostManager) SetPostContentRefs(
+	ctx sdk.Context, permlink types.Permlink, contentRefs []types.ContentReference) sdk.Error {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postInfo.ContentRefs = contentRefs
+	return pm.postStorage.SetPostInfo(ctx, postInfo)
+}
+
+// HasContentRef - check if the post references the given off-chain content
+func (pm PostManager) HasContentRef(ctx sdk.Context, permlink types.Permlink, ref string) bool {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return false
+	}
+	for _, contentRef := range postInfo.ContentRefs {
+		if contentRef.Ref == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDonationShares - split coin among beneficiaries of the post by weight,
+// all coin goes to the author if the post has no beneficiary
+func (pm PostManager) GetDonationShares(
+	ctx sdk.Context, permlink types.Permlink, coin types.Coin) ([]DonationShare, sdk.Error) {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return nil, err
+	}
+	return splitDonation(postInfo.Author, postInfo.Beneficiaries, coin), nil
+}
+
+// splitDonation - the last beneficiary gets the remainder so shares always sum to coin,
+// other shares are rounded down so the remainder can't be negative
+func splitDonation(
+	author types.AccountKey, beneficiaries []model.Beneficiary, coin types.Coin) []DonationShare {
+	if len(beneficiaries) == 0 {
+		return []DonationShare{{Username: author, Amount: coin}}
+	}
+	shares := []DonationShare{}
+	remain := coin
+	for i, beneficiary := range beneficiaries {
+		amount := remain
+		if i != len(beneficiaries)-1 {
+			share := coin.ToRat().Mul(beneficiary.Weight)
+			amount = types.NewCoinFromBigInt(share.Num().Div(share.Denom()).BigInt())
+		}
+		remain = remain.Minus(amount)
+		shares = append(shares, DonationShare{Username: beneficiary.Username, Amount: amount})
+	}
+	return shares
+}
+
+func (pm PostManager) addToTagIndex(
+	ctx sdk.Context, permlink types.Permlink, tags []string, createdAt int64) sdk.Error {
+	for _, tag := range tags {
+		taggedPost := &model.TaggedPost{
+			Permlink:  permlink,
+			CreatedAt: createdAt,
+		}
+		if err := pm.postStorage.SetTaggedPost(ctx, tag, taggedPost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pm PostManager) removeFromTagIndex(
+	ctx sdk.Context, permlink types.Permlink, tags []string, createdAt int64) {
+	for _, tag := range tags {
+		pm.postStorage.DeleteTaggedPost(ctx, tag, createdAt, permlink)
+	}
+}
+
+// addTagDonationStats - add donation to today's statistics of all tags of the post
+func (pm PostManager) addTagDonationStats(
+	ctx sdk.Context, permlink types.Permlink, amount types.Coin) sdk.Error {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	day := ctx.BlockHeader().Time.Unix() / types.SecondsPerDay
+	for _, tag := range postInfo.Tags {
+		stat, _ := pm.postStorage.GetTagDonationStat(ctx, day, tag)
+		if stat == nil {
+			stat = &model.TagDonationStat{Tag: tag, Day: day, Amount: types.NewCoinFromInt64(0)}
+		}
+		stat.Amount = stat.Amount.Plus(amount)
+		stat.DonateCount++
+		if err := pm.postStorage.SetTagDonationStat(ctx, stat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTaggedPosts - get posts with the tag page by page, newest first. Page starts from 1
+func (pm PostManager) GetTaggedPosts(
+	ctx sdk.Context, tag string, page, pageSize int) ([]model.TaggedPost, sdk.Error) {
+	taggedPosts, err := pm.postStorage.GetTaggedPosts(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	return PaginateTaggedPosts(taggedPosts, page, pageSize), nil
+}
+
+// GetTrendingTags - get tags received most donations in recent days,
+// days out of tag donation stat retention are not counted
+func (pm PostManager) GetTrendingTags(ctx sdk.Context, days int64, limit int) ([]TrendingTag, sdk.Error) {
+	if days > types.TagDonationStatRetentionDays {
+		days = types.TagDonationStatRetentionDays
+	}
+	today := ctx.BlockHeader().Time.Unix() / types.SecondsPerDay
+	stats := []model.TagDonationStat{}
+	for day := today - days + 1; day <= today; day++ {
+		statsOfDay, err := pm.postStorage.GetTagDonationStatsOfDay(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, statsOfDay...)
+	}
+	return GetTrendingTags(stats, limit), nil
+}
+
+// PruneTagDonationStats - remove tag donation statistics of days out of retention
+func (pm PostManager) PruneTagDonationStats(ctx sdk.Context) sdk.Error {
+	today := ctx.BlockHeader().Time.Unix() / types.SecondsPerDay
+	return pm.postStorage.DeleteTagDonationStatsBefore(ctx, today-types.TagDonationStatRetentionDays+1)
+}
+
+// SetReplyPermission - set who is allowed to reply the post
+func (pm PostManager) SetReplyPermission(
+	ctx sdk.Context, permlink types.Permlink, replyPermission types.ReplyPermission) sdk.Error {
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postMeta.ReplyPermission = replyPermission
+	postMeta.AllowReplies = replyPermission != types.ReplyFromNobody
+	if err := pm.postStorage.SetPostMeta(ctx, permlink, postMeta); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetReplyPermission - get who is allowed to reply the post
+func (pm PostManager) GetReplyPermission(
+	ctx sdk.Context, permlink types.Permlink) (types.ReplyPermission, sdk.Error) {
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	if err != nil {
+		return types.ReplyFromAll, err
+	}
+	return postMeta.ReplyPermission, nil
+}
+
+// BlockReplier - block user from replying the post, or all posts of the author if post ID is empty
+func (pm PostManager) BlockReplier(
+	ctx sdk.Context, author types.AccountKey, postID string, user types.AccountKey) sdk.Error {
+	blockedReplier := &model.BlockedReplier{
+		Username:  user,
+		BlockedAt: ctx.BlockHeader().Time.Unix(),
+	}
+	if postID == "" {
+		return pm.postStorage.SetAuthorReplyBlock(ctx, author, blockedReplier)
+	}
+	return pm.postStorage.SetPostReplyBlock(ctx, types.GetPermlink(author, postID), blockedReplier)
+}
+
+// UnblockReplier - unblock user from replying the post, or all posts of the author if post ID is empty
+func (pm PostManager) UnblockReplier(
+	ctx sdk.Context, author types.AccountKey, postID string, user types.AccountKey) {
+	if postID == "" {
+		pm.postStorage.DeleteAuthorReplyBlock(ctx, author, user)
+		return
+	}
+	pm.postStorage.DeletePostReplyBlock(ctx, types.GetPermlink(author, postID), user)
+}
+
+// IsReplyBlocked - check if user is blocked by author from replying the post
+func (pm PostManager) IsReplyBlocked(
+	ctx sdk.Context, author types.AccountKey, postID string, user types.AccountKey) bool {
+	return pm.postStorage.IsBlockedFromAuthorReply(ctx, author, user) ||
+		pm.postStorage.IsBlockedFromPostReply(ctx, types.GetPermlink(author, postID), user)
+}
+
 // UpdateLastActivityAt - update post last activity at
 func (pm PostManager) UpdateLastActivityAt(ctx sdk.Context, permlink types.Permlink) sdk.Error {
 	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
@@ -291,3 +994,155 @@ func (pm PostManager) GetPenaltyScore(ctx sdk.Context, reputation types.Coin) (s
 	}
 	return penaltyScore, nil
 }
+
+// addPostStat - apply update to daily stat buckets of the post and its author, today is the past day since chain start
+func (pm PostManager) addPostStat(
+	ctx sdk.Context, permlink types.Permlink, today int64, update func(stat *model.PostStat)) sdk.Error {
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	postStat, _ := pm.postStorage.GetPostStat(ctx, permlink, types.DailyStat, today)
+	if postStat == nil {
+		postStat = newPostStat(types.DailyStat, today)
+	}
+	update(postStat)
+	if err := pm.setPostStat(ctx, postInfo.Author, permlink, postStat); err != nil {
+		return err
+	}
+	authorStat, _ := pm.postStorage.GetAuthorStat(ctx, postInfo.Author, types.DailyStat, today)
+	if authorStat == nil {
+		authorStat = newPostStat(types.DailyStat, today)
+	}
+	update(authorStat)
+	return pm.setPostStat(ctx, postInfo.Author, "", authorStat)
+}
+
+// setPostStat - set stat bucket of the post, or of the author if permlink is empty,
+// and add the bucket to stat index
+func (pm PostManager) setPostStat(
+	ctx sdk.Context, author types.AccountKey, permlink types.Permlink, stat *model.PostStat) sdk.Error {
+	if permlink == "" {
+		if err := pm.postStorage.SetAuthorStat(ctx, author, stat); err != nil {
+			return err
+		}
+	} else {
+		if err := pm.postStorage.SetPostStat(ctx, permlink, stat); err != nil {
+			return err
+		}
+	}
+	return pm.postStorage.SetStatIndex(ctx, &model.StatIndex{
+		Author:   author,
+		Permlink: permlink,
+		StartDay: stat.StartDay,
+		Period:   stat.Period,
+	})
+}
+
+// CompactPostStats - merge daily stat buckets older than daily retention into weekly
+// buckets and weekly buckets older than weekly retention into monthly buckets,
+// monthly buckets older than monthly retention are removed. Today is the past day since chain start
+func (pm PostManager) CompactPostStats(ctx sdk.Context, today int64) sdk.Error {
+	if err := pm.compactPostStats(
+		ctx, types.DailyStat, types.WeeklyStat, today-types.DailyStatRetentionDays); err != nil {
+		return err
+	}
+	if err := pm.compactPostStats(
+		ctx, types.WeeklyStat, types.MonthlyStat, today-types.WeeklyStatRetentionDays); err != nil {
+		return err
+	}
+	return pm.removePostStats(ctx, types.MonthlyStat, today-types.MonthlyStatRetentionDays)
+}
+
+// compactPostStats - merge stat buckets in the period end not later than the given day
+// into buckets in the longer period
+func (pm PostManager) compactPostStats(
+	ctx sdk.Context, from, to types.StatPeriod, day int64) sdk.Error {
+	indexes, err := pm.postStorage.GetStatIndexesBefore(ctx, from, day-int64(from)+1)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		startDay := index.StartDay / int64(to) * int64(to)
+		var stat, compacted *model.PostStat
+		if index.Permlink == "" {
+			stat, err = pm.postStorage.GetAuthorStat(ctx, index.Author, from, index.StartDay)
+			compacted, _ = pm.postStorage.GetAuthorStat(ctx, index.Author, to, startDay)
+		} else {
+			stat, err = pm.postStorage.GetPostStat(ctx, index.Permlink, from, index.StartDay)
+			compacted, _ = pm.postStorage.GetPostStat(ctx, index.Permlink, to, startDay)
+		}
+		if err != nil {
+			return err
+		}
+		if compacted == nil {
+			compacted = newPostStat(to, startDay)
+		}
+		mergePostStat(compacted, stat)
+		if err := pm.setPostStat(ctx, index.Author, index.Permlink, compacted); err != nil {
+			return err
+		}
+		if index.Permlink == "" {
+			pm.postStorage.DeleteAuthorStat(ctx, index.Author, from, index.StartDay)
+		} else {
+			pm.postStorage.DeletePostStat(ctx, index.Permlink, from, index.StartDay)
+		}
+		pm.postStorage.DeleteStatIndex(ctx, &index)
+	}
+	return nil
+}
+
+// removePostStats - remove stat buckets in the period end not later than the given day
+func (pm PostManager) removePostStats(ctx sdk.Context, period types.StatPeriod, day int64) sdk.Error {
+	indexes, err := pm.postStorage.GetStatIndexesBefore(ctx, period, day-int64(period)+1)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index.Permlink == "" {
+			pm.postStorage.DeleteAuthorStat(ctx, index.Author, period, index.StartDay)
+		} else {
+			pm.postStorage.DeletePostStat(ctx, index.Permlink, period, index.StartDay)
+		}
+		pm.postStorage.DeleteStatIndex(ctx, &index)
+	}
+	return nil
+}
+
+// GetPostStats - get stat buckets of the post overlap with the day range, oldest first
+func (pm PostManager) GetPostStats(
+	ctx sdk.Context, permlink types.Permlink, startDay, endDay int64) ([]model.PostStat, sdk.Error) {
+	stats, err := pm.postStorage.GetPostStats(ctx, permlink)
+	if err != nil {
+		return nil, err
+	}
+	return FilterPostStats(stats, startDay, endDay), nil
+}
+
+// GetAuthorStats - get stat buckets of the author overlap with the day range, oldest first
+func (pm PostManager) GetAuthorStats(
+	ctx sdk.Context, author types.AccountKey, startDay, endDay int64) ([]model.PostStat, sdk.Error) {
+	stats, err := pm.postStorage.GetAuthorStats(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+	return FilterPostStats(stats, startDay, endDay), nil
+}
+
+func newPostStat(period types.StatPeriod, startDay int64) *model.PostStat {
+	return &model.PostStat{
+		StartDay:      startDay,
+		Period:        period,
+		Donation:      types.NewCoinFromInt64(0),
+		UpvoteCoinDay: types.NewCoinFromInt64(0),
+		Reward:        types.NewCoinFromInt64(0),
+	}
+}
+
+func mergePostStat(dst *model.PostStat, src *model.PostStat) {
+	dst.DonateCount += src.DonateCount
+	dst.Donation = dst.Donation.Plus(src.Donation)
+	dst.ViewCount += src.ViewCount
+	dst.UpvoteCoinDay = dst.UpvoteCoinDay.Plus(src.UpvoteCoinDay)
+	dst.Reward = dst.Reward.Plus(src.Reward)
+}
@@ -2,9 +2,11 @@ package post
 
 import (
 	"math/big"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/post/model"
 	"github.com/stretchr/testify/assert"
@@ -203,6 +205,7 @@ func TestUpdatePost(t *testing.T) {
 			TotalReportCoinDay:      types.NewCoinFromInt64(0),
 			TotalReward:             types.NewCoinFromInt64(0),
 			RedistributionSplitRate: sdk.ZeroRat(),
+			NumOfRevisions:          1,
 		}
 		checkPostKVStore(t, ctx,
 			types.GetPermlink(tc.msg.Author, tc.msg.PostID), postInfo, postMeta)
@@ -364,7 +367,7 @@ func TestAddOrUpdateViewToPost(t *testing.T) {
 	for _, tc := range testCases {
 		postKey := types.GetPermlink(tc.author, tc.postID)
 		ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(tc.viewTime, 0)})
-		err := pm.AddOrUpdateViewToPost(ctx, postKey, tc.viewUser)
+		err := pm.AddOrUpdateViewToPost(ctx, postKey, tc.viewUser, 0)
 		if err != nil {
 			t.Errorf("%s: failed to add or update view to post, got err %v", tc.testName, err)
 		}
@@ -465,7 +468,7 @@ func TestDonation(t *testing.T) {
 
 	for _, tc := range testCases {
 		postKey := types.GetPermlink(tc.author, tc.postID)
-		err := pm.AddDonation(ctx, postKey, tc.user, tc.amount, tc.donationType)
+		err := pm.AddDonation(ctx, postKey, tc.user, tc.amount, tc.donationType, 0)
 		if err != nil {
 			t.Errorf("%s: failed to add donation, got err %v", tc.testName, err)
 		}
@@ -567,3 +570,599 @@ func TestDeletePost(t *testing.T) {
 	assert.Nil(t, err)
 	checkIsDelete(t, ctx, pm, types.GetPermlink(user, postID))
 }
+
+func TestCensorAndRestorePost(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user, postID := createTestPost(t, ctx, "user", "postID", am, pm, "0.5")
+	permlink := types.GetPermlink(user, postID)
+	originalInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	assert.Nil(t, err)
+
+	// restore a post which is not censored
+	assert.False(t, pm.IsCensored(ctx, permlink))
+	err = pm.RestorePost(ctx, permlink, types.ProposalKey("2"))
+	assert.Equal(t, model.ErrCensoredPostNotFound(model.GetPostTombstoneKey(permlink)), err)
+
+	err = pm.CensorPost(ctx, permlink, types.ProposalKey("1"), "reason")
+	assert.Nil(t, err)
+	checkIsDelete(t, ctx, pm, permlink)
+	assert.True(t, pm.IsCensored(ctx, permlink))
+	censoredPost, err := pm.postStorage.GetCensoredPost(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, *originalInfo, censoredPost.PostInfo)
+	assert.Equal(t, sdk.NewRat(1, 2), censoredPost.RedistributionSplitRate)
+
+	// censor again won't override the tombstone
+	err = pm.CensorPost(ctx, permlink, types.ProposalKey("3"), "another reason")
+	assert.Nil(t, err)
+	censoredPost, err = pm.postStorage.GetCensoredPost(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, *originalInfo, censoredPost.PostInfo)
+
+	history, err := pm.GetCensorshipHistory(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.CensorshipRecord{
+		{
+			ProposalID: types.ProposalKey("1"),
+			Reason:     "reason",
+			CensoredAt: ctx.BlockHeader().Time.Unix(),
+		},
+	}, history)
+
+	err = pm.RestorePost(ctx, permlink, types.ProposalKey("2"))
+	assert.Nil(t, err)
+	assert.False(t, pm.IsCensored(ctx, permlink))
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, originalInfo, postInfo)
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink)
+	assert.Nil(t, err)
+	assert.False(t, postMeta.IsDeleted)
+	assert.Equal(t, sdk.NewRat(1, 2), postMeta.RedistributionSplitRate)
+	assert.Equal(t, []model.CensorshipRecord{
+		{
+			ProposalID:        types.ProposalKey("1"),
+			Reason:            "reason",
+			CensoredAt:        ctx.BlockHeader().Time.Unix(),
+			RestoreProposalID: types.ProposalKey("2"),
+			RestoredAt:        ctx.BlockHeader().Time.Unix(),
+		},
+	}, postMeta.CensorshipHistory)
+}
+
+func TestCensorAndRestoreDeletedPost(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user, postID := createTestPost(t, ctx, "user", "postID", am, pm, "0")
+	permlink := types.GetPermlink(user, postID)
+
+	// post deleted by author can't be censored
+	err := pm.DeletePost(ctx, permlink)
+	assert.Nil(t, err)
+	err = pm.CensorPost(ctx, permlink, types.ProposalKey("1"), "reason")
+	assert.Equal(t, ErrPostDeletedByAuthor(permlink), err)
+	assert.False(t, pm.IsCensored(ctx, permlink))
+
+	// censored post deleted by author can't be restored
+	user2, postID2 := createTestPost(t, ctx, "user2", "postID", am, pm, "0")
+	permlink2 := types.GetPermlink(user2, postID2)
+	err = pm.CensorPost(ctx, permlink2, types.ProposalKey("2"), "reason")
+	assert.Nil(t, err)
+	isDeletedByAuthor, err := pm.IsDeletedByAuthor(ctx, permlink2)
+	assert.Nil(t, err)
+	assert.False(t, isDeletedByAuthor)
+	err = pm.DeletePost(ctx, permlink2)
+	assert.Nil(t, err)
+	err = pm.RestorePost(ctx, permlink2, types.ProposalKey("3"))
+	assert.Equal(t, ErrPostDeletedByAuthor(permlink2), err)
+	checkIsDelete(t, ctx, pm, permlink2)
+	postMeta, err := pm.postStorage.GetPostMeta(ctx, permlink2)
+	assert.Nil(t, err)
+	assert.True(t, postMeta.IsDeleted)
+	assert.True(t, postMeta.IsCensored)
+}
+
+func TestPostRevisions(t *testing.T) {
+	ctx, am, ph, pm, _, _, _, _ := setupTest(t, 1)
+	baseTime := time.Now().Unix()
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime, 0)})
+	user, postID := createTestPost(t, ctx, "user", "postID", am, pm, "0")
+	permlink := types.GetPermlink(user, postID)
+
+	postParam, err := ph.GetPostParam(ctx)
+	assert.Nil(t, err)
+	postParam.MaxNumOfRevisions = 2
+	err = param.ChangeParamEvent{Param: *postParam}.Execute(ctx, ph, nil)
+	assert.Nil(t, err)
+
+	links := []types.IDToURLMapping{{Identifier: "#1", URL: "https://lino.network"}}
+	for i := int64(1); i <= 3; i++ {
+		ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime+i, 0)})
+		err := pm.UpdatePost(
+			ctx, user, postID, "title"+strconv.FormatInt(i, 10), "content"+strconv.FormatInt(i, 10), links)
+		assert.Nil(t, err)
+	}
+
+	revisions, err := pm.GetPostRevisions(ctx, permlink)
+	assert.Nil(t, err)
+	wantRevisions := []model.PostRevision{
+		{
+			Revision:    2,
+			Title:       "title1",
+			Content:     "content1",
+			Links:       links,
+			ContentHash: GetContentHash("content1"),
+			CreatedAt:   baseTime + 1,
+			ReplacedAt:  baseTime + 2,
+		},
+		{
+			Revision:    3,
+			Title:       "title2",
+			Content:     "content2",
+			Links:       links,
+			ContentHash: GetContentHash("content2"),
+			CreatedAt:   baseTime + 2,
+			ReplacedAt:  baseTime + 3,
+		},
+	}
+	assert.Equal(t, wantRevisions, revisions)
+
+	revision, err := pm.GetPostRevision(ctx, permlink, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, wantRevisions[1], *revision)
+	_, err = pm.GetPostRevision(ctx, permlink, 1)
+	assert.Equal(t, types.CodePostRevisionNotFound, err.Code())
+
+	// delete post removes all revisions
+	err = pm.DeletePost(ctx, permlink)
+	assert.Nil(t, err)
+	revisions, err = pm.GetPostRevisions(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.PostRevision{}, revisions)
+}
+
+func TestPostTags(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	baseTime := time.Now().Unix()
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime, 0)})
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID1", am, pm, "0")
+	permlink1 := types.GetPermlink(user1, postID1)
+	err := pm.SetPostTags(ctx, permlink1, []string{"lino", "music"})
+	assert.Nil(t, err)
+
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime+1, 0)})
+	user2, postID2 := createTestPost(t, ctx, "user2", "postID2", am, pm, "0")
+	permlink2 := types.GetPermlink(user2, postID2)
+	err = pm.SetPostTags(ctx, permlink2, []string{"lino"})
+	assert.Nil(t, err)
+
+	postInfo, err := pm.postStorage.GetPostInfo(ctx, permlink1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"lino", "music"}, postInfo.Tags)
+
+	taggedPost1 := model.TaggedPost{Permlink: permlink1, CreatedAt: baseTime}
+	taggedPost2 := model.TaggedPost{Permlink: permlink2, CreatedAt: baseTime + 1}
+	taggedPosts, err := pm.GetTaggedPosts(ctx, "lino", 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TaggedPost{taggedPost2, taggedPost1}, taggedPosts)
+	taggedPosts, err = pm.GetTaggedPosts(ctx, "music", 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TaggedPost{taggedPost1}, taggedPosts)
+
+	// donations are recorded in trending tags
+	err = pm.AddDonation(ctx, permlink1, "donator", types.NewCoinFromInt64(10), types.DirectDeposit, 0)
+	assert.Nil(t, err)
+	err = pm.AddDonation(ctx, permlink2, "donator", types.NewCoinFromInt64(5), types.DirectDeposit, 0)
+	assert.Nil(t, err)
+	trendingTags, err := pm.GetTrendingTags(ctx, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []TrendingTag{
+		{Tag: "lino", Amount: types.NewCoinFromInt64(15), DonateCount: 2},
+		{Tag: "music", Amount: types.NewCoinFromInt64(10), DonateCount: 1},
+	}, trendingTags)
+
+	// donations out of the period are not counted
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "Lino", Time: time.Unix(baseTime+types.SecondsPerDay, 0)})
+	trendingTags, err = pm.GetTrendingTags(ctx, 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []TrendingTag{}, trendingTags)
+
+	// stats are kept until out of retention
+	day := baseTime / types.SecondsPerDay
+	err = pm.PruneTagDonationStats(ctx)
+	assert.Nil(t, err)
+	stats, err := pm.postStorage.GetTagDonationStatsOfDay(ctx, day)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(stats))
+	ctx = ctx.WithBlockHeader(abci.Header{
+		ChainID: "Lino", Time: time.Unix(baseTime+types.TagDonationStatRetentionDays*types.SecondsPerDay, 0)})
+	err = pm.PruneTagDonationStats(ctx)
+	assert.Nil(t, err)
+	stats, err = pm.postStorage.GetTagDonationStatsOfDay(ctx, day)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TagDonationStat{}, stats)
+
+	// deleted post is removed from tag index
+	err = pm.DeletePost(ctx, permlink1)
+	assert.Nil(t, err)
+	taggedPosts, err = pm.GetTaggedPosts(ctx, "lino", 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TaggedPost{taggedPost2}, taggedPosts)
+
+	// restored post is added back to tag index
+	err = pm.CensorPost(ctx, permlink2, types.ProposalKey("1"), "reason")
+	assert.Nil(t, err)
+	taggedPosts, err = pm.GetTaggedPosts(ctx, "lino", 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TaggedPost{}, taggedPosts)
+	err = pm.RestorePost(ctx, permlink2, types.ProposalKey("2"))
+	assert.Nil(t, err)
+	taggedPosts, err = pm.GetTaggedPosts(ctx, "lino", 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TaggedPost{taggedPost2}, taggedPosts)
+}
+
+func TestDonationShares(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID1", am, pm, "0")
+	user2, postID2 := createTestPost(t, ctx, "user2", "postID2", am, pm, "0")
+	user3 := createTestAccount(t, ctx, am, "user3")
+	permlink1 := types.GetPermlink(user1, postID1)
+	permlink2 := types.GetPermlink(user2, postID2)
+	user4, postID4 := createTestPost(t, ctx, "user4", "postID4", am, pm, "0")
+	permlink4 := types.GetPermlink(user4, postID4)
+
+	err := pm.SetPostBeneficiaries(ctx, permlink2, []model.Beneficiary{
+		{Username: user2, Weight: sdk.NewRat(1, 3)},
+		{Username: user3, Weight: sdk.NewRat(2, 3)},
+	})
+	assert.Nil(t, err)
+	err = pm.SetPostBeneficiaries(ctx, permlink4, []model.Beneficiary{
+		{Username: user4, Weight: sdk.NewRat(3, 10)},
+		{Username: user1, Weight: sdk.NewRat(3, 10)},
+		{Username: user2, Weight: sdk.NewRat(3, 10)},
+		{Username: user3, Weight: sdk.NewRat(1, 10)},
+	})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName   string
+		permlink   types.Permlink
+		coin       types.Coin
+		wantShares []DonationShare
+	}{
+		{
+			testName:   "post without beneficiary",
+			permlink:   permlink1,
+			coin:       types.NewCoinFromInt64(100),
+			wantShares: []DonationShare{{Username: user1, Amount: types.NewCoinFromInt64(100)}},
+		},
+		{
+			testName: "last beneficiary gets the remainder",
+			permlink: permlink2,
+			coin:     types.NewCoinFromInt64(100),
+			wantShares: []DonationShare{
+				{Username: user2, Amount: types.NewCoinFromInt64(33)},
+				{Username: user3, Amount: types.NewCoinFromInt64(67)},
+			},
+		},
+		{
+			testName: "shares are rounded down so the remainder isn't negative",
+			permlink: permlink4,
+			coin:     types.NewCoinFromInt64(5),
+			wantShares: []DonationShare{
+				{Username: user4, Amount: types.NewCoinFromInt64(1)},
+				{Username: user1, Amount: types.NewCoinFromInt64(1)},
+				{Username: user2, Amount: types.NewCoinFromInt64(1)},
+				{Username: user3, Amount: types.NewCoinFromInt64(2)},
+			},
+		},
+		{
+			testName: "zero coin",
+			permlink: permlink2,
+			coin:     types.NewCoinFromInt64(0),
+			wantShares: []DonationShare{
+				{Username: user2, Amount: types.NewCoinFromInt64(0)},
+				{Username: user3, Amount: types.NewCoinFromInt64(0)},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		shares, err := pm.GetDonationShares(ctx, tc.permlink, tc.coin)
+		if err != nil {
+			t.Errorf("%s: failed to get donation shares, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantShares, shares) {
+			t.Errorf("%s: diff shares, got %v, want %v", tc.testName, shares, tc.wantShares)
+		}
+	}
+}
+
+func TestHasPendingRewards(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID1", am, pm, "0")
+	user2, postID2 := createTestPost(t, ctx, "user2", "postID2", am, pm, "0")
+	user3 := createTestAccount(t, ctx, am, "user3")
+	user4 := createTestAccount(t, ctx, am, "user4")
+	permlink1 := types.GetPermlink(user1, postID1)
+	permlink2 := types.GetPermlink(user2, postID2)
+	now := ctx.BlockHeader().Time.Unix()
+
+	err := pm.SetPostBeneficiaries(ctx, permlink2, []model.Beneficiary{
+		{Username: user3, Weight: sdk.OneRat()},
+	})
+	assert.Nil(t, err)
+	// executed reward event doesn't count
+	err = pm.AddPendingReward(ctx, permlink1, now-1)
+	assert.Nil(t, err)
+	err = pm.AddPendingReward(ctx, permlink2, now+100)
+	assert.Nil(t, err)
+	// event registered at block time hasn't been executed
+	user5, postID5 := createTestPost(t, ctx, "user5", "postID5", am, pm, "0")
+	err = pm.AddPendingReward(ctx, types.GetPermlink(user5, postID5), now)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		testName string
+		username types.AccountKey
+		want     bool
+	}{
+		{testName: "author of post with executed reward", username: user1, want: false},
+		{testName: "author of post with pending reward", username: user2, want: true},
+		{testName: "beneficiary of post with pending reward", username: user3, want: true},
+		{testName: "no post", username: user4, want: false},
+		{testName: "author of post with reward at block time", username: user5, want: true},
+	}
+	for _, tc := range testCases {
+		got, err := pm.HasPendingRewards(ctx, tc.username)
+		if err != nil {
+			t.Errorf("%s: failed to check pending rewards, got err %v", tc.testName, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, got, tc.want)
+		}
+	}
+}
+
+func TestReportStat(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user1, postID := createTestPost(t, ctx, "user1", "postID", am, pm, "0")
+	user2 := createTestAccount(t, ctx, am, "user2")
+	user3 := createTestAccount(t, ctx, am, "user3")
+	permlink := types.GetPermlink(user1, postID)
+	coinDay := types.NewCoinFromInt64(1)
+
+	testCases := []struct {
+		testName     string
+		user         types.AccountKey
+		isReport     bool
+		reason       types.ReportReason
+		reportWeight types.Coin
+		wantStat     *model.PostReportStat
+	}{
+		{
+			testName:     "user2 reports spam",
+			user:         user2,
+			isReport:     true,
+			reason:       types.SpamReport,
+			reportWeight: types.NewCoinFromInt64(10),
+			wantStat: &model.PostReportStat{
+				Permlink:     permlink,
+				ReportCount:  1,
+				ReportWeight: types.NewCoinFromInt64(10),
+				ReasonCounts: []model.ReportReasonCount{{Reason: types.SpamReport, Count: 1}},
+				LastReportAt: ctx.BlockHeader().Time.Unix(),
+			},
+		},
+		{
+			testName:     "user3 reports abuse",
+			user:         user3,
+			isReport:     true,
+			reason:       types.AbuseReport,
+			reportWeight: types.NewCoinFromInt64(5),
+			wantStat: &model.PostReportStat{
+				Permlink:     permlink,
+				ReportCount:  2,
+				ReportWeight: types.NewCoinFromInt64(15),
+				ReasonCounts: []model.ReportReasonCount{
+					{Reason: types.SpamReport, Count: 1},
+					{Reason: types.AbuseReport, Count: 1},
+				},
+				LastReportAt: ctx.BlockHeader().Time.Unix(),
+			},
+		},
+		{
+			testName:     "user2 reports again with another reason",
+			user:         user2,
+			isReport:     true,
+			reason:       types.CopyrightReport,
+			reportWeight: types.NewCoinFromInt64(1),
+			wantStat: &model.PostReportStat{
+				Permlink:     permlink,
+				ReportCount:  2,
+				ReportWeight: types.NewCoinFromInt64(6),
+				ReasonCounts: []model.ReportReasonCount{
+					{Reason: types.CopyrightReport, Count: 1},
+					{Reason: types.AbuseReport, Count: 1},
+				},
+				LastReportAt: ctx.BlockHeader().Time.Unix(),
+			},
+		},
+		{
+			testName:     "user3 changes report to upvote",
+			user:         user3,
+			isReport:     false,
+			reason:       types.UnspecifiedReport,
+			reportWeight: types.NewCoinFromInt64(0),
+			wantStat: &model.PostReportStat{
+				Permlink:     permlink,
+				ReportCount:  1,
+				ReportWeight: types.NewCoinFromInt64(6),
+				ReasonCounts: []model.ReportReasonCount{{Reason: types.CopyrightReport, Count: 1}},
+				LastReportAt: ctx.BlockHeader().Time.Unix(),
+			},
+		},
+		{
+			testName:     "user3 reports again after upvote replaces previous weight",
+			user:         user3,
+			isReport:     true,
+			reason:       types.SpamReport,
+			reportWeight: types.NewCoinFromInt64(2),
+			wantStat: &model.PostReportStat{
+				Permlink:     permlink,
+				ReportCount:  2,
+				ReportWeight: types.NewCoinFromInt64(3),
+				ReasonCounts: []model.ReportReasonCount{
+					{Reason: types.SpamReport, Count: 1},
+					{Reason: types.CopyrightReport, Count: 1},
+				},
+				LastReportAt: ctx.BlockHeader().Time.Unix(),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		err := pm.AddReportOrUpvote(
+			ctx, permlink, tc.user, coinDay, tc.isReport, tc.reason, "memo", tc.reportWeight, 0)
+		if err != nil {
+			t.Errorf("%s: failed to add report or upvote, got err %v", tc.testName, err)
+		}
+		stat, err := pm.GetReportStat(ctx, permlink)
+		if err != nil {
+			t.Errorf("%s: failed to get report stat, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantStat, stat) {
+			t.Errorf("%s: diff stat, got %v, want %v", tc.testName, stat, tc.wantStat)
+		}
+	}
+
+	queue, err := pm.GetReportQueue(ctx, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(queue))
+
+	assert.Nil(t, pm.DeletePost(ctx, permlink))
+	queue, err = pm.GetReportQueue(ctx, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.PostReportStat{}, queue)
+}
+
+func TestPostContentRefs(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user, postID := createTestPost(t, ctx, "user", "postID", am, pm, "0")
+	permlink := types.GetPermlink(user, postID)
+	ref := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	assert.False(t, pm.HasContentRef(ctx, permlink, ref))
+	err := pm.SetPostContentRefs(ctx, permlink, []types.ContentReference{
+		{RefType: types.SHA256ContentRef, Ref: ref, Size: 100, MimeType: "text/plain"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, pm.HasContentRef(ctx, permlink, ref))
+	assert.False(t, pm.HasContentRef(ctx, types.GetPermlink(user, "invalid"), ref))
+
+	// update doesn't change content refs
+	err = pm.UpdatePost(ctx, user, postID, "title", "content", nil)
+	assert.Nil(t, err)
+	assert.True(t, pm.HasContentRef(ctx, permlink, ref))
+
+	err = pm.DeletePost(ctx, permlink)
+	assert.Nil(t, err)
+	assert.False(t, pm.HasContentRef(ctx, permlink, ref))
+}
+
+func TestPostStats(t *testing.T) {
+	ctx, am, _, pm, _, _, _, _ := setupTest(t, 1)
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID1", am, pm, "0")
+	postID2 := "postID2"
+	err := pm.CreatePost(
+		ctx, user1, postID2, "", "", "", "", "content", "title", sdk.ZeroRat(), []types.IDToURLMapping{})
+	assert.Nil(t, err)
+	user2 := createTestAccount(t, ctx, am, "user2")
+	permlink1 := types.GetPermlink(user1, postID1)
+	permlink2 := types.GetPermlink(user1, postID2)
+	today := int64(30)
+
+	assert.Nil(t, pm.AddDonation(ctx, permlink1, user2, types.NewCoinFromInt64(10), types.DirectDeposit, today))
+	assert.Nil(t, pm.AddDonation(ctx, permlink1, user2, types.NewCoinFromInt64(5), types.Inflation, today))
+	assert.Nil(t, pm.AddReportOrUpvote(
+		ctx, permlink1, user2, types.NewCoinFromInt64(3), false, types.UnspecifiedReport, "",
+		types.NewCoinFromInt64(0), today))
+	assert.Nil(t, pm.AddOrUpdateViewToPost(ctx, permlink2, user2, today))
+
+	post1Stat := model.PostStat{
+		DonateCount:   1,
+		Donation:      types.NewCoinFromInt64(10),
+		UpvoteCoinDay: types.NewCoinFromInt64(3),
+		Reward:        types.NewCoinFromInt64(5),
+	}
+	authorStat := post1Stat
+	authorStat.ViewCount = 1
+	withPeriod := func(stat model.PostStat, period types.StatPeriod) model.PostStat {
+		stat.Period = period
+		stat.StartDay = today / int64(period) * int64(period)
+		return stat
+	}
+	weekStart := today / 7 * 7
+	monthStart := today / 28 * 28
+
+	testCases := []struct {
+		testName        string
+		day             int64
+		wantPost1Stats  []model.PostStat
+		wantAuthorStats []model.PostStat
+	}{
+		{
+			testName:        "daily stat is kept in retention",
+			day:             today + types.DailyStatRetentionDays,
+			wantPost1Stats:  []model.PostStat{withPeriod(post1Stat, types.DailyStat)},
+			wantAuthorStats: []model.PostStat{withPeriod(authorStat, types.DailyStat)},
+		},
+		{
+			testName:        "daily stat is compacted into weekly stat",
+			day:             today + types.DailyStatRetentionDays + 1,
+			wantPost1Stats:  []model.PostStat{withPeriod(post1Stat, types.WeeklyStat)},
+			wantAuthorStats: []model.PostStat{withPeriod(authorStat, types.WeeklyStat)},
+		},
+		{
+			testName:        "weekly stat is kept in retention",
+			day:             weekStart + 6 + types.WeeklyStatRetentionDays,
+			wantPost1Stats:  []model.PostStat{withPeriod(post1Stat, types.WeeklyStat)},
+			wantAuthorStats: []model.PostStat{withPeriod(authorStat, types.WeeklyStat)},
+		},
+		{
+			testName:        "weekly stat is compacted into monthly stat",
+			day:             weekStart + 7 + types.WeeklyStatRetentionDays,
+			wantPost1Stats:  []model.PostStat{withPeriod(post1Stat, types.MonthlyStat)},
+			wantAuthorStats: []model.PostStat{withPeriod(authorStat, types.MonthlyStat)},
+		},
+		{
+			testName:        "monthly stat is kept in retention",
+			day:             monthStart + 27 + types.MonthlyStatRetentionDays,
+			wantPost1Stats:  []model.PostStat{withPeriod(post1Stat, types.MonthlyStat)},
+			wantAuthorStats: []model.PostStat{withPeriod(authorStat, types.MonthlyStat)},
+		},
+		{
+			testName:        "monthly stat is removed after retention",
+			day:             monthStart + 28 + types.MonthlyStatRetentionDays,
+			wantPost1Stats:  []model.PostStat{},
+			wantAuthorStats: []model.PostStat{},
+		},
+	}
+	for _, tc := range testCases {
+		if err := pm.CompactPostStats(ctx, tc.day); err != nil {
+			t.Errorf("%s: failed to compact post stats, got err %v", tc.testName, err)
+		}
+		post1Stats, err := pm.GetPostStats(ctx, permlink1, today, tc.day)
+		if err != nil {
+			t.Errorf("%s: failed to get post stats, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantPost1Stats, post1Stats) {
+			t.Errorf("%s: diff post stats, got %v, want %v", tc.testName, post1Stats, tc.wantPost1Stats)
+		}
+		authorStats, err := pm.GetAuthorStats(ctx, user1, today, tc.day)
+		if err != nil {
+			t.Errorf("%s: failed to get author stats, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantAuthorStats, authorStats) {
+			t.Errorf("%s: diff author stats, got %v, want %v", tc.testName, authorStats, tc.wantAuthorStats)
+		}
+	}
+}
@@ -97,3 +97,143 @@ func ErrFailedToUnmarshalPostView(err error) sdk.Error {
 func ErrFailedToUnmarshalPostDonations(err error) sdk.Error {
 	return types.NewError(types.CodeFailedToUnmarshalPostDonations, fmt.Sprintf("failed to unmarshal post donations: %s", err.Error()))
 }
+
+// ErrCensoredPostNotFound - error if censored post tombstone is not found in KVStore
+func ErrCensoredPostNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodeCensoredPostNotFound, fmt.Sprintf("censored post is not found for key: %s", key))
+}
+
+// ErrFailedToMarshalCensoredPost - error if marshal censored post failed
+func ErrFailedToMarshalCensoredPost(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalCensoredPost, fmt.Sprintf("failed to marshal censored post: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalCensoredPost - error if unmarshal censored post failed
+func ErrFailedToUnmarshalCensoredPost(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalCensoredPost, fmt.Sprintf("failed to unmarshal censored post: %s", err.Error()))
+}
+
+// ErrFailedToMarshalBlockedReplier - error if marshal blocked replier failed
+func ErrFailedToMarshalBlockedReplier(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalBlockedReplier, fmt.Sprintf("failed to marshal blocked replier: %s", err.Error()))
+}
+
+// ErrPostRevisionNotFound - error if post revision is not found in KVStore
+func ErrPostRevisionNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodePostRevisionNotFound, fmt.Sprintf("post revision is not found for key: %s", key))
+}
+
+// ErrFailedToMarshalPostRevision - error if marshal post revision failed
+func ErrFailedToMarshalPostRevision(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalPostRevision, fmt.Sprintf("failed to marshal post revision: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalPostRevision - error if unmarshal post revision failed
+func ErrFailedToUnmarshalPostRevision(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalPostRevision, fmt.Sprintf("failed to unmarshal post revision: %s", err.Error()))
+}
+
+// ErrFailedToMarshalTaggedPost - error if marshal tagged post failed
+func ErrFailedToMarshalTaggedPost(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalTaggedPost, fmt.Sprintf("failed to marshal tagged post: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalTaggedPost - error if unmarshal tagged post failed
+func ErrFailedToUnmarshalTaggedPost(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalTaggedPost, fmt.Sprintf("failed to unmarshal tagged post: %s", err.Error()))
+}
+
+// ErrFailedToMarshalTagDonationStat - error if marshal tag donation stat failed
+func ErrFailedToMarshalTagDonationStat(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalTagDonationStat, fmt.Sprintf("failed to marshal tag donation stat: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalTagDonationStat - error if unmarshal tag donation stat failed
+func ErrFailedToUnmarshalTagDonationStat(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalTagDonationStat, fmt.Sprintf("failed to unmarshal tag donation stat: %s", err.Error()))
+}
+
+// ErrTagDonationStatNotFound - error if tag donation stat is not found in KVStore
+func ErrTagDonationStatNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodeTagDonationStatNotFound, fmt.Sprintf("tag donation stat is not found for key: %s", key))
+}
+
+// ErrPostPaywallNotFound - error if post paywall is not found in KVStore
+func ErrPostPaywallNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodePostPaywallNotFound, fmt.Sprintf("post paywall is not found for key: %s", key))
+}
+
+// ErrFailedToMarshalPostPaywall - error if marshal post paywall failed
+func ErrFailedToMarshalPostPaywall(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalPostPaywall, fmt.Sprintf("failed to marshal post paywall: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalPostPaywall - error if unmarshal post paywall failed
+func ErrFailedToUnmarshalPostPaywall(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalPostPaywall, fmt.Sprintf("failed to unmarshal post paywall: %s", err.Error()))
+}
+
+// ErrPostUnlockNotFound - error if post unlock is not found in KVStore
+func ErrPostUnlockNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodePostUnlockNotFound, fmt.Sprintf("post unlock is not found for key: %s", key))
+}
+
+// ErrFailedToMarshalPostUnlock - error if marshal post unlock failed
+func ErrFailedToMarshalPostUnlock(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalPostUnlock, fmt.Sprintf("failed to marshal post unlock: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalPostUnlock - error if unmarshal post unlock failed
+func ErrFailedToUnmarshalPostUnlock(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalPostUnlock, fmt.Sprintf("failed to unmarshal post unlock: %s", err.Error()))
+}
+
+// ErrPostReportStatNotFound - error if post report stat is not found in KVStore
+func ErrPostReportStatNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodePostReportStatNotFound, fmt.Sprintf("post report stat is not found for key: %s", key))
+}
+
+// ErrFailedToMarshalPostReportStat - error if marshal post report stat failed
+func ErrFailedToMarshalPostReportStat(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalPostReportStat, fmt.Sprintf("failed to marshal post report stat: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalPostReportStat - error if unmarshal post report stat failed
+func ErrFailedToUnmarshalPostReportStat(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalPostReportStat, fmt.Sprintf("failed to unmarshal post report stat: %s", err.Error()))
+}
+
+// ErrFailedToMarshalPendingReward - error if marshal pending reward failed
+func ErrFailedToMarshalPendingReward(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalPendingReward, fmt.Sprintf("failed to marshal pending reward: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalPendingReward - error if unmarshal pending reward failed
+func ErrFailedToUnmarshalPendingReward(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalPendingReward, fmt.Sprintf("failed to unmarshal pending reward: %s", err.Error()))
+}
+
+// ErrPostStatNotFound - error if post stat is not found in KVStore
+func ErrPostStatNotFound(key []byte) sdk.Error {
+	return types.NewError(types.CodePostStatNotFound, fmt.Sprintf("post stat is not found for key: %s", key))
+}
+
+// ErrFailedToMarshalPostStat - error if marshal post stat failed
+func ErrFailedToMarshalPostStat(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalPostStat, fmt.Sprintf("failed to marshal post stat: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalPostStat - error if unmarshal post stat failed
+func ErrFailedToUnmarshalPostStat(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalPostStat, fmt.Sprintf("failed to unmarshal post stat: %s", err.Error()))
+}
+
+// ErrFailedToMarshalStatIndex - error if marshal stat index failed
+func ErrFailedToMarshalStatIndex(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToMarshalStatIndex, fmt.Sprintf("failed to marshal stat index: %s", err.Error()))
+}
+
+// ErrFailedToUnmarshalStatIndex - error if unmarshal stat index failed
+func ErrFailedToUnmarshalStatIndex(err error) sdk.Error {
+	return types.NewError(types.CodeFailedToUnmarshalStatIndex, fmt.Sprintf("failed to unmarshal stat index: %s", err.Error()))
+}
@@ -14,38 +14,121 @@ type URL string
 
 // PostInfo - can also use to present comment(with parent) or repost(with source)
 type PostInfo struct {
-	PostID       string                 `json:"post_id"`
-	Title        string                 `json:"title"`
-	Content      string                 `json:"content"`
-	Author       types.AccountKey       `json:"author"`
-	ParentAuthor types.AccountKey       `json:"parent_author"`
-	ParentPostID string                 `json:"parent_postID"`
-	SourceAuthor types.AccountKey       `json:"source_author"`
-	SourcePostID string                 `json:"source_postID"`
-	Links        []types.IDToURLMapping `json:"links"`
+	PostID        string                   `json:"post_id"`
+	Title         string                   `json:"title"`
+	Content       string                   `json:"content"`
+	Author        types.AccountKey         `json:"author"`
+	ParentAuthor  types.AccountKey         `json:"parent_author"`
+	ParentPostID  string                   `json:"parent_postID"`
+	SourceAuthor  types.AccountKey         `json:"source_author"`
+	SourcePostID  string                   `json:"source_postID"`
+	Links         []types.IDToURLMapping   `json:"links"`
+	Tags          []string                 `json:"tags"`
+	Beneficiaries []Beneficiary            `json:"beneficiaries"`
+	ContentRefs   []types.ContentReference `json:"content_refs"`
+}
+
+// Beneficiary - account shares direct deposit and inflation reward of the post by weight
+type Beneficiary struct {
+	Username types.AccountKey `json:"username"`
+	Weight   sdk.Rat          `json:"weight"`
 }
 
 // PostMeta - stores tiny and frequently updated fields.
 type PostMeta struct {
-	CreatedAt               int64      `json:"created_at"`
-	LastUpdatedAt           int64      `json:"last_updated_at"`
-	LastActivityAt          int64      `json:"last_activity_at"`
-	AllowReplies            bool       `json:"allow_replies"`
-	IsDeleted               bool       `json:"is_deleted"`
-	TotalDonateCount        int64      `json:"total_donate_count"`
-	TotalReportCoinDay      types.Coin `json:"total_report_coin_day"`
-	TotalUpvoteCoinDay      types.Coin `json:"total_upvote_coin_day"`
-	TotalViewCount          int64      `json:"total_view_count"`
-	TotalReward             types.Coin `json:"total_reward"`
-	RedistributionSplitRate sdk.Rat    `json:"redistribution_split_rate"`
+	CreatedAt               int64                 `json:"created_at"`
+	LastUpdatedAt           int64                 `json:"last_updated_at"`
+	LastActivityAt          int64                 `json:"last_activity_at"`
+	AllowReplies            bool                  `json:"allow_replies"`
+	ReplyPermission         types.ReplyPermission `json:"reply_permission"`
+	IsDeleted               bool                  `json:"is_deleted"`
+	TotalDonateCount        int64                 `json:"total_donate_count"`
+	TotalReportCoinDay      types.Coin            `json:"total_report_coin_day"`
+	TotalUpvoteCoinDay      types.Coin            `json:"total_upvote_coin_day"`
+	TotalViewCount          int64                 `json:"total_view_count"`
+	TotalPreviewCount       int64                 `json:"total_preview_count"`
+	TotalReward             types.Coin            `json:"total_reward"`
+	RedistributionSplitRate sdk.Rat               `json:"redistribution_split_rate"`
+	CensorshipHistory       []CensorshipRecord    `json:"censorship_history"`
+	NumOfRevisions          int64                 `json:"num_of_revisions"`
+	IsCensored              bool                  `json:"is_censored"`
+}
+
+// CensorshipRecord - a content censorship of the post and its restore if any
+type CensorshipRecord struct {
+	ProposalID        types.ProposalKey `json:"proposal_id"`
+	Reason            string            `json:"reason"`
+	CensoredAt        int64             `json:"censored_at"`
+	RestoreProposalID types.ProposalKey `json:"restore_proposal_id"`
+	RestoredAt        int64             `json:"restored_at"`
+}
+
+// CensoredPost - tombstone of censored post, keeps original content for restore
+type CensoredPost struct {
+	PostInfo                PostInfo          `json:"post_info"`
+	RedistributionSplitRate sdk.Rat           `json:"redistribution_split_rate"`
+	ProposalID              types.ProposalKey `json:"proposal_id"`
+	CensoredAt              int64             `json:"censored_at"`
+}
+
+// PostRevision - title, content and links of a post before an update,
+// CreatedAt is when this revision was written and ReplacedAt is when it was updated
+type PostRevision struct {
+	Revision    int64                  `json:"revision"`
+	Title       string                 `json:"title"`
+	Content     string                 `json:"content"`
+	Links       []types.IDToURLMapping `json:"links"`
+	ContentHash string                 `json:"content_hash"`
+	CreatedAt   int64                  `json:"created_at"`
+	ReplacedAt  int64                  `json:"replaced_at"`
+}
+
+// TaggedPost - a post in the tag index
+type TaggedPost struct {
+	Permlink  types.Permlink `json:"permlink"`
+	CreatedAt int64          `json:"created_at"`
+}
+
+// TagDonationStat - donations received by posts with the tag in one day
+type TagDonationStat struct {
+	Tag         string     `json:"tag"`
+	Day         int64      `json:"day"`
+	Amount      types.Coin `json:"amount"`
+	DonateCount int64      `json:"donate_count"`
+}
+
+// BlockedReplier - user blocked by author from replying a post or all posts of the author
+type BlockedReplier struct {
+	Username  types.AccountKey `json:"username"`
+	BlockedAt int64            `json:"blocked_at"`
 }
 
 // ReportOrUpvote - report or upvote from a user to a post
 type ReportOrUpvote struct {
-	Username  types.AccountKey `json:"username"`
-	CoinDay   types.Coin       `json:"coin_day"`
-	CreatedAt int64            `json:"created_at"`
-	IsReport  bool             `json:"is_report"`
+	Username  types.AccountKey   `json:"username"`
+	CoinDay   types.Coin         `json:"coin_day"`
+	CreatedAt int64              `json:"created_at"`
+	IsReport  bool               `json:"is_report"`
+	Reason    types.ReportReason `json:"reason"`
+	Memo      string             `json:"memo"`
+	// ReportWeight - weight the user currently contributes to the post report stat
+	ReportWeight types.Coin `json:"report_weight"`
+}
+
+// PostReportStat - aggregation of reports to a post, report weight is the sum of
+// reporters' reputation, each reporter is only counted once with the latest reason
+type PostReportStat struct {
+	Permlink     types.Permlink      `json:"permlink"`
+	ReportCount  int64               `json:"report_count"`
+	ReportWeight types.Coin          `json:"report_weight"`
+	ReasonCounts []ReportReasonCount `json:"reason_counts"`
+	LastReportAt int64               `json:"last_report_at"`
+}
+
+// ReportReasonCount - number of reporters report a post with the reason
+type ReportReasonCount struct {
+	Reason types.ReportReason `json:"reason"`
+	Count  int64              `json:"count"`
 }
 
 // Comment - comment list store dy a post
@@ -55,11 +138,28 @@ type Comment struct {
 	CreatedAt int64            `json:"created_at"`
 }
 
-// View - from a user to a post
+// View - from a user to a post, preview times counts views of a paywalled post without access
 type View struct {
-	Username   types.AccountKey `json:"username"`
-	LastViewAt int64            `json:"last_view_at"`
-	Times      int64            `jons:"times"`
+	Username     types.AccountKey `json:"username"`
+	LastViewAt   int64            `json:"last_view_at"`
+	Times        int64            `jons:"times"`
+	PreviewTimes int64            `json:"preview_times"`
+}
+
+// Paywall - price to access a paywalled post, price is paid once for
+// one time unlock or paid for every view for pay per view post
+type Paywall struct {
+	PaywallType types.PaywallType `json:"paywall_type"`
+	Price       types.Coin        `json:"price"`
+}
+
+// PostUnlock - record a user unlock behavior to a paywalled post,
+// remaining views are only used by pay per view post
+type PostUnlock struct {
+	Username       types.AccountKey `json:"username"`
+	UnlockedAt     int64            `json:"unlocked_at"`
+	Times          int64            `json:"times"`
+	RemainingViews int64            `json:"remaining_views"`
 }
 
 // Donations - record a user donation behavior to a post
@@ -68,3 +168,33 @@ type Donations struct {
 	Times    int64            `json:"times"`
 	Amount   types.Coin       `json:"amount"`
 }
+
+// PendingReward - a content reward event of a post that is still in the
+// consumption freezing period, donated at is the time the friction was added.
+// Users are the author and beneficiaries the record is indexed by
+type PendingReward struct {
+	EventTime int64              `json:"event_time"`
+	DonatedAt int64              `json:"donated_at"`
+	Users     []types.AccountKey `json:"users"`
+}
+
+// PostStat - donations, views, upvote coin day and inflation reward received by
+// a post or all posts of an author from start day in a period of days
+type PostStat struct {
+	StartDay      int64            `json:"start_day"`
+	Period        types.StatPeriod `json:"period"`
+	DonateCount   int64            `json:"donate_count"`
+	Donation      types.Coin       `json:"donation"`
+	ViewCount     int64            `json:"view_count"`
+	UpvoteCoinDay types.Coin       `json:"upvote_coin_day"`
+	Reward        types.Coin       `json:"reward"`
+}
+
+// StatIndex - a stat bucket of a post or an author, permlink is empty
+// for author stat. Used to find stat buckets need to be compacted
+type StatIndex struct {
+	Author   types.AccountKey `json:"author"`
+	Permlink types.Permlink   `json:"permlink"`
+	StartDay int64            `json:"start_day"`
+	Period   types.StatPeriod `json:"period"`
+}
@@ -1,6 +1,10 @@
 package model
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/types"
 
@@ -14,6 +18,20 @@ var (
 	postCommentSubStore        = []byte{0x03} // SubStore for all comments
 	postViewsSubStore          = []byte{0x04} // SubStore for all views
 	postDonationsSubStore      = []byte{0x05} // SubStore for all donations
+	postTombstoneSubStore      = []byte{0x06} // SubStore for all censored post content
+	postReplyBlockSubStore     = []byte{0x07} // SubStore for all users blocked from replying a post
+	authorReplyBlockSubStore   = []byte{0x08} // SubStore for all users blocked from replying an author
+	postRevisionSubStore       = []byte{0x09} // SubStore for all post revisions
+	tagIndexSubStore           = []byte{0x0a} // SubStore for tag to post index
+	tagDonationStatSubStore    = []byte{0x0b} // SubStore for daily donation statistics of tags
+	postPaywallSubStore        = []byte{0x0c} // SubStore for all post paywalls
+	postUnlockSubStore         = []byte{0x0d} // SubStore for all unlocks to paywalled post
+	postReportStatSubStore     = []byte{0x0e} // SubStore for report stat of reported posts
+	postPendingRewardSubStore  = []byte{0x0f} // SubStore for pending reward events of posts
+	postStatSubStore           = []byte{0x10} // SubStore for stat buckets of posts
+	authorStatSubStore         = []byte{0x11} // SubStore for stat buckets of authors
+	statIndexSubStore          = []byte{0x12} // SubStore for stat buckets ordered by period and start day
+	userPendingRewardSubStore  = []byte{0x13} // SubStore for pending reward events indexed by rewarded user
 )
 
 // PostStorage - post storage
@@ -202,6 +220,545 @@ func (ps PostStorage) SetPostDonations(
 	return nil
 }
 
+// DoesCensoredPostExist - check if a post has tombstone in KVStore or not
+func (ps PostStorage) DoesCensoredPostExist(ctx sdk.Context, permlink types.Permlink) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(GetPostTombstoneKey(permlink))
+}
+
+// GetCensoredPost - get censored post tombstone from KVStore
+func (ps PostStorage) GetCensoredPost(ctx sdk.Context, permlink types.Permlink) (*CensoredPost, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	censoredBytes := store.Get(GetPostTombstoneKey(permlink))
+	if censoredBytes == nil {
+		return nil, ErrCensoredPostNotFound(GetPostTombstoneKey(permlink))
+	}
+	censoredPost := new(CensoredPost)
+	if unmarshalErr := ps.cdc.UnmarshalJSON(censoredBytes, censoredPost); unmarshalErr != nil {
+		return nil, ErrFailedToUnmarshalCensoredPost(unmarshalErr)
+	}
+	return censoredPost, nil
+}
+
+// SetCensoredPost - set censored post tombstone to KVStore
+func (ps PostStorage) SetCensoredPost(
+	ctx sdk.Context, permlink types.Permlink, censoredPost *CensoredPost) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	censoredBytes, err := ps.cdc.MarshalJSON(*censoredPost)
+	if err != nil {
+		return ErrFailedToMarshalCensoredPost(err)
+	}
+	store.Set(GetPostTombstoneKey(permlink), censoredBytes)
+	return nil
+}
+
+// DeleteCensoredPost - delete censored post tombstone from KVStore
+func (ps PostStorage) DeleteCensoredPost(ctx sdk.Context, permlink types.Permlink) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetPostTombstoneKey(permlink))
+}
+
+// IsBlockedFromPostReply - check if user is blocked from replying the post
+func (ps PostStorage) IsBlockedFromPostReply(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(getPostReplyBlockKey(permlink, user))
+}
+
+// SetPostReplyBlock - block user from replying the post
+func (ps PostStorage) SetPostReplyBlock(
+	ctx sdk.Context, permlink types.Permlink, blockedReplier *BlockedReplier) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	blockedBytes, err := ps.cdc.MarshalJSON(*blockedReplier)
+	if err != nil {
+		return ErrFailedToMarshalBlockedReplier(err)
+	}
+	store.Set(getPostReplyBlockKey(permlink, blockedReplier.Username), blockedBytes)
+	return nil
+}
+
+// DeletePostReplyBlock - unblock user from replying the post
+func (ps PostStorage) DeletePostReplyBlock(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(getPostReplyBlockKey(permlink, user))
+}
+
+// IsBlockedFromAuthorReply - check if user is blocked from replying all posts of the author
+func (ps PostStorage) IsBlockedFromAuthorReply(
+	ctx sdk.Context, author types.AccountKey, user types.AccountKey) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(getAuthorReplyBlockKey(author, user))
+}
+
+// SetAuthorReplyBlock - block user from replying all posts of the author
+func (ps PostStorage) SetAuthorReplyBlock(
+	ctx sdk.Context, author types.AccountKey, blockedReplier *BlockedReplier) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	blockedBytes, err := ps.cdc.MarshalJSON(*blockedReplier)
+	if err != nil {
+		return ErrFailedToMarshalBlockedReplier(err)
+	}
+	store.Set(getAuthorReplyBlockKey(author, blockedReplier.Username), blockedBytes)
+	return nil
+}
+
+// DeleteAuthorReplyBlock - unblock user from replying all posts of the author
+func (ps PostStorage) DeleteAuthorReplyBlock(
+	ctx sdk.Context, author types.AccountKey, user types.AccountKey) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(getAuthorReplyBlockKey(author, user))
+}
+
+// GetPostRevision - get post revision from KVStore
+func (ps PostStorage) GetPostRevision(
+	ctx sdk.Context, permlink types.Permlink, revision int64) (*PostRevision, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	revisionBytes := store.Get(GetPostRevisionKey(permlink, revision))
+	if revisionBytes == nil {
+		return nil, ErrPostRevisionNotFound(GetPostRevisionKey(permlink, revision))
+	}
+	postRevision := new(PostRevision)
+	if unmarshalErr := ps.cdc.UnmarshalJSON(revisionBytes, postRevision); unmarshalErr != nil {
+		return nil, ErrFailedToUnmarshalPostRevision(unmarshalErr)
+	}
+	return postRevision, nil
+}
+
+// SetPostRevision - set post revision to KVStore
+func (ps PostStorage) SetPostRevision(
+	ctx sdk.Context, permlink types.Permlink, postRevision *PostRevision) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	revisionBytes, err := ps.cdc.MarshalJSON(*postRevision)
+	if err != nil {
+		return ErrFailedToMarshalPostRevision(err)
+	}
+	store.Set(GetPostRevisionKey(permlink, postRevision.Revision), revisionBytes)
+	return nil
+}
+
+// DeletePostRevision - delete post revision from KVStore
+func (ps PostStorage) DeletePostRevision(ctx sdk.Context, permlink types.Permlink, revision int64) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetPostRevisionKey(permlink, revision))
+}
+
+// GetPostRevisions - get all revisions of a post sorted by revision number
+func (ps PostStorage) GetPostRevisions(
+	ctx sdk.Context, permlink types.Permlink) ([]PostRevision, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetPostRevisionPrefix(permlink))
+	defer iter.Close()
+	revisions := []PostRevision{}
+	for ; iter.Valid(); iter.Next() {
+		postRevision := new(PostRevision)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), postRevision); err != nil {
+			return nil, ErrFailedToUnmarshalPostRevision(err)
+		}
+		revisions = append(revisions, *postRevision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// SetTaggedPost - add post to the tag index
+func (ps PostStorage) SetTaggedPost(ctx sdk.Context, tag string, taggedPost *TaggedPost) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	taggedPostBytes, err := ps.cdc.MarshalJSON(*taggedPost)
+	if err != nil {
+		return ErrFailedToMarshalTaggedPost(err)
+	}
+	store.Set(GetTaggedPostKey(tag, taggedPost.CreatedAt, taggedPost.Permlink), taggedPostBytes)
+	return nil
+}
+
+// DeleteTaggedPost - remove post from the tag index
+func (ps PostStorage) DeleteTaggedPost(
+	ctx sdk.Context, tag string, createdAt int64, permlink types.Permlink) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetTaggedPostKey(tag, createdAt, permlink))
+}
+
+// GetTaggedPosts - get all posts with the tag ordered by creation time
+func (ps PostStorage) GetTaggedPosts(ctx sdk.Context, tag string) ([]TaggedPost, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetTagIndexPrefix(tag))
+	defer iter.Close()
+	taggedPosts := []TaggedPost{}
+	for ; iter.Valid(); iter.Next() {
+		taggedPost := new(TaggedPost)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), taggedPost); err != nil {
+			return nil, ErrFailedToUnmarshalTaggedPost(err)
+		}
+		taggedPosts = append(taggedPosts, *taggedPost)
+	}
+	return taggedPosts, nil
+}
+
+// GetTagDonationStat - get donation statistic of the tag in the day
+func (ps PostStorage) GetTagDonationStat(ctx sdk.Context, day int64, tag string) (*TagDonationStat, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	statBytes := store.Get(GetTagDonationStatKey(day, tag))
+	if statBytes == nil {
+		return nil, ErrTagDonationStatNotFound(GetTagDonationStatKey(day, tag))
+	}
+	stat := new(TagDonationStat)
+	if err := ps.cdc.UnmarshalJSON(statBytes, stat); err != nil {
+		return nil, ErrFailedToUnmarshalTagDonationStat(err)
+	}
+	return stat, nil
+}
+
+// SetTagDonationStat - set donation statistic of the tag in the day
+func (ps PostStorage) SetTagDonationStat(ctx sdk.Context, stat *TagDonationStat) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	statBytes, err := ps.cdc.MarshalJSON(*stat)
+	if err != nil {
+		return ErrFailedToMarshalTagDonationStat(err)
+	}
+	store.Set(GetTagDonationStatKey(stat.Day, stat.Tag), statBytes)
+	return nil
+}
+
+// GetTagDonationStatsOfDay - get donation statistics of all tags in the day
+func (ps PostStorage) GetTagDonationStatsOfDay(ctx sdk.Context, day int64) ([]TagDonationStat, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetTagDonationStatPrefix(day))
+	defer iter.Close()
+	stats := []TagDonationStat{}
+	for ; iter.Valid(); iter.Next() {
+		stat := new(TagDonationStat)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), stat); err != nil {
+			return nil, ErrFailedToUnmarshalTagDonationStat(err)
+		}
+		stats = append(stats, *stat)
+	}
+	return stats, nil
+}
+
+// DeleteTagDonationStatsBefore - remove donation statistics of all tags in days before the given day
+func (ps PostStorage) DeleteTagDonationStatsBefore(ctx sdk.Context, day int64) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, tagDonationStatSubStore)
+	expiredKeys := [][]byte{}
+	for ; iter.Valid(); iter.Next() {
+		stat := new(TagDonationStat)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), stat); err != nil {
+			iter.Close()
+			return ErrFailedToUnmarshalTagDonationStat(err)
+		}
+		if stat.Day < day {
+			expiredKeys = append(expiredKeys, iter.Key())
+		}
+	}
+	iter.Close()
+	for _, key := range expiredKeys {
+		store.Delete(key)
+	}
+	return nil
+}
+
+// DoesPostPaywallExist - check if a post is paywalled
+func (ps PostStorage) DoesPostPaywallExist(ctx sdk.Context, permlink types.Permlink) bool {
+	store := ctx.KVStore(ps.key)
+	return store.Has(GetPostPaywallKey(permlink))
+}
+
+// GetPostPaywall - get post paywall from KVStore
+func (ps PostStorage) GetPostPaywall(ctx sdk.Context, permlink types.Permlink) (*Paywall, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	paywallBytes := store.Get(GetPostPaywallKey(permlink))
+	if paywallBytes == nil {
+		return nil, ErrPostPaywallNotFound(GetPostPaywallKey(permlink))
+	}
+	paywall := new(Paywall)
+	if unmarshalErr := ps.cdc.UnmarshalJSON(paywallBytes, paywall); unmarshalErr != nil {
+		return nil, ErrFailedToUnmarshalPostPaywall(unmarshalErr)
+	}
+	return paywall, nil
+}
+
+// SetPostPaywall - set post paywall to KVStore
+func (ps PostStorage) SetPostPaywall(ctx sdk.Context, permlink types.Permlink, paywall *Paywall) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	paywallBytes, err := ps.cdc.MarshalJSON(*paywall)
+	if err != nil {
+		return ErrFailedToMarshalPostPaywall(err)
+	}
+	store.Set(GetPostPaywallKey(permlink), paywallBytes)
+	return nil
+}
+
+// GetPostUnlock - get post unlock from KVStore
+func (ps PostStorage) GetPostUnlock(
+	ctx sdk.Context, permlink types.Permlink, user types.AccountKey) (*PostUnlock, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	unlockBytes := store.Get(GetPostUnlockKey(permlink, user))
+	if unlockBytes == nil {
+		return nil, ErrPostUnlockNotFound(GetPostUnlockKey(permlink, user))
+	}
+	postUnlock := new(PostUnlock)
+	if unmarshalErr := ps.cdc.UnmarshalJSON(unlockBytes, postUnlock); unmarshalErr != nil {
+		return nil, ErrFailedToUnmarshalPostUnlock(unmarshalErr)
+	}
+	return postUnlock, nil
+}
+
+// SetPostUnlock - set post unlock to KVStore
+func (ps PostStorage) SetPostUnlock(
+	ctx sdk.Context, permlink types.Permlink, postUnlock *PostUnlock) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	unlockBytes, err := ps.cdc.MarshalJSON(*postUnlock)
+	if err != nil {
+		return ErrFailedToMarshalPostUnlock(err)
+	}
+	store.Set(GetPostUnlockKey(permlink, postUnlock.Username), unlockBytes)
+	return nil
+}
+
+// GetPostReportStat - get post report stat from KVStore
+func (ps PostStorage) GetPostReportStat(
+	ctx sdk.Context, permlink types.Permlink) (*PostReportStat, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	statBytes := store.Get(GetPostReportStatKey(permlink))
+	if statBytes == nil {
+		return nil, ErrPostReportStatNotFound(GetPostReportStatKey(permlink))
+	}
+	stat := new(PostReportStat)
+	if unmarshalErr := ps.cdc.UnmarshalJSON(statBytes, stat); unmarshalErr != nil {
+		return nil, ErrFailedToUnmarshalPostReportStat(unmarshalErr)
+	}
+	return stat, nil
+}
+
+// SetPostReportStat - set post report stat to KVStore
+func (ps PostStorage) SetPostReportStat(ctx sdk.Context, stat *PostReportStat) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	statBytes, err := ps.cdc.MarshalJSON(*stat)
+	if err != nil {
+		return ErrFailedToMarshalPostReportStat(err)
+	}
+	store.Set(GetPostReportStatKey(stat.Permlink), statBytes)
+	return nil
+}
+
+// DeletePostReportStat - delete post report stat from KVStore
+func (ps PostStorage) DeletePostReportStat(ctx sdk.Context, permlink types.Permlink) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetPostReportStatKey(permlink))
+}
+
+// GetPostReportStats - get report stat of all reported posts
+func (ps PostStorage) GetPostReportStats(ctx sdk.Context) ([]PostReportStat, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetPostReportStatPrefix())
+	defer iter.Close()
+	stats := []PostReportStat{}
+	for ; iter.Valid(); iter.Next() {
+		stat := new(PostReportStat)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), stat); err != nil {
+			return nil, ErrFailedToUnmarshalPostReportStat(err)
+		}
+		stats = append(stats, *stat)
+	}
+	return stats, nil
+}
+
+// SetPendingReward - set pending reward to KVStore
+func (ps PostStorage) SetPendingReward(
+	ctx sdk.Context, permlink types.Permlink, pendingReward *PendingReward) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	pendingRewardBytes, err := ps.cdc.MarshalJSON(*pendingReward)
+	if err != nil {
+		return ErrFailedToMarshalPendingReward(err)
+	}
+	ps.DeletePendingReward(ctx, permlink, pendingReward.EventTime)
+	store.Set(GetPendingRewardKey(permlink, pendingReward.EventTime), pendingRewardBytes)
+	for _, user := range pendingReward.Users {
+		store.Set(GetUserPendingRewardKey(user, permlink, pendingReward.EventTime), pendingRewardBytes)
+	}
+	return nil
+}
+
+// DeletePendingReward - delete pending reward and its user index from KVStore
+func (ps PostStorage) DeletePendingReward(ctx sdk.Context, permlink types.Permlink, eventTime int64) {
+	store := ctx.KVStore(ps.key)
+	pendingRewardBytes := store.Get(GetPendingRewardKey(permlink, eventTime))
+	if pendingRewardBytes == nil {
+		return
+	}
+	pendingReward := new(PendingReward)
+	if err := ps.cdc.UnmarshalJSON(pendingRewardBytes, pendingReward); err == nil {
+		for _, user := range pendingReward.Users {
+			store.Delete(GetUserPendingRewardKey(user, permlink, eventTime))
+		}
+	}
+	store.Delete(GetPendingRewardKey(permlink, eventTime))
+}
+
+// GetPendingRewards - get all pending rewards of a post sorted by event time
+func (ps PostStorage) GetPendingRewards(
+	ctx sdk.Context, permlink types.Permlink) ([]PendingReward, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetPendingRewardPrefix(permlink))
+	defer iter.Close()
+	pendingRewards := []PendingReward{}
+	for ; iter.Valid(); iter.Next() {
+		pendingReward := new(PendingReward)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), pendingReward); err != nil {
+			return nil, ErrFailedToUnmarshalPendingReward(err)
+		}
+		pendingRewards = append(pendingRewards, *pendingReward)
+	}
+	sort.Slice(pendingRewards, func(i, j int) bool {
+		return pendingRewards[i].EventTime < pendingRewards[j].EventTime
+	})
+	return pendingRewards, nil
+}
+
+// IterateUserPendingRewards - iterate pending rewards of posts the user is rewarded by
+// until process returns true
+func (ps PostStorage) IterateUserPendingRewards(
+	ctx sdk.Context, username types.AccountKey,
+	process func(pendingReward PendingReward) (stop bool)) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetUserPendingRewardPrefix(username))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		pendingReward := new(PendingReward)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), pendingReward); err != nil {
+			return ErrFailedToUnmarshalPendingReward(err)
+		}
+		if process(*pendingReward) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// GetPostStat - get stat bucket of the post from KVStore
+func (ps PostStorage) GetPostStat(
+	ctx sdk.Context, permlink types.Permlink, period types.StatPeriod, startDay int64) (*PostStat, sdk.Error) {
+	return ps.getStat(ctx, GetPostStatKey(permlink, period, startDay))
+}
+
+// SetPostStat - set stat bucket of the post to KVStore
+func (ps PostStorage) SetPostStat(ctx sdk.Context, permlink types.Permlink, stat *PostStat) sdk.Error {
+	return ps.setStat(ctx, GetPostStatKey(permlink, stat.Period, stat.StartDay), stat)
+}
+
+// DeletePostStat - delete stat bucket of the post from KVStore
+func (ps PostStorage) DeletePostStat(
+	ctx sdk.Context, permlink types.Permlink, period types.StatPeriod, startDay int64) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetPostStatKey(permlink, period, startDay))
+}
+
+// GetPostStats - get all stat buckets of the post sorted by start day
+func (ps PostStorage) GetPostStats(ctx sdk.Context, permlink types.Permlink) ([]PostStat, sdk.Error) {
+	return ps.getStats(ctx, GetPostStatPrefix(permlink))
+}
+
+// GetAuthorStat - get stat bucket of the author from KVStore
+func (ps PostStorage) GetAuthorStat(
+	ctx sdk.Context, author types.AccountKey, period types.StatPeriod, startDay int64) (*PostStat, sdk.Error) {
+	return ps.getStat(ctx, GetAuthorStatKey(author, period, startDay))
+}
+
+// SetAuthorStat - set stat bucket of the author to KVStore
+func (ps PostStorage) SetAuthorStat(ctx sdk.Context, author types.AccountKey, stat *PostStat) sdk.Error {
+	return ps.setStat(ctx, GetAuthorStatKey(author, stat.Period, stat.StartDay), stat)
+}
+
+// DeleteAuthorStat - delete stat bucket of the author from KVStore
+func (ps PostStorage) DeleteAuthorStat(
+	ctx sdk.Context, author types.AccountKey, period types.StatPeriod, startDay int64) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetAuthorStatKey(author, period, startDay))
+}
+
+// GetAuthorStats - get all stat buckets of the author sorted by start day
+func (ps PostStorage) GetAuthorStats(ctx sdk.Context, author types.AccountKey) ([]PostStat, sdk.Error) {
+	return ps.getStats(ctx, GetAuthorStatPrefix(author))
+}
+
+func (ps PostStorage) getStat(ctx sdk.Context, key []byte) (*PostStat, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	statBytes := store.Get(key)
+	if statBytes == nil {
+		return nil, ErrPostStatNotFound(key)
+	}
+	stat := new(PostStat)
+	if err := ps.cdc.UnmarshalJSON(statBytes, stat); err != nil {
+		return nil, ErrFailedToUnmarshalPostStat(err)
+	}
+	return stat, nil
+}
+
+func (ps PostStorage) setStat(ctx sdk.Context, key []byte, stat *PostStat) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	statBytes, err := ps.cdc.MarshalJSON(*stat)
+	if err != nil {
+		return ErrFailedToMarshalPostStat(err)
+	}
+	store.Set(key, statBytes)
+	return nil
+}
+
+func (ps PostStorage) getStats(ctx sdk.Context, prefix []byte) ([]PostStat, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	stats := []PostStat{}
+	for ; iter.Valid(); iter.Next() {
+		stat := new(PostStat)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), stat); err != nil {
+			return nil, ErrFailedToUnmarshalPostStat(err)
+		}
+		stats = append(stats, *stat)
+	}
+	return stats, nil
+}
+
+// SetStatIndex - add stat bucket to stat index
+func (ps PostStorage) SetStatIndex(ctx sdk.Context, index *StatIndex) sdk.Error {
+	store := ctx.KVStore(ps.key)
+	indexBytes, err := ps.cdc.MarshalJSON(*index)
+	if err != nil {
+		return ErrFailedToMarshalStatIndex(err)
+	}
+	store.Set(GetStatIndexKey(index), indexBytes)
+	return nil
+}
+
+// DeleteStatIndex - remove stat bucket from stat index
+func (ps PostStorage) DeleteStatIndex(ctx sdk.Context, index *StatIndex) {
+	store := ctx.KVStore(ps.key)
+	store.Delete(GetStatIndexKey(index))
+}
+
+// GetStatIndexesBefore - get index of all stat buckets in the period start before the given day
+func (ps PostStorage) GetStatIndexesBefore(
+	ctx sdk.Context, period types.StatPeriod, day int64) ([]StatIndex, sdk.Error) {
+	store := ctx.KVStore(ps.key)
+	iter := sdk.KVStorePrefixIterator(store, GetStatIndexPrefix(period))
+	defer iter.Close()
+	indexes := []StatIndex{}
+	for ; iter.Valid(); iter.Next() {
+		index := new(StatIndex)
+		if err := ps.cdc.UnmarshalJSON(iter.Value(), index); err != nil {
+			return nil, ErrFailedToUnmarshalStatIndex(err)
+		}
+		if index.StartDay >= day {
+			break
+		}
+		indexes = append(indexes, *index)
+	}
+	return indexes, nil
+}
+
 // GetPostInfoPrefix - "post info substore" + "author"
 func GetPostInfoPrefix(author types.AccountKey) []byte {
 	return append(postInfoSubStore, author...)
@@ -260,3 +817,165 @@ func getPostDonationsPrefix(permlink types.Permlink) []byte {
 func getPostDonationKey(permlink types.Permlink, donateUser types.AccountKey) []byte {
 	return append(getPostDonationsPrefix(permlink), donateUser...)
 }
+
+// GetPostTombstoneKey - "post tombstone substore" + "permlink"
+func GetPostTombstoneKey(permlink types.Permlink) []byte {
+	return append(postTombstoneSubStore, permlink...)
+}
+
+// GetPostReplyBlockPrefix - "post reply block substore" + "permlink"
+// which can be used to access all users blocked from replying this post
+func GetPostReplyBlockPrefix(permlink types.Permlink) []byte {
+	return append(append(postReplyBlockSubStore, permlink...), types.KeySeparator...)
+}
+
+// getPostReplyBlockKey - "post reply block substore" + "permlink" + "user"
+func getPostReplyBlockKey(permlink types.Permlink, user types.AccountKey) []byte {
+	return append(GetPostReplyBlockPrefix(permlink), user...)
+}
+
+// GetAuthorReplyBlockPrefix - "author reply block substore" + "author"
+// which can be used to access all users blocked from replying this author
+func GetAuthorReplyBlockPrefix(author types.AccountKey) []byte {
+	return append(append(authorReplyBlockSubStore, author...), types.KeySeparator...)
+}
+
+// getAuthorReplyBlockKey - "author reply block substore" + "author" + "user"
+func getAuthorReplyBlockKey(author types.AccountKey, user types.AccountKey) []byte {
+	return append(GetAuthorReplyBlockPrefix(author), user...)
+}
+
+// GetPostRevisionPrefix - "post revision substore" + "length prefixed permlink"
+// which can be used to access all revisions belong to this post
+func GetPostRevisionPrefix(permlink types.Permlink) []byte {
+	return append(append(postRevisionSubStore, getPermlinkKeyPart(permlink)...), types.KeySeparator...)
+}
+
+// GetPostRevisionKey - "post revision substore" + "permlink" + "revision"
+func GetPostRevisionKey(permlink types.Permlink, revision int64) []byte {
+	return append(GetPostRevisionPrefix(permlink), strconv.FormatInt(revision, 10)...)
+}
+
+// GetTagIndexPrefix - "tag index substore" + "tag"
+// which can be used to access all posts with this tag
+func GetTagIndexPrefix(tag string) []byte {
+	return append(append(tagIndexSubStore, tag...), types.KeySeparator...)
+}
+
+// GetTaggedPostKey - "tag index substore" + "tag" + "created at" + "permlink",
+// created at is zero padded so posts are ordered by creation time
+func GetTaggedPostKey(tag string, createdAt int64, permlink types.Permlink) []byte {
+	return append(
+		append(GetTagIndexPrefix(tag), fmt.Sprintf("%020d", createdAt)...), permlink...)
+}
+
+// GetTagDonationStatPrefix - "tag donation stat substore" + "day"
+// which can be used to access donation statistics of all tags in this day
+func GetTagDonationStatPrefix(day int64) []byte {
+	return append(append(tagDonationStatSubStore, strconv.FormatInt(day, 10)...), types.KeySeparator...)
+}
+
+// GetTagDonationStatKey - "tag donation stat substore" + "day" + "tag"
+func GetTagDonationStatKey(day int64, tag string) []byte {
+	return append(GetTagDonationStatPrefix(day), tag...)
+}
+
+// GetPostPaywallKey - "post paywall substore" + "permlink"
+func GetPostPaywallKey(permlink types.Permlink) []byte {
+	return append(postPaywallSubStore, permlink...)
+}
+
+// GetPostUnlockPrefix - "post unlock substore" + "permlink"
+// which can be used to access all unlocks belong to this post
+func GetPostUnlockPrefix(permlink types.Permlink) []byte {
+	return append(append(postUnlockSubStore, permlink...), types.KeySeparator...)
+}
+
+// GetPostUnlockKey - "post unlock substore" + "permlink" + "user"
+func GetPostUnlockKey(permlink types.Permlink, user types.AccountKey) []byte {
+	return append(GetPostUnlockPrefix(permlink), user...)
+}
+
+// GetPostReportStatPrefix - "post report stat substore"
+// which can be used to access report stat of all reported posts
+func GetPostReportStatPrefix() []byte {
+	return postReportStatSubStore
+}
+
+// GetPostReportStatKey - "post report stat substore" + "permlink"
+func GetPostReportStatKey(permlink types.Permlink) []byte {
+	return append(GetPostReportStatPrefix(), permlink...)
+}
+
+// GetPendingRewardPrefix - "post pending reward substore" + "length prefixed permlink"
+// which can be used to access all pending rewards belong to this post
+func GetPendingRewardPrefix(permlink types.Permlink) []byte {
+	return append(append(postPendingRewardSubStore, getPermlinkKeyPart(permlink)...), types.KeySeparator...)
+}
+
+// GetPendingRewardKey - "post pending reward substore" + "permlink" + "event time"
+func GetPendingRewardKey(permlink types.Permlink, eventTime int64) []byte {
+	return append(GetPendingRewardPrefix(permlink), strconv.FormatInt(eventTime, 10)...)
+}
+
+// GetUserPendingRewardPrefix - "user pending reward substore" + "username"
+func GetUserPendingRewardPrefix(username types.AccountKey) []byte {
+	return append(append(userPendingRewardSubStore, username...), types.KeySeparator...)
+}
+
+// GetUserPendingRewardKey - "user pending reward substore" + "username" + "length prefixed permlink" + "event time"
+func GetUserPendingRewardKey(username types.AccountKey, permlink types.Permlink, eventTime int64) []byte {
+	return append(append(append(GetUserPendingRewardPrefix(username), getPermlinkKeyPart(permlink)...),
+		types.KeySeparator...), strconv.FormatInt(eventTime, 10)...)
+}
+
+// GetPostStatPrefix - "post stat substore" + "length prefixed permlink"
+// which can be used to access all stat buckets belong to this post
+func GetPostStatPrefix(permlink types.Permlink) []byte {
+	return append(append(postStatSubStore, getPermlinkKeyPart(permlink)...), types.KeySeparator...)
+}
+
+// GetPostStatKey - "post stat substore" + "permlink" + "start day" + "period",
+// start day is zero padded so buckets are ordered by start day
+func GetPostStatKey(permlink types.Permlink, period types.StatPeriod, startDay int64) []byte {
+	return append(GetPostStatPrefix(permlink), getStatBucketSuffix(period, startDay)...)
+}
+
+// GetAuthorStatPrefix - "author stat substore" + "author"
+// which can be used to access all stat buckets belong to this author
+func GetAuthorStatPrefix(author types.AccountKey) []byte {
+	return append(append(authorStatSubStore, author...), types.KeySeparator...)
+}
+
+// GetAuthorStatKey - "author stat substore" + "author" + "start day" + "period",
+// start day is zero padded so buckets are ordered by start day
+func GetAuthorStatKey(author types.AccountKey, period types.StatPeriod, startDay int64) []byte {
+	return append(GetAuthorStatPrefix(author), getStatBucketSuffix(period, startDay)...)
+}
+
+// GetStatIndexPrefix - "stat index substore" + "period"
+// which can be used to access all stat buckets in this period
+func GetStatIndexPrefix(period types.StatPeriod) []byte {
+	return append(append(statIndexSubStore, strconv.FormatInt(int64(period), 10)...), types.KeySeparator...)
+}
+
+// GetStatIndexKey - "stat index substore" + "period" + "start day" + "stat key",
+// start day is zero padded so buckets are ordered by start day
+func GetStatIndexKey(index *StatIndex) []byte {
+	statKey := GetAuthorStatKey(index.Author, index.Period, index.StartDay)
+	if index.Permlink != "" {
+		statKey = GetPostStatKey(index.Permlink, index.Period, index.StartDay)
+	}
+	return append(
+		append(GetStatIndexPrefix(index.Period), fmt.Sprintf("%020d", index.StartDay)...), statKey...)
+}
+
+func getStatBucketSuffix(period types.StatPeriod, startDay int64) string {
+	return fmt.Sprintf("%020d", startDay) + types.KeySeparator + strconv.FormatInt(int64(period), 10)
+}
+
+// getPermlinkKeyPart - "permlink length" + ":" + "permlink", post id may contain
+// key separator, length prefix keeps prefix of a post from covering keys of another post
+func getPermlinkKeyPart(permlink types.Permlink) string {
+	return strconv.Itoa(len(permlink)) + ":" + string(permlink)
+}
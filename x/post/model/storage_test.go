@@ -126,6 +126,97 @@ func TestPostDonate(t *testing.T) {
 	})
 }
 
+func TestCensoredPost(t *testing.T) {
+	permlink := types.GetPermlink("author", "postID")
+	censoredPost := CensoredPost{
+		PostInfo: PostInfo{
+			PostID:  "postID",
+			Title:   "title",
+			Content: "content",
+			Author:  types.AccountKey("author"),
+			Links:   nil,
+		},
+		RedistributionSplitRate: sdk.NewRat(1, 2),
+		ProposalID:              types.ProposalKey("1"),
+		CensoredAt:              100,
+	}
+
+	runTest(t, func(env TestEnv) {
+		assert.False(t, env.ps.DoesCensoredPostExist(env.ctx, permlink))
+		_, err := env.ps.GetCensoredPost(env.ctx, permlink)
+		assert.Equal(t, ErrCensoredPostNotFound(GetPostTombstoneKey(permlink)), err)
+
+		err = env.ps.SetCensoredPost(env.ctx, permlink, &censoredPost)
+		assert.Nil(t, err)
+		assert.True(t, env.ps.DoesCensoredPostExist(env.ctx, permlink))
+
+		resultPtr, err := env.ps.GetCensoredPost(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, censoredPost, *resultPtr, "Censored post should be equal")
+
+		env.ps.DeleteCensoredPost(env.ctx, permlink)
+		assert.False(t, env.ps.DoesCensoredPostExist(env.ctx, permlink))
+	})
+}
+
+func TestPostRevision(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		permlink := types.GetPermlink("author", "postID")
+		revisions := []PostRevision{}
+		for i := int64(12); i > 8; i-- {
+			revision := PostRevision{Revision: i, Title: "title", Content: "content", CreatedAt: i}
+			err := env.ps.SetPostRevision(env.ctx, permlink, &revision)
+			assert.Nil(t, err)
+			revisions = append([]PostRevision{revision}, revisions...)
+		}
+
+		resultPtr, err := env.ps.GetPostRevision(env.ctx, permlink, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, revisions[1], *resultPtr)
+
+		// revisions are sorted by number instead of key
+		result, err := env.ps.GetPostRevisions(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, revisions, result)
+
+		// revisions of a post whose id extends the post id with separator are excluded
+		otherPermlink := types.GetPermlink("author", "postID"+types.KeySeparator+"1")
+		err = env.ps.SetPostRevision(env.ctx, otherPermlink, &PostRevision{Revision: 1, Title: "other"})
+		assert.Nil(t, err)
+		result, err = env.ps.GetPostRevisions(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, revisions, result)
+
+		env.ps.DeletePostRevision(env.ctx, permlink, 10)
+		_, err = env.ps.GetPostRevision(env.ctx, permlink, 10)
+		assert.Equal(t, ErrPostRevisionNotFound(GetPostRevisionKey(permlink, 10)), err)
+	})
+}
+
+func TestReplyBlock(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		author := types.AccountKey("author")
+		user := types.AccountKey("user")
+		permlink := types.GetPermlink(author, "postID")
+		blockedReplier := BlockedReplier{Username: user, BlockedAt: 1}
+
+		assert.False(t, env.ps.IsBlockedFromPostReply(env.ctx, permlink, user))
+		err := env.ps.SetPostReplyBlock(env.ctx, permlink, &blockedReplier)
+		assert.Nil(t, err)
+		assert.True(t, env.ps.IsBlockedFromPostReply(env.ctx, permlink, user))
+		assert.False(t, env.ps.IsBlockedFromAuthorReply(env.ctx, author, user))
+		env.ps.DeletePostReplyBlock(env.ctx, permlink, user)
+		assert.False(t, env.ps.IsBlockedFromPostReply(env.ctx, permlink, user))
+
+		err = env.ps.SetAuthorReplyBlock(env.ctx, author, &blockedReplier)
+		assert.Nil(t, err)
+		assert.True(t, env.ps.IsBlockedFromAuthorReply(env.ctx, author, user))
+		assert.False(t, env.ps.IsBlockedFromPostReply(env.ctx, permlink, user))
+		env.ps.DeleteAuthorReplyBlock(env.ctx, author, user)
+		assert.False(t, env.ps.IsBlockedFromAuthorReply(env.ctx, author, user))
+	})
+}
+
 //
 // Test Environment setup
 //
@@ -151,3 +242,206 @@ func getContext() sdk.Context {
 
 	return sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
 }
+
+func TestPostPaywall(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		user := types.AccountKey("user")
+		permlink := types.GetPermlink("author", "postID")
+		paywall := Paywall{PaywallType: types.PayPerView, Price: types.NewCoinFromInt64(100)}
+
+		assert.False(t, env.ps.DoesPostPaywallExist(env.ctx, permlink))
+		_, err := env.ps.GetPostPaywall(env.ctx, permlink)
+		assert.Equal(t, ErrPostPaywallNotFound(GetPostPaywallKey(permlink)), err)
+		err = env.ps.SetPostPaywall(env.ctx, permlink, &paywall)
+		assert.Nil(t, err)
+		assert.True(t, env.ps.DoesPostPaywallExist(env.ctx, permlink))
+		resultPtr, err := env.ps.GetPostPaywall(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, paywall, *resultPtr)
+
+		postUnlock := PostUnlock{Username: user, UnlockedAt: 1, Times: 2, RemainingViews: 1}
+		_, err = env.ps.GetPostUnlock(env.ctx, permlink, user)
+		assert.Equal(t, ErrPostUnlockNotFound(GetPostUnlockKey(permlink, user)), err)
+		err = env.ps.SetPostUnlock(env.ctx, permlink, &postUnlock)
+		assert.Nil(t, err)
+		unlockPtr, err := env.ps.GetPostUnlock(env.ctx, permlink, user)
+		assert.Nil(t, err)
+		assert.Equal(t, postUnlock, *unlockPtr)
+	})
+}
+
+func TestPostReportStat(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		permlink1 := types.GetPermlink("author", "postID1")
+		permlink2 := types.GetPermlink("author", "postID2")
+		stat1 := PostReportStat{
+			Permlink:     permlink1,
+			ReportCount:  2,
+			ReportWeight: types.NewCoinFromInt64(100),
+			ReasonCounts: []ReportReasonCount{{Reason: types.SpamReport, Count: 2}},
+			LastReportAt: 1,
+		}
+		stat2 := PostReportStat{
+			Permlink:     permlink2,
+			ReportCount:  1,
+			ReportWeight: types.NewCoinFromInt64(10),
+			ReasonCounts: []ReportReasonCount{{Reason: types.AbuseReport, Count: 1}},
+			LastReportAt: 2,
+		}
+
+		_, err := env.ps.GetPostReportStat(env.ctx, permlink1)
+		assert.Equal(t, ErrPostReportStatNotFound(GetPostReportStatKey(permlink1)), err)
+		assert.Nil(t, env.ps.SetPostReportStat(env.ctx, &stat1))
+		assert.Nil(t, env.ps.SetPostReportStat(env.ctx, &stat2))
+		statPtr, err := env.ps.GetPostReportStat(env.ctx, permlink1)
+		assert.Nil(t, err)
+		assert.Equal(t, stat1, *statPtr)
+
+		stats, err := env.ps.GetPostReportStats(env.ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, []PostReportStat{stat1, stat2}, stats)
+
+		env.ps.DeletePostReportStat(env.ctx, permlink1)
+		stats, err = env.ps.GetPostReportStats(env.ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, []PostReportStat{stat2}, stats)
+	})
+}
+
+func TestPendingReward(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		permlink := types.GetPermlink("author", "postID")
+		pendingReward1 := PendingReward{EventTime: 100, DonatedAt: 1}
+		pendingReward2 := PendingReward{EventTime: 1000, DonatedAt: 10}
+
+		pendingRewards, err := env.ps.GetPendingRewards(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PendingReward{}, pendingRewards)
+		assert.Nil(t, env.ps.SetPendingReward(env.ctx, permlink, &pendingReward2))
+		assert.Nil(t, env.ps.SetPendingReward(env.ctx, permlink, &pendingReward1))
+		pendingRewards, err = env.ps.GetPendingRewards(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PendingReward{pendingReward1, pendingReward2}, pendingRewards)
+
+		env.ps.DeletePendingReward(env.ctx, permlink, pendingReward1.EventTime)
+		pendingRewards, err = env.ps.GetPendingRewards(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PendingReward{pendingReward2}, pendingRewards)
+
+		// pending rewards of a post whose id extends the post id with separator are excluded
+		otherPermlink := types.GetPermlink("author", "postID"+types.KeySeparator+"1")
+		assert.Nil(t, env.ps.SetPendingReward(env.ctx, otherPermlink, &pendingReward1))
+		pendingRewards, err = env.ps.GetPendingRewards(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PendingReward{pendingReward2}, pendingRewards)
+		env.ps.DeletePendingReward(env.ctx, otherPermlink, pendingReward1.EventTime)
+
+		// pending rewards are indexed by rewarded users
+		permlink2 := types.GetPermlink("author2", "post/ID")
+		pendingReward3 := PendingReward{EventTime: 100, DonatedAt: 1, Users: []types.AccountKey{"author2", "user"}}
+		pendingReward4 := PendingReward{EventTime: 1000, DonatedAt: 10, Users: []types.AccountKey{"author"}}
+		assert.Nil(t, env.ps.SetPendingReward(env.ctx, permlink2, &pendingReward3))
+		assert.Nil(t, env.ps.SetPendingReward(env.ctx, permlink, &pendingReward4))
+		getUserPendingRewards := func(username types.AccountKey) []PendingReward {
+			pendingRewards := []PendingReward{}
+			err := env.ps.IterateUserPendingRewards(
+				env.ctx, username, func(pendingReward PendingReward) bool {
+					pendingRewards = append(pendingRewards, pendingReward)
+					return false
+				})
+			assert.Nil(t, err)
+			return pendingRewards
+		}
+		assert.Equal(t, []PendingReward{pendingReward3}, getUserPendingRewards("author2"))
+		assert.Equal(t, []PendingReward{pendingReward3}, getUserPendingRewards("user"))
+		assert.Equal(t, []PendingReward{pendingReward4}, getUserPendingRewards("author"))
+		assert.Equal(t, []PendingReward{}, getUserPendingRewards("use"))
+
+		// overwritten and deleted records are removed from user index
+		pendingReward3.Users = []types.AccountKey{"author2"}
+		assert.Nil(t, env.ps.SetPendingReward(env.ctx, permlink2, &pendingReward3))
+		assert.Equal(t, []PendingReward{}, getUserPendingRewards("user"))
+		env.ps.DeletePendingReward(env.ctx, permlink2, pendingReward3.EventTime)
+		assert.Equal(t, []PendingReward{}, getUserPendingRewards("author2"))
+	})
+}
+
+func TestPostStat(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		permlink := types.GetPermlink("author", "postID")
+		stat1 := PostStat{
+			StartDay:      7,
+			Period:        types.WeeklyStat,
+			DonateCount:   1,
+			Donation:      types.NewCoinFromInt64(10),
+			UpvoteCoinDay: types.NewCoinFromInt64(0),
+			Reward:        types.NewCoinFromInt64(0),
+		}
+		stat2 := PostStat{
+			StartDay:      15,
+			Period:        types.DailyStat,
+			ViewCount:     1,
+			Donation:      types.NewCoinFromInt64(0),
+			UpvoteCoinDay: types.NewCoinFromInt64(0),
+			Reward:        types.NewCoinFromInt64(1),
+		}
+
+		_, err := env.ps.GetPostStat(env.ctx, permlink, types.DailyStat, 15)
+		assert.Equal(t, ErrPostStatNotFound(GetPostStatKey(permlink, types.DailyStat, 15)), err)
+		assert.Nil(t, env.ps.SetPostStat(env.ctx, permlink, &stat2))
+		assert.Nil(t, env.ps.SetPostStat(env.ctx, permlink, &stat1))
+		assert.Nil(t, env.ps.SetAuthorStat(env.ctx, "author", &stat2))
+		statPtr, err := env.ps.GetPostStat(env.ctx, permlink, types.DailyStat, 15)
+		assert.Nil(t, err)
+		assert.Equal(t, stat2, *statPtr)
+		statPtr, err = env.ps.GetAuthorStat(env.ctx, "author", types.DailyStat, 15)
+		assert.Nil(t, err)
+		assert.Equal(t, stat2, *statPtr)
+
+		stats, err := env.ps.GetPostStats(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PostStat{stat1, stat2}, stats)
+
+		// stats of a post whose id extends the post id with separator are excluded
+		otherPermlink := types.GetPermlink("author", "postID"+types.KeySeparator+"1")
+		assert.Nil(t, env.ps.SetPostStat(env.ctx, otherPermlink, &stat1))
+		stats, err = env.ps.GetPostStats(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PostStat{stat1, stat2}, stats)
+
+		env.ps.DeletePostStat(env.ctx, permlink, types.WeeklyStat, 7)
+		stats, err = env.ps.GetPostStats(env.ctx, permlink)
+		assert.Nil(t, err)
+		assert.Equal(t, []PostStat{stat2}, stats)
+		env.ps.DeleteAuthorStat(env.ctx, "author", types.DailyStat, 15)
+		stats, err = env.ps.GetAuthorStats(env.ctx, "author")
+		assert.Nil(t, err)
+		assert.Equal(t, []PostStat{}, stats)
+	})
+}
+
+func TestStatIndex(t *testing.T) {
+	runTest(t, func(env TestEnv) {
+		// post stats are ordered before author stats in the same day
+		index1 := StatIndex{Author: "author", StartDay: 9, Period: types.DailyStat}
+		index2 := StatIndex{
+			Author: "author", Permlink: types.GetPermlink("author", "postID"), StartDay: 9, Period: types.DailyStat}
+		index3 := StatIndex{Author: "author", StartDay: 10, Period: types.DailyStat}
+		index4 := StatIndex{Author: "author", StartDay: 7, Period: types.WeeklyStat}
+		for _, index := range []StatIndex{index4, index3, index2, index1} {
+			assert.Nil(t, env.ps.SetStatIndex(env.ctx, &index))
+		}
+
+		indexes, err := env.ps.GetStatIndexesBefore(env.ctx, types.DailyStat, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, []StatIndex{index2, index1}, indexes)
+		indexes, err = env.ps.GetStatIndexesBefore(env.ctx, types.DailyStat, 11)
+		assert.Nil(t, err)
+		assert.Equal(t, []StatIndex{index2, index1, index3}, indexes)
+
+		env.ps.DeleteStatIndex(env.ctx, &index1)
+		indexes, err = env.ps.GetStatIndexesBefore(env.ctx, types.DailyStat, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, []StatIndex{index2}, indexes)
+	})
+}
@@ -1,10 +1,14 @@
 package post
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/post/model"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -15,28 +19,43 @@ var _ types.Msg = DeletePostMsg{}
 var _ types.Msg = DonateMsg{}
 var _ types.Msg = ReportOrUpvoteMsg{}
 var _ types.Msg = ViewMsg{}
+var _ types.Msg = UpdateReplyBlocklistMsg{}
+var _ types.Msg = UnlockPostMsg{}
+var _ types.Msg = BatchDonateMsg{}
+
+const (
+	base58Alphabet      = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base32LowerAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
 
 // CreatePostMsg contains information to create a post
 type CreatePostMsg struct {
-	Author                  types.AccountKey       `json:"author"`
-	PostID                  string                 `json:"post_id"`
-	Title                   string                 `json:"title"`
-	Content                 string                 `json:"content"`
-	ParentAuthor            types.AccountKey       `json:"parent_author"`
-	ParentPostID            string                 `json:"parent_postID"`
-	SourceAuthor            types.AccountKey       `json:"source_author"`
-	SourcePostID            string                 `json:"source_postID"`
-	Links                   []types.IDToURLMapping `json:"links"`
-	RedistributionSplitRate string                 `json:"redistribution_split_rate"`
-}
-
-// UpdatePostMsg - update post
+	Author                  types.AccountKey         `json:"author"`
+	PostID                  string                   `json:"post_id"`
+	Title                   string                   `json:"title"`
+	Content                 string                   `json:"content"`
+	ParentAuthor            types.AccountKey         `json:"parent_author"`
+	ParentPostID            string                   `json:"parent_postID"`
+	SourceAuthor            types.AccountKey         `json:"source_author"`
+	SourcePostID            string                   `json:"source_postID"`
+	Links                   []types.IDToURLMapping   `json:"links"`
+	RedistributionSplitRate string                   `json:"redistribution_split_rate"`
+	ReplyPermission         types.ReplyPermission    `json:"reply_permission"`
+	Tags                    []string                 `json:"tags"`
+	Beneficiaries           []types.Beneficiary      `json:"beneficiaries"`
+	PaywallType             types.PaywallType        `json:"paywall_type"`
+	UnlockPrice             types.LNO                `json:"unlock_price"`
+	ContentRefs             []types.ContentReference `json:"content_refs"`
+}
+
+// UpdatePostMsg - update post, reply permission is kept if it's not set
 type UpdatePostMsg struct {
-	Author  types.AccountKey       `json:"author"`
-	PostID  string                 `json:"post_id"`
-	Title   string                 `json:"title"`
-	Content string                 `json:"content"`
-	Links   []types.IDToURLMapping `json:"links"`
+	Author          types.AccountKey       `json:"author"`
+	PostID          string                 `json:"post_id"`
+	Title           string                 `json:"title"`
+	Content         string                 `json:"content"`
+	Links           []types.IDToURLMapping `json:"links"`
+	ReplyPermission *types.ReplyPermission `json:"reply_permission,omitempty"`
 }
 
 // DeletePostMsg - sent from a user to a post
@@ -64,10 +83,45 @@ type ViewMsg struct {
 
 // ReportOrUpvoteMsg - sent from a user to a post
 type ReportOrUpvoteMsg struct {
+	Username types.AccountKey   `json:"username"`
+	Author   types.AccountKey   `json:"author"`
+	PostID   string             `json:"post_id"`
+	IsReport bool               `json:"is_report"`
+	Reason   types.ReportReason `json:"reason"`
+	Memo     string             `json:"memo"`
+}
+
+// UpdateReplyBlocklistMsg - sent from author to block or unblock a user from
+// replying the post, or all posts of the author if post ID is empty
+type UpdateReplyBlocklistMsg struct {
+	Author    types.AccountKey `json:"author"`
+	PostID    string           `json:"post_id"`
+	Username  types.AccountKey `json:"username"`
+	IsBlocked bool             `json:"is_blocked"`
+}
+
+// BatchDonateMsg - sent from a user to donate to many posts in one transaction
+type BatchDonateMsg struct {
+	Username  types.AccountKey `json:"username"`
+	FromApp   types.AccountKey `json:"from_app"`
+	Donations []BatchDonation  `json:"donations"`
+}
+
+// BatchDonation - a donation to a post in BatchDonateMsg
+type BatchDonation struct {
+	Author types.AccountKey `json:"author"`
+	PostID string           `json:"post_id"`
+	Amount types.LNO        `json:"amount"`
+	Memo   string           `json:"memo"`
+}
+
+// UnlockPostMsg - sent from a user to pay the unlock price of a paywalled post
+type UnlockPostMsg struct {
 	Username types.AccountKey `json:"username"`
+	Amount   types.LNO        `json:"amount"`
 	Author   types.AccountKey `json:"author"`
 	PostID   string           `json:"post_id"`
-	IsReport bool             `json:"is_report"`
+	FromApp  types.AccountKey `json:"from_app"`
 }
 
 // NewCreatePostMsg - constructs a post msg
@@ -131,6 +185,15 @@ func NewDonateMsg(
 	}
 }
 
+// NewBatchDonateMsg - constructs a batch donate msg
+func NewBatchDonateMsg(user string, fromApp string, donations []BatchDonation) BatchDonateMsg {
+	return BatchDonateMsg{
+		Username:  types.AccountKey(user),
+		FromApp:   types.AccountKey(fromApp),
+		Donations: donations,
+	}
+}
+
 // NewReportOrUpvoteMsg - constructs a ReportOrUpvote msg
 func NewReportOrUpvoteMsg(
 	user, author, postID string, isReport bool) ReportOrUpvoteMsg {
@@ -143,6 +206,29 @@ func NewReportOrUpvoteMsg(
 	}
 }
 
+// NewUpdateReplyBlocklistMsg - constructs a UpdateReplyBlocklist msg
+func NewUpdateReplyBlocklistMsg(
+	author, postID, user string, isBlocked bool) UpdateReplyBlocklistMsg {
+	return UpdateReplyBlocklistMsg{
+		Author:    types.AccountKey(author),
+		PostID:    postID,
+		Username:  types.AccountKey(user),
+		IsBlocked: isBlocked,
+	}
+}
+
+// NewUnlockPostMsg - constructs a UnlockPost msg
+func NewUnlockPostMsg(
+	user string, amount types.LNO, author, postID, fromApp string) UnlockPostMsg {
+	return UnlockPostMsg{
+		Username: types.AccountKey(user),
+		Amount:   amount,
+		Author:   types.AccountKey(author),
+		PostID:   postID,
+		FromApp:  types.AccountKey(fromApp),
+	}
+}
+
 // Type - implements sdk.Msg
 func (msg CreatePostMsg) Type() string { return types.PostRouterName }
 
@@ -161,6 +247,15 @@ func (msg ReportOrUpvoteMsg) Type() string { return types.PostRouterName }
 // Type - implements sdk.Msg
 func (msg ViewMsg) Type() string { return types.PostRouterName }
 
+// Type - implements sdk.Msg
+func (msg UpdateReplyBlocklistMsg) Type() string { return types.PostRouterName }
+
+// Type - implements sdk.Msg
+func (msg UnlockPostMsg) Type() string { return types.PostRouterName }
+
+// Type - implements sdk.Msg
+func (msg BatchDonateMsg) Type() string { return types.PostRouterName }
+
 // ValidateBasic - implements sdk.Msg
 func (msg CreatePostMsg) ValidateBasic() sdk.Error {
 	// Ensure permlink exists
@@ -207,6 +302,31 @@ func (msg CreatePostMsg) ValidateBasic() sdk.Error {
 	if splitRate.LT(sdk.ZeroRat()) || splitRate.GT(sdk.OneRat()) {
 		return ErrInvalidPostRedistributionSplitRate()
 	}
+	if !isValidReplyPermission(msg.ReplyPermission) {
+		return ErrInvalidReplyPermission()
+	}
+	if len(msg.Tags) > types.MaximumNumOfTags {
+		return ErrTooManyTags()
+	}
+	for i, tag := range msg.Tags {
+		if !isValidTag(tag) {
+			return ErrInvalidTag(tag)
+		}
+		for _, prevTag := range msg.Tags[:i] {
+			if prevTag == tag {
+				return ErrInvalidTag(tag)
+			}
+		}
+	}
+	if _, err := parseBeneficiaries(msg.Beneficiaries); err != nil {
+		return err
+	}
+	if _, err := parsePaywall(msg.PaywallType, msg.UnlockPrice); err != nil {
+		return err
+	}
+	if err := validateContentRefs(msg.ContentRefs); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -234,6 +354,9 @@ func (msg UpdatePostMsg) ValidateBasic() sdk.Error {
 			return ErrURLLengthTooLong()
 		}
 	}
+	if msg.ReplyPermission != nil && !isValidReplyPermission(*msg.ReplyPermission) {
+		return ErrInvalidReplyPermission()
+	}
 	return nil
 }
 
@@ -270,6 +393,36 @@ func (msg DonateMsg) ValidateBasic() sdk.Error {
 	return nil
 }
 
+// ValidateBasic - implements sdk.Msg
+func (msg BatchDonateMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) == 0 {
+		return ErrNoUsername()
+	}
+	if len(msg.Donations) == 0 {
+		return ErrNoDonationInBatch()
+	}
+	if len(msg.Donations) > types.MaximumNumOfDonationsInBatch {
+		return ErrTooManyDonationsInBatch()
+	}
+	for i, donation := range msg.Donations {
+		if len(donation.Author) == 0 || len(donation.PostID) == 0 {
+			return ErrInvalidTarget()
+		}
+		if _, err := types.LinoToCoin(donation.Amount); err != nil {
+			return err
+		}
+		if utf8.RuneCountInString(donation.Memo) > types.MaximumMemoLength {
+			return ErrInvalidMemo()
+		}
+		for _, prevDonation := range msg.Donations[:i] {
+			if prevDonation.Author == donation.Author && prevDonation.PostID == donation.PostID {
+				return ErrDuplicateDonationInBatch(types.GetPermlink(donation.Author, donation.PostID))
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateBasic - implements sdk.Msg
 func (msg ReportOrUpvoteMsg) ValidateBasic() sdk.Error {
 	if len(msg.Username) == 0 {
@@ -278,6 +431,12 @@ func (msg ReportOrUpvoteMsg) ValidateBasic() sdk.Error {
 	if len(msg.Author) == 0 || len(msg.PostID) == 0 {
 		return ErrInvalidTarget()
 	}
+	if !isValidReportReason(msg.Reason) || (!msg.IsReport && msg.Reason != types.UnspecifiedReport) {
+		return ErrInvalidReportReason()
+	}
+	if utf8.RuneCountInString(msg.Memo) > types.MaximumMemoLength {
+		return ErrInvalidMemo()
+	}
 	return nil
 }
 
@@ -292,6 +451,186 @@ func (msg ViewMsg) ValidateBasic() sdk.Error {
 	return nil
 }
 
+// ValidateBasic - implements sdk.Msg
+func (msg UpdateReplyBlocklistMsg) ValidateBasic() sdk.Error {
+	if len(msg.Author) == 0 {
+		return ErrNoAuthor()
+	}
+	if len(msg.PostID) > types.MaximumLengthOfPostID {
+		return ErrPostIDTooLong()
+	}
+	if len(msg.Username) == 0 {
+		return ErrNoUsername()
+	}
+	if msg.Username == msg.Author {
+		return ErrCannotBlockSelf(msg.Author)
+	}
+	return nil
+}
+
+// ValidateBasic - implements sdk.Msg
+func (msg UnlockPostMsg) ValidateBasic() sdk.Error {
+	if len(msg.Username) == 0 {
+		return ErrNoUsername()
+	}
+	if len(msg.Author) == 0 || len(msg.PostID) == 0 {
+		return ErrInvalidTarget()
+	}
+	if msg.Username == msg.Author {
+		return ErrCannotUnlockOwnPost()
+	}
+	if _, err := types.LinoToCoin(msg.Amount); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NormalizeTag - remove leading "#" and surrounding spaces and lowercase the tag
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// isValidTag - tag must be normalized and only contains lowercase letters, digits, "-" and "_"
+func isValidTag(tag string) bool {
+	if len(tag) == 0 || len(tag) > types.MaximumLengthOfTag {
+		return false
+	}
+	for _, c := range tag {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBeneficiaries - parse beneficiary weights, weights must be positive and sum to 1,
+// empty beneficiary list means all donations go to the author
+func parseBeneficiaries(beneficiaries []types.Beneficiary) ([]model.Beneficiary, sdk.Error) {
+	if len(beneficiaries) > types.MaximumNumOfBeneficiaries {
+		return nil, ErrTooManyBeneficiaries()
+	}
+	if len(beneficiaries) == 0 {
+		return nil, nil
+	}
+	res := []model.Beneficiary{}
+	totalWeight := sdk.ZeroRat()
+	for i, beneficiary := range beneficiaries {
+		if len(beneficiary.Username) == 0 || len(beneficiary.Weight) > types.MaximumSdkRatLength {
+			return nil, ErrInvalidBeneficiary(beneficiary.Username)
+		}
+		for _, prev := range beneficiaries[:i] {
+			if prev.Username == beneficiary.Username {
+				return nil, ErrInvalidBeneficiary(beneficiary.Username)
+			}
+		}
+		weight, err := sdk.NewRatFromDecimal(beneficiary.Weight, types.NewRatFromDecimalPrecision)
+		if err != nil || !weight.GT(sdk.ZeroRat()) {
+			return nil, ErrInvalidBeneficiary(beneficiary.Username)
+		}
+		totalWeight = totalWeight.Add(weight)
+		res = append(res, model.Beneficiary{Username: beneficiary.Username, Weight: weight})
+	}
+	if !totalWeight.Equal(sdk.OneRat()) {
+		return nil, ErrInvalidBeneficiaryWeights()
+	}
+	return res, nil
+}
+
+// parsePaywall - parse unlock price, paywalled post must have positive price
+// and post without paywall must not have price
+func parsePaywall(paywallType types.PaywallType, unlockPrice types.LNO) (types.Coin, sdk.Error) {
+	switch paywallType {
+	case types.NoPaywall:
+		if unlockPrice != "" {
+			return types.NewCoinFromInt64(0), ErrInvalidPaywall()
+		}
+		return types.NewCoinFromInt64(0), nil
+	case types.OneTimeUnlock, types.PayPerView:
+		price, err := types.LinoToCoin(unlockPrice)
+		if err != nil {
+			return types.NewCoinFromInt64(0), ErrInvalidPaywall()
+		}
+		return price, nil
+	default:
+		return types.NewCoinFromInt64(0), ErrInvalidPaywall()
+	}
+}
+
+// validateContentRefs - content references must be well formed and unique
+func validateContentRefs(contentRefs []types.ContentReference) sdk.Error {
+	if len(contentRefs) > types.MaximumNumOfContentRefs {
+		return ErrTooManyContentRefs()
+	}
+	for i, contentRef := range contentRefs {
+		if !isValidContentRef(contentRef) {
+			return ErrInvalidContentRef(contentRef.Ref)
+		}
+		for _, prevRef := range contentRefs[:i] {
+			if prevRef.Ref == contentRef.Ref {
+				return ErrInvalidContentRef(contentRef.Ref)
+			}
+		}
+	}
+	return nil
+}
+
+func isValidContentRef(contentRef types.ContentReference) bool {
+	if contentRef.Size <= 0 || !isValidMimeType(contentRef.MimeType) {
+		return false
+	}
+	switch contentRef.RefType {
+	case types.IPFSContentRef:
+		return isValidCID(contentRef.Ref)
+	case types.SHA256ContentRef:
+		digest, err := hex.DecodeString(contentRef.Ref)
+		return err == nil && len(digest) == sha256.Size && strings.ToLower(contentRef.Ref) == contentRef.Ref
+	default:
+		return false
+	}
+}
+
+// isValidCID - accept base58 CIDv0 and base32 CIDv1
+func isValidCID(cid string) bool {
+	if len(cid) > types.MaximumLengthOfContentRef {
+		return false
+	}
+	if len(cid) == 46 && strings.HasPrefix(cid, "Qm") {
+		for _, c := range cid {
+			if !strings.ContainsRune(base58Alphabet, c) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(cid) < 2 || cid[0] != 'b' {
+		return false
+	}
+	for _, c := range cid[1:] {
+		if !strings.ContainsRune(base32LowerAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidMimeType(mimeType string) bool {
+	if len(mimeType) > types.MaximumLengthOfMimeType || strings.ContainsAny(mimeType, " \t\r\n") {
+		return false
+	}
+	pair := strings.SplitN(mimeType, "/", 2)
+	return len(pair) == 2 && len(pair[0]) > 0 && len(pair[1]) > 0 && !strings.Contains(pair[1], "/")
+}
+
+func isValidReportReason(reason types.ReportReason) bool {
+	return reason >= types.UnspecifiedReport && reason <= types.IllegalReport
+}
+
+func isValidReplyPermission(replyPermission types.ReplyPermission) bool {
+	return replyPermission == types.ReplyFromAll ||
+		replyPermission == types.ReplyFromFollower ||
+		replyPermission == types.ReplyFromNobody
+}
+
 // GetPermission - implements types.Msg
 func (msg CreatePostMsg) GetPermission() types.Permission {
 	return types.AppPermission
@@ -322,6 +661,21 @@ func (msg ViewMsg) GetPermission() types.Permission {
 	return types.AppPermission
 }
 
+// GetPermission - implements types.Msg
+func (msg UpdateReplyBlocklistMsg) GetPermission() types.Permission {
+	return types.AppPermission
+}
+
+// GetPermission - implements types.Msg
+func (msg UnlockPostMsg) GetPermission() types.Permission {
+	return types.PreAuthorizationPermission
+}
+
+// GetPermission - implements types.Msg
+func (msg BatchDonateMsg) GetPermission() types.Permission {
+	return types.PreAuthorizationPermission
+}
+
 // GetSignBytes - implements sdk.Msg
 func (msg CreatePostMsg) GetSignBytes() []byte {
 	return getSignBytes(msg)
@@ -352,6 +706,21 @@ func (msg ViewMsg) GetSignBytes() []byte {
 	return getSignBytes(msg)
 }
 
+// GetSignBytes - implements sdk.Msg
+func (msg UpdateReplyBlocklistMsg) GetSignBytes() []byte {
+	return getSignBytes(msg)
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg UnlockPostMsg) GetSignBytes() []byte {
+	return getSignBytes(msg)
+}
+
+// GetSignBytes - implements sdk.Msg
+func (msg BatchDonateMsg) GetSignBytes() []byte {
+	return getSignBytes(msg)
+}
+
 func getSignBytes(msg sdk.Msg) []byte {
 	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
 	if err != nil {
@@ -390,17 +759,38 @@ func (msg ViewMsg) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
 }
 
+// GetSigners - implements sdk.Msg
+func (msg UpdateReplyBlocklistMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Author)}
+}
+
+// GetSigners - implements sdk.Msg
+func (msg UnlockPostMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
+// GetSigners - implements sdk.Msg
+func (msg BatchDonateMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Username)}
+}
+
 // String implements Stringer
 func (msg CreatePostMsg) String() string {
 	return fmt.Sprintf("Post.CreatePostMsg{author:%v, postID:%v, title:%v, content:%v, parentAuthor:%v,"+
-		"parentPostID:%v, sourceAuthor:%v, sourcePostID:%v,links:%v, redistribution split rate:%v}",
+		"parentPostID:%v, sourceAuthor:%v, sourcePostID:%v,links:%v, redistribution split rate:%v, reply permission:%v, tags:%v, beneficiaries:%v,"+
+		" paywall type:%v, unlock price:%v, content refs:%v}",
 		msg.Author, msg.PostID, msg.Title, msg.Content, msg.ParentAuthor, msg.ParentPostID, msg.SourceAuthor, msg.SourcePostID,
-		msg.Links, msg.RedistributionSplitRate)
+		msg.Links, msg.RedistributionSplitRate, msg.ReplyPermission, msg.Tags, msg.Beneficiaries,
+		msg.PaywallType, msg.UnlockPrice, msg.ContentRefs)
 }
 
 func (msg UpdatePostMsg) String() string {
-	return fmt.Sprintf("Post.UpdatePostMsg{author:%v, postID:%v, title:%v, content:%v, links:%v}",
-		msg.Author, msg.PostID, msg.Title, msg.Content, msg.Links)
+	replyPermission := "unchanged"
+	if msg.ReplyPermission != nil {
+		replyPermission = fmt.Sprintf("%v", *msg.ReplyPermission)
+	}
+	return fmt.Sprintf("Post.UpdatePostMsg{author:%v, postID:%v, title:%v, content:%v, links:%v, reply permission:%v}",
+		msg.Author, msg.PostID, msg.Title, msg.Content, msg.Links, replyPermission)
 }
 
 func (msg DeletePostMsg) String() string {
@@ -415,8 +805,8 @@ func (msg DonateMsg) String() string {
 
 func (msg ReportOrUpvoteMsg) String() string {
 	return fmt.Sprintf(
-		"Post.ReportOrUpvoteMsg{from: %v, post author:%v, post id: %v}",
-		msg.Username, msg.Author, msg.PostID)
+		"Post.ReportOrUpvoteMsg{from: %v, post author:%v, post id: %v, is report: %v, reason: %v, memo: %v}",
+		msg.Username, msg.Author, msg.PostID, msg.IsReport, msg.Reason, msg.Memo)
 }
 
 func (msg ViewMsg) String() string {
@@ -425,6 +815,24 @@ func (msg ViewMsg) String() string {
 		msg.Username, msg.Author, msg.PostID)
 }
 
+func (msg UpdateReplyBlocklistMsg) String() string {
+	return fmt.Sprintf(
+		"Post.UpdateReplyBlocklistMsg{author:%v, post id:%v, user:%v, is blocked:%v}",
+		msg.Author, msg.PostID, msg.Username, msg.IsBlocked)
+}
+
+func (msg UnlockPostMsg) String() string {
+	return fmt.Sprintf(
+		"Post.UnlockPostMsg{username:%v, amount:%v, post author:%v, post id:%v, from app:%v}",
+		msg.Username, msg.Amount, msg.Author, msg.PostID, msg.FromApp)
+}
+
+func (msg BatchDonateMsg) String() string {
+	return fmt.Sprintf(
+		"Post.BatchDonateMsg{donation from:%v, from app:%v, donations:%v}",
+		msg.Username, msg.FromApp, msg.Donations)
+}
+
 // GetConsumeAmount - implements types.Msg
 func (msg CreatePostMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
@@ -455,3 +863,24 @@ func (msg ReportOrUpvoteMsg) GetConsumeAmount() types.Coin {
 func (msg ViewMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
+
+// GetConsumeAmount - implements types.Msg
+func (msg UpdateReplyBlocklistMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// GetConsumeAmount - implements types.Msg
+func (msg UnlockPostMsg) GetConsumeAmount() types.Coin {
+	coin, _ := types.LinoToCoin(msg.Amount)
+	return coin
+}
+
+// GetConsumeAmount - implements types.Msg, total amount of all donations in the batch
+func (msg BatchDonateMsg) GetConsumeAmount() types.Coin {
+	total := types.NewCoinFromInt64(0)
+	for _, donation := range msg.Donations {
+		coin, _ := types.LinoToCoin(donation.Amount)
+		total = total.Plus(coin)
+	}
+	return total
+}
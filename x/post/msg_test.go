@@ -1,6 +1,8 @@
 package post
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/lino-network/lino/types"
@@ -267,6 +269,315 @@ func TestCreatePostMsg(t *testing.T) {
 			},
 			expectedResult: ErrURLLengthTooLong(),
 		},
+		{
+			testName: "followers only reply permission",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Title:                   string(make([]byte, 100)),
+				Content:                 string(make([]byte, 1000)),
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ReplyPermission:         types.ReplyFromFollower,
+			},
+			expectedResult: nil,
+		},
+		{
+			testName: "invalid reply permission",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Title:                   string(make([]byte, 100)),
+				Content:                 string(make([]byte, 1000)),
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ReplyPermission:         types.ReplyPermission(3),
+			},
+			expectedResult: ErrInvalidReplyPermission(),
+		},
+		{
+			testName: "with tags",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Tags:                    []string{"lino", "block_chain", "web-3", "2018"},
+			},
+			expectedResult: nil,
+		},
+		{
+			testName: "too many tags",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Tags:                    []string{"a", "b", "c", "d", "e", "f"},
+			},
+			expectedResult: ErrTooManyTags(),
+		},
+		{
+			testName: "tag not normalized",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Tags:                    []string{"Lino"},
+			},
+			expectedResult: ErrInvalidTag("Lino"),
+		},
+		{
+			testName: "empty tag",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Tags:                    []string{""},
+			},
+			expectedResult: ErrInvalidTag(""),
+		},
+		{
+			testName: "tag too long",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Tags:                    []string{strings.Repeat("a", types.MaximumLengthOfTag+1)},
+			},
+			expectedResult: ErrInvalidTag(strings.Repeat("a", types.MaximumLengthOfTag+1)),
+		},
+		{
+			testName: "duplicate tags",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Tags:                    []string{"lino", "lino"},
+			},
+			expectedResult: ErrInvalidTag("lino"),
+		},
+		{
+			testName: "with beneficiaries",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Beneficiaries: []types.Beneficiary{
+					{Username: author, Weight: "0.6"},
+					{Username: "coauthor", Weight: "0.4"},
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			testName: "too many beneficiaries",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Beneficiaries:           make([]types.Beneficiary, types.MaximumNumOfBeneficiaries+1),
+			},
+			expectedResult: ErrTooManyBeneficiaries(),
+		},
+		{
+			testName: "beneficiary weights don't sum to 1",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Beneficiaries: []types.Beneficiary{
+					{Username: author, Weight: "0.6"},
+					{Username: "coauthor", Weight: "0.3"},
+				},
+			},
+			expectedResult: ErrInvalidBeneficiaryWeights(),
+		},
+		{
+			testName: "zero beneficiary weight",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Beneficiaries: []types.Beneficiary{
+					{Username: author, Weight: "1"},
+					{Username: "coauthor", Weight: "0"},
+				},
+			},
+			expectedResult: ErrInvalidBeneficiary("coauthor"),
+		},
+		{
+			testName: "invalid beneficiary weight",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Beneficiaries: []types.Beneficiary{
+					{Username: author, Weight: "half"},
+				},
+			},
+			expectedResult: ErrInvalidBeneficiary(author),
+		},
+		{
+			testName: "duplicate beneficiaries",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				Beneficiaries: []types.Beneficiary{
+					{Username: author, Weight: "0.5"},
+					{Username: author, Weight: "0.5"},
+				},
+			},
+			expectedResult: ErrInvalidBeneficiary(author),
+		},
+		{
+			testName: "pay per view post",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				PaywallType:             types.PayPerView,
+				UnlockPrice:             types.LNO("0.1"),
+			},
+			expectedResult: nil,
+		},
+		{
+			testName: "paywalled post without price",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				PaywallType:             types.OneTimeUnlock,
+			},
+			expectedResult: ErrInvalidPaywall(),
+		},
+		{
+			testName: "free post with price",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				UnlockPrice:             types.LNO("1"),
+			},
+			expectedResult: ErrInvalidPaywall(),
+		},
+		{
+			testName: "invalid paywall type",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				PaywallType:             types.PaywallType(3),
+				UnlockPrice:             types.LNO("1"),
+			},
+			expectedResult: ErrInvalidPaywall(),
+		},
+		{
+			testName: "content refs",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.IPFSContentRef, Ref: "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", Size: 100, MimeType: "image/png"},
+					{RefType: types.IPFSContentRef, Ref: "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", Size: 100, MimeType: "video/mp4"},
+					{RefType: types.SHA256ContentRef, Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Size: 100, MimeType: "text/markdown"},
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			testName: "too many content refs",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs:             make([]types.ContentReference, types.MaximumNumOfContentRefs+1),
+			},
+			expectedResult: ErrTooManyContentRefs(),
+		},
+		{
+			testName: "invalid CID",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.IPFSContentRef, Ref: "Qm0wAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", Size: 100, MimeType: "image/png"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("Qm0wAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"),
+		},
+		{
+			testName: "invalid sha256",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.SHA256ContentRef, Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b8", Size: 100, MimeType: "image/png"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b8"),
+		},
+		{
+			testName: "uppercase sha256",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.SHA256ContentRef, Ref: "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855", Size: 100, MimeType: "image/png"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"),
+		},
+		{
+			testName: "invalid content ref type",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.ContentRefType(2), Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Size: 100, MimeType: "image/png"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+		},
+		{
+			testName: "invalid size",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.SHA256ContentRef, Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Size: 0, MimeType: "image/png"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+		},
+		{
+			testName: "invalid mime type",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.SHA256ContentRef, Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Size: 100, MimeType: "image"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+		},
+		{
+			testName: "duplicate content refs",
+			msg: CreatePostMsg{
+				PostID:                  "TestPostID",
+				Author:                  author,
+				RedistributionSplitRate: "0",
+				ContentRefs: []types.ContentReference{
+					{RefType: types.SHA256ContentRef, Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Size: 100, MimeType: "image/png"},
+					{RefType: types.SHA256ContentRef, Ref: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Size: 100, MimeType: "image/png"},
+				},
+			},
+			expectedResult: ErrInvalidContentRef("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+		},
 	}
 	for _, tc := range testCases {
 		result := tc.msg.ValidateBasic()
@@ -277,6 +588,8 @@ func TestCreatePostMsg(t *testing.T) {
 }
 
 func TestUpdatePostMsg(t *testing.T) {
+	replyFromNobody := types.ReplyFromNobody
+	invalidReplyPermission := types.ReplyPermission(3)
 	testCases := []struct {
 		testName       string
 		updatePostMsg  UpdatePostMsg
@@ -344,6 +657,20 @@ func TestUpdatePostMsg(t *testing.T) {
 				[]types.IDToURLMapping{}),
 			expectedResult: ErrPostContentExceedMaxLength(),
 		},
+		{
+			testName: "set reply permission",
+			updatePostMsg: UpdatePostMsg{
+				Author: "author", PostID: "postID", Title: "title", Content: "content",
+				ReplyPermission: &replyFromNobody},
+			expectedResult: nil,
+		},
+		{
+			testName: "invalid reply permission",
+			updatePostMsg: UpdatePostMsg{
+				Author: "author", PostID: "postID", Title: "title", Content: "content",
+				ReplyPermission: &invalidReplyPermission},
+			expectedResult: ErrInvalidReplyPermission(),
+		},
 	}
 	for _, tc := range testCases {
 		result := tc.updatePostMsg.ValidateBasic()
@@ -397,6 +724,189 @@ func TestDeletePostMsg(t *testing.T) {
 	}
 }
 
+func TestNormalizeTag(t *testing.T) {
+	testCases := []struct {
+		tag     string
+		wantTag string
+	}{
+		{tag: "lino", wantTag: "lino"},
+		{tag: " #Lino ", wantTag: "lino"},
+		{tag: "#BlockChain", wantTag: "blockchain"},
+	}
+	for _, tc := range testCases {
+		if tag := NormalizeTag(tc.tag); tag != tc.wantTag {
+			t.Errorf("%s: diff tag, got %v, want %v", tc.tag, tag, tc.wantTag)
+		}
+	}
+}
+
+func TestUpdateReplyBlocklistMsg(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		msg         UpdateReplyBlocklistMsg
+		wantErrCode sdk.CodeType
+	}{
+		{
+			testName:    "block from a post",
+			msg:         NewUpdateReplyBlocklistMsg("author", "postID", "user", true),
+			wantErrCode: sdk.CodeOK,
+		},
+		{
+			testName:    "unblock from all posts",
+			msg:         NewUpdateReplyBlocklistMsg("author", "", "user", false),
+			wantErrCode: sdk.CodeOK,
+		},
+		{
+			testName:    "empty author",
+			msg:         NewUpdateReplyBlocklistMsg("", "postID", "user", true),
+			wantErrCode: types.CodeNoAuthor,
+		},
+		{
+			testName:    "post id too long",
+			msg:         NewUpdateReplyBlocklistMsg("author", string(make([]byte, types.MaximumLengthOfPostID+1)), "user", true),
+			wantErrCode: types.CodePostIDTooLong,
+		},
+		{
+			testName:    "empty username",
+			msg:         NewUpdateReplyBlocklistMsg("author", "postID", "", true),
+			wantErrCode: types.CodeNoUsername,
+		},
+		{
+			testName:    "block self",
+			msg:         NewUpdateReplyBlocklistMsg("author", "postID", "author", true),
+			wantErrCode: types.CodeCannotBlockSelf,
+		},
+	}
+	for _, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+		if got == nil && tc.wantErrCode != sdk.CodeOK {
+			t.Errorf("%s: got non-OK code, got %v, want %v", tc.testName, got, tc.wantErrCode)
+		}
+		if got != nil {
+			if got.Code() != tc.wantErrCode {
+				t.Errorf("%s: diff err code, got %v, want %v", tc.testName, got, tc.wantErrCode)
+			}
+		}
+	}
+}
+
+func TestUnlockPostMsg(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		msg         UnlockPostMsg
+		wantErrCode sdk.CodeType
+	}{
+		{
+			testName:    "normal case",
+			msg:         NewUnlockPostMsg("user", types.LNO("1"), "author", "postID", ""),
+			wantErrCode: sdk.CodeOK,
+		},
+		{
+			testName:    "empty username",
+			msg:         NewUnlockPostMsg("", types.LNO("1"), "author", "postID", ""),
+			wantErrCode: types.CodeNoUsername,
+		},
+		{
+			testName:    "empty post id",
+			msg:         NewUnlockPostMsg("user", types.LNO("1"), "author", "", ""),
+			wantErrCode: types.CodeInvalidTarget,
+		},
+		{
+			testName:    "unlock own post",
+			msg:         NewUnlockPostMsg("author", types.LNO("1"), "author", "postID", ""),
+			wantErrCode: types.CodeCannotUnlockOwnPost,
+		},
+		{
+			testName:    "invalid amount",
+			msg:         NewUnlockPostMsg("user", types.LNO("-1"), "author", "postID", ""),
+			wantErrCode: types.CodeInvalidCoins,
+		},
+	}
+	for _, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+		if got == nil && tc.wantErrCode != sdk.CodeOK {
+			t.Errorf("%s: got non-OK code, got %v, want %v", tc.testName, got, tc.wantErrCode)
+		}
+		if got != nil {
+			if got.Code() != tc.wantErrCode {
+				t.Errorf("%s: diff err code, got %v, want %v", tc.testName, got, tc.wantErrCode)
+			}
+		}
+	}
+}
+
+func TestBatchDonateMsg(t *testing.T) {
+	donation1 := BatchDonation{Author: "author1", PostID: "postID", Amount: types.LNO("1"), Memo: "memo"}
+	donation2 := BatchDonation{Author: "author2", PostID: "postID", Amount: types.LNO("2")}
+	tooManyDonations := []BatchDonation{}
+	for i := 0; i <= types.MaximumNumOfDonationsInBatch; i++ {
+		tooManyDonations = append(tooManyDonations, BatchDonation{
+			Author: "author", PostID: strconv.Itoa(i), Amount: types.LNO("1")})
+	}
+
+	testCases := []struct {
+		testName    string
+		msg         BatchDonateMsg
+		wantErrCode sdk.CodeType
+	}{
+		{
+			testName:    "normal case",
+			msg:         NewBatchDonateMsg("user", "", []BatchDonation{donation1, donation2}),
+			wantErrCode: sdk.CodeOK,
+		},
+		{
+			testName:    "empty username",
+			msg:         NewBatchDonateMsg("", "", []BatchDonation{donation1}),
+			wantErrCode: types.CodeNoUsername,
+		},
+		{
+			testName:    "no donation",
+			msg:         NewBatchDonateMsg("user", "", []BatchDonation{}),
+			wantErrCode: types.CodeNoDonationInBatch,
+		},
+		{
+			testName:    "too many donations",
+			msg:         NewBatchDonateMsg("user", "", tooManyDonations),
+			wantErrCode: types.CodeTooManyDonationsInBatch,
+		},
+		{
+			testName: "invalid target",
+			msg: NewBatchDonateMsg("user", "", []BatchDonation{
+				donation1, {Author: "author", Amount: types.LNO("1")}}),
+			wantErrCode: types.CodeInvalidTarget,
+		},
+		{
+			testName: "invalid amount",
+			msg: NewBatchDonateMsg("user", "", []BatchDonation{
+				donation1, {Author: "author", PostID: "postID", Amount: types.LNO("-1")}}),
+			wantErrCode: types.CodeInvalidCoins,
+		},
+		{
+			testName: "memo is too long",
+			msg: NewBatchDonateMsg("user", "", []BatchDonation{{
+				Author: "author", PostID: "postID", Amount: types.LNO("1"),
+				Memo: string(make([]byte, types.MaximumMemoLength+1))}}),
+			wantErrCode: types.CodeInvalidMemo,
+		},
+		{
+			testName:    "duplicate donation",
+			msg:         NewBatchDonateMsg("user", "", []BatchDonation{donation1, donation2, donation1}),
+			wantErrCode: types.CodeDuplicateDonationInBatch,
+		},
+	}
+	for _, tc := range testCases {
+		got := tc.msg.ValidateBasic()
+		if got == nil && tc.wantErrCode != sdk.CodeOK {
+			t.Errorf("%s: got non-OK code, got %v, want %v", tc.testName, got, tc.wantErrCode)
+		}
+		if got != nil {
+			if got.Code() != tc.wantErrCode {
+				t.Errorf("%s: diff err code, got %v, want %v", tc.testName, got, tc.wantErrCode)
+			}
+		}
+	}
+}
+
 func TestCommentAndRepost(t *testing.T) {
 	parentAuthor := "Parent"
 	parentPostID := "ParentPostID"
@@ -559,6 +1069,38 @@ func TestReportOrUpvoteMsg(t *testing.T) {
 			reportOrUpvoteMsg: NewReportOrUpvoteMsg("test", "", "", false),
 			expectedError:     ErrInvalidTarget(),
 		},
+		{
+			testName: "report with reason and memo",
+			reportOrUpvoteMsg: ReportOrUpvoteMsg{
+				Username: "test", Author: "author", PostID: "postID", IsReport: true,
+				Reason: types.CopyrightReport, Memo: "copied from my post",
+			},
+			expectedError: nil,
+		},
+		{
+			testName: "invalid report reason",
+			reportOrUpvoteMsg: ReportOrUpvoteMsg{
+				Username: "test", Author: "author", PostID: "postID", IsReport: true,
+				Reason: types.ReportReason(100),
+			},
+			expectedError: ErrInvalidReportReason(),
+		},
+		{
+			testName: "upvote with reason",
+			reportOrUpvoteMsg: ReportOrUpvoteMsg{
+				Username: "test", Author: "author", PostID: "postID", IsReport: false,
+				Reason: types.SpamReport,
+			},
+			expectedError: ErrInvalidReportReason(),
+		},
+		{
+			testName: "memo is too long",
+			reportOrUpvoteMsg: ReportOrUpvoteMsg{
+				Username: "test", Author: "author", PostID: "postID", IsReport: true,
+				Memo: string(make([]byte, types.MaximumMemoLength+1)),
+			},
+			expectedError: ErrInvalidMemo(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -663,6 +1205,21 @@ func TestMsgPermission(t *testing.T) {
 				"author", "postID", "title", "content", []types.IDToURLMapping{}),
 			expectedPermission: types.AppPermission,
 		},
+		{
+			testName:           "update reply blocklist",
+			msg:                NewUpdateReplyBlocklistMsg("author", "postID", "user", true),
+			expectedPermission: types.AppPermission,
+		},
+		{
+			testName:           "unlock post",
+			msg:                NewUnlockPostMsg("user", types.LNO("1"), "author", "postID", ""),
+			expectedPermission: types.PreAuthorizationPermission,
+		},
+		{
+			testName:           "batch donate",
+			msg:                NewBatchDonateMsg("user", "", []BatchDonation{{Author: "author", PostID: "postID", Amount: types.LNO("1")}}),
+			expectedPermission: types.PreAuthorizationPermission,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -724,6 +1281,18 @@ func TestGetSignBytes(t *testing.T) {
 			msg: NewUpdatePostMsg(
 				"author", "postID", "title", "content", []types.IDToURLMapping{}),
 		},
+		{
+			testName: "update reply blocklist",
+			msg:      NewUpdateReplyBlocklistMsg("author", "postID", "user", true),
+		},
+		{
+			testName: "unlock post",
+			msg:      NewUnlockPostMsg("user", types.LNO("1"), "author", "postID", ""),
+		},
+		{
+			testName: "batch donate",
+			msg:      NewBatchDonateMsg("user", "", []BatchDonation{{Author: "author", PostID: "postID", Amount: types.LNO("1")}}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -789,6 +1358,21 @@ func TestGetSigners(t *testing.T) {
 				"author", "postID", "title", "content", []types.IDToURLMapping{}),
 			expectSigners: []types.AccountKey{"author"},
 		},
+		{
+			testName:      "update reply blocklist",
+			msg:           NewUpdateReplyBlocklistMsg("author", "postID", "user", true),
+			expectSigners: []types.AccountKey{"author"},
+		},
+		{
+			testName:      "unlock post",
+			msg:           NewUnlockPostMsg("user", types.LNO("1"), "author", "postID", ""),
+			expectSigners: []types.AccountKey{"user"},
+		},
+		{
+			testName:      "batch donate",
+			msg:           NewBatchDonateMsg("user", "", []BatchDonation{{Author: "author", PostID: "postID", Amount: types.LNO("1")}}),
+			expectSigners: []types.AccountKey{"user"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -818,6 +1402,19 @@ func TestGetConsumeAmount(t *testing.T) {
 				"author", "postID", "", memo1),
 			expectAmount: types.NewCoinFromInt64(1 * types.Decimals),
 		},
+		{
+			testName:     "unlock post",
+			msg:          NewUnlockPostMsg("test", types.LNO("0.5"), "author", "postID", ""),
+			expectAmount: types.NewCoinFromInt64(types.Decimals / 2),
+		},
+		{
+			testName: "batch donate",
+			msg: NewBatchDonateMsg("test", "", []BatchDonation{
+				{Author: "author1", PostID: "postID", Amount: types.LNO("0.5")},
+				{Author: "author2", PostID: "postID", Amount: types.LNO("1")},
+			}),
+			expectAmount: types.NewCoinFromInt64(types.Decimals * 3 / 2),
+		},
 		{
 			testName: "create post",
 			msg: CreatePostMsg{
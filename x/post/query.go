@@ -0,0 +1,154 @@
+package post
+
+import (
+	"sort"
+
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/post/model"
+)
+
+// TrendingTag - donations received by posts with the tag in a period
+type TrendingTag struct {
+	Tag         string     `json:"tag"`
+	Amount      types.Coin `json:"amount"`
+	DonateCount int64      `json:"donate_count"`
+}
+
+// TaggedPostsQuery - query data of a page of posts with the tag
+type TaggedPostsQuery struct {
+	Tag      string `json:"tag"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+}
+
+// TrendingTagsQuery - query data of tags received most donations in recent days
+type TrendingTagsQuery struct {
+	Days  int64 `json:"days"`
+	Limit int   `json:"limit"`
+}
+
+// PostAccess - whether a user can view a paywalled post
+type PostAccess struct {
+	HasAccess      bool              `json:"has_access"`
+	PaywallType    types.PaywallType `json:"paywall_type"`
+	Price          types.Coin        `json:"price"`
+	RemainingViews int64             `json:"remaining_views"`
+}
+
+// NewPostAccess - check access of the user to the post, author always has access,
+// post unlock is nil if the user never unlocked the post
+func NewPostAccess(
+	author types.AccountKey, paywall *model.Paywall, postUnlock *model.PostUnlock,
+	user types.AccountKey) *PostAccess {
+	access := &PostAccess{
+		PaywallType: paywall.PaywallType,
+		Price:       paywall.Price,
+	}
+	switch {
+	case paywall.PaywallType == types.NoPaywall || author == user:
+		access.HasAccess = true
+	case postUnlock == nil:
+		access.HasAccess = false
+	case paywall.PaywallType == types.PayPerView:
+		access.RemainingViews = postUnlock.RemainingViews
+		access.HasAccess = postUnlock.RemainingViews > 0
+	default:
+		access.HasAccess = true
+	}
+	return access
+}
+
+// PaginateTaggedPosts - sort tagged posts newest first and return the given page,
+// page starts from 1
+func PaginateTaggedPosts(taggedPosts []model.TaggedPost, page, pageSize int) []model.TaggedPost {
+	sorted := make([]model.TaggedPost, len(taggedPosts))
+	copy(sorted, taggedPosts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt != sorted[j].CreatedAt {
+			return sorted[i].CreatedAt > sorted[j].CreatedAt
+		}
+		return sorted[i].Permlink > sorted[j].Permlink
+	})
+	if page < 1 || pageSize < 1 {
+		return []model.TaggedPost{}
+	}
+	start := (page - 1) * pageSize
+	if start >= len(sorted) {
+		return []model.TaggedPost{}
+	}
+	end := start + pageSize
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+	return sorted[start:end]
+}
+
+// GetTrendingTags - sum up daily donation statistics by tag and return at most
+// limit tags ordered by donation amount, then donate count and tag
+func GetTrendingTags(stats []model.TagDonationStat, limit int) []TrendingTag {
+	trendingTags := []TrendingTag{}
+	indexOfTag := map[string]int{}
+	for _, stat := range stats {
+		idx, ok := indexOfTag[stat.Tag]
+		if !ok {
+			idx = len(trendingTags)
+			indexOfTag[stat.Tag] = idx
+			trendingTags = append(
+				trendingTags, TrendingTag{Tag: stat.Tag, Amount: types.NewCoinFromInt64(0)})
+		}
+		trendingTags[idx].Amount = trendingTags[idx].Amount.Plus(stat.Amount)
+		trendingTags[idx].DonateCount += stat.DonateCount
+	}
+	sort.SliceStable(trendingTags, func(i, j int) bool {
+		if !trendingTags[i].Amount.IsEqual(trendingTags[j].Amount) {
+			return trendingTags[i].Amount.IsGT(trendingTags[j].Amount)
+		}
+		if trendingTags[i].DonateCount != trendingTags[j].DonateCount {
+			return trendingTags[i].DonateCount > trendingTags[j].DonateCount
+		}
+		return trendingTags[i].Tag < trendingTags[j].Tag
+	})
+	if limit >= 0 && len(trendingTags) > limit {
+		trendingTags = trendingTags[:limit]
+	}
+	return trendingTags
+}
+
+// GetReportQueue - sort report stats by report weight then report count and
+// return at most limit posts, posts without current reporter are not included
+func GetReportQueue(stats []model.PostReportStat, limit int) []model.PostReportStat {
+	res := []model.PostReportStat{}
+	for _, stat := range stats {
+		if stat.ReportCount > 0 {
+			res = append(res, stat)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].ReportWeight.IsEqual(res[j].ReportWeight) {
+			return res[i].ReportWeight.IsGT(res[j].ReportWeight)
+		}
+		if res[i].ReportCount != res[j].ReportCount {
+			return res[i].ReportCount > res[j].ReportCount
+		}
+		return res[i].Permlink < res[j].Permlink
+	})
+	if limit >= 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+// FilterPostStats - return stat buckets overlap with days from start day to end day
+// inclusive, ordered by start day
+func FilterPostStats(stats []model.PostStat, startDay, endDay int64) []model.PostStat {
+	res := []model.PostStat{}
+	for _, stat := range stats {
+		if stat.StartDay <= endDay && stat.StartDay+int64(stat.Period) > startDay {
+			res = append(res, stat)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].StartDay < res[j].StartDay
+	})
+	return res
+}
@@ -0,0 +1,242 @@
+package post
+
+import (
+	"testing"
+
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/post/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginateTaggedPosts(t *testing.T) {
+	post1 := model.TaggedPost{Permlink: types.Permlink("user1#post1"), CreatedAt: 1}
+	post2 := model.TaggedPost{Permlink: types.Permlink("user1#post2"), CreatedAt: 2}
+	post3 := model.TaggedPost{Permlink: types.Permlink("user2#post3"), CreatedAt: 2}
+	taggedPosts := []model.TaggedPost{post1, post2, post3}
+
+	testCases := []struct {
+		testName        string
+		page            int
+		pageSize        int
+		wantTaggedPosts []model.TaggedPost
+	}{
+		{
+			testName:        "newest first",
+			page:            1,
+			pageSize:        10,
+			wantTaggedPosts: []model.TaggedPost{post3, post2, post1},
+		},
+		{
+			testName:        "second page",
+			page:            2,
+			pageSize:        2,
+			wantTaggedPosts: []model.TaggedPost{post1},
+		},
+		{
+			testName:        "page out of range",
+			page:            3,
+			pageSize:        2,
+			wantTaggedPosts: []model.TaggedPost{},
+		},
+		{
+			testName:        "invalid page size",
+			page:            1,
+			pageSize:        0,
+			wantTaggedPosts: []model.TaggedPost{},
+		},
+	}
+	for _, tc := range testCases {
+		res := PaginateTaggedPosts(taggedPosts, tc.page, tc.pageSize)
+		if !assert.Equal(t, tc.wantTaggedPosts, res) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, res, tc.wantTaggedPosts)
+		}
+	}
+}
+
+func TestGetTrendingTags(t *testing.T) {
+	stats := []model.TagDonationStat{
+		{Tag: "lino", Day: 1, Amount: types.NewCoinFromInt64(10), DonateCount: 1},
+		{Tag: "music", Day: 1, Amount: types.NewCoinFromInt64(15), DonateCount: 3},
+		{Tag: "lino", Day: 2, Amount: types.NewCoinFromInt64(10), DonateCount: 2},
+		{Tag: "game", Day: 2, Amount: types.NewCoinFromInt64(15), DonateCount: 1},
+		{Tag: "art", Day: 2, Amount: types.NewCoinFromInt64(15), DonateCount: 1},
+	}
+
+	testCases := []struct {
+		testName         string
+		limit            int
+		wantTrendingTags []TrendingTag
+	}{
+		{
+			testName: "all tags",
+			limit:    10,
+			wantTrendingTags: []TrendingTag{
+				{Tag: "lino", Amount: types.NewCoinFromInt64(20), DonateCount: 3},
+				{Tag: "music", Amount: types.NewCoinFromInt64(15), DonateCount: 3},
+				{Tag: "art", Amount: types.NewCoinFromInt64(15), DonateCount: 1},
+				{Tag: "game", Amount: types.NewCoinFromInt64(15), DonateCount: 1},
+			},
+		},
+		{
+			testName: "limited tags",
+			limit:    1,
+			wantTrendingTags: []TrendingTag{
+				{Tag: "lino", Amount: types.NewCoinFromInt64(20), DonateCount: 3},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		res := GetTrendingTags(stats, tc.limit)
+		if !assert.Equal(t, tc.wantTrendingTags, res) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, res, tc.wantTrendingTags)
+		}
+	}
+}
+
+func TestNewPostAccess(t *testing.T) {
+	author := types.AccountKey("author")
+	user := types.AccountKey("user")
+	price := types.NewCoinFromInt64(100)
+	free := &model.Paywall{PaywallType: types.NoPaywall, Price: types.NewCoinFromInt64(0)}
+	oneTime := &model.Paywall{PaywallType: types.OneTimeUnlock, Price: price}
+	perView := &model.Paywall{PaywallType: types.PayPerView, Price: price}
+
+	testCases := []struct {
+		testName   string
+		paywall    *model.Paywall
+		postUnlock *model.PostUnlock
+		user       types.AccountKey
+		wantAccess *PostAccess
+	}{
+		{
+			testName:   "free post",
+			paywall:    free,
+			user:       user,
+			wantAccess: &PostAccess{HasAccess: true, PaywallType: types.NoPaywall, Price: types.NewCoinFromInt64(0)},
+		},
+		{
+			testName:   "author of paywalled post",
+			paywall:    perView,
+			user:       author,
+			wantAccess: &PostAccess{HasAccess: true, PaywallType: types.PayPerView, Price: price},
+		},
+		{
+			testName:   "one time unlock post not unlocked",
+			paywall:    oneTime,
+			user:       user,
+			wantAccess: &PostAccess{HasAccess: false, PaywallType: types.OneTimeUnlock, Price: price},
+		},
+		{
+			testName:   "one time unlock post unlocked",
+			paywall:    oneTime,
+			postUnlock: &model.PostUnlock{Username: user, Times: 1},
+			user:       user,
+			wantAccess: &PostAccess{HasAccess: true, PaywallType: types.OneTimeUnlock, Price: price},
+		},
+		{
+			testName:   "pay per view post with remaining views",
+			paywall:    perView,
+			postUnlock: &model.PostUnlock{Username: user, Times: 2, RemainingViews: 1},
+			user:       user,
+			wantAccess: &PostAccess{HasAccess: true, PaywallType: types.PayPerView, Price: price, RemainingViews: 1},
+		},
+		{
+			testName:   "pay per view post without remaining views",
+			paywall:    perView,
+			postUnlock: &model.PostUnlock{Username: user, Times: 2},
+			user:       user,
+			wantAccess: &PostAccess{HasAccess: false, PaywallType: types.PayPerView, Price: price},
+		},
+	}
+	for _, tc := range testCases {
+		access := NewPostAccess(author, tc.paywall, tc.postUnlock, tc.user)
+		if !assert.Equal(t, tc.wantAccess, access) {
+			t.Errorf("%s: diff access, got %v, want %v", tc.testName, access, tc.wantAccess)
+		}
+	}
+}
+
+func TestGetReportQueue(t *testing.T) {
+	stat1 := model.PostReportStat{
+		Permlink: types.Permlink("user1#post1"), ReportCount: 1, ReportWeight: types.NewCoinFromInt64(10)}
+	stat2 := model.PostReportStat{
+		Permlink: types.Permlink("user1#post2"), ReportCount: 2, ReportWeight: types.NewCoinFromInt64(10)}
+	stat3 := model.PostReportStat{
+		Permlink: types.Permlink("user2#post3"), ReportCount: 1, ReportWeight: types.NewCoinFromInt64(100)}
+	stat4 := model.PostReportStat{
+		Permlink: types.Permlink("user2#post4"), ReportCount: 0, ReportWeight: types.NewCoinFromInt64(1000)}
+	stats := []model.PostReportStat{stat1, stat2, stat3, stat4}
+
+	testCases := []struct {
+		testName  string
+		limit     int
+		wantStats []model.PostReportStat
+	}{
+		{
+			testName:  "sorted by weight then count",
+			limit:     10,
+			wantStats: []model.PostReportStat{stat3, stat2, stat1},
+		},
+		{
+			testName:  "limit",
+			limit:     2,
+			wantStats: []model.PostReportStat{stat3, stat2},
+		},
+		{
+			testName:  "zero limit",
+			limit:     0,
+			wantStats: []model.PostReportStat{},
+		},
+	}
+	for _, tc := range testCases {
+		res := GetReportQueue(stats, tc.limit)
+		if !assert.Equal(t, tc.wantStats, res) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, res, tc.wantStats)
+		}
+	}
+}
+
+func TestFilterPostStats(t *testing.T) {
+	monthly := model.PostStat{StartDay: 28, Period: types.MonthlyStat, DonateCount: 1}
+	weekly := model.PostStat{StartDay: 56, Period: types.WeeklyStat, DonateCount: 2}
+	daily := model.PostStat{StartDay: 63, Period: types.DailyStat, DonateCount: 3}
+	stats := []model.PostStat{daily, monthly, weekly}
+
+	testCases := []struct {
+		testName  string
+		startDay  int64
+		endDay    int64
+		wantStats []model.PostStat
+	}{
+		{
+			testName:  "all buckets in range",
+			startDay:  0,
+			endDay:    100,
+			wantStats: []model.PostStat{monthly, weekly, daily},
+		},
+		{
+			testName:  "range overlaps with end of monthly bucket",
+			startDay:  55,
+			endDay:    56,
+			wantStats: []model.PostStat{monthly, weekly},
+		},
+		{
+			testName:  "range starts after monthly bucket",
+			startDay:  56,
+			endDay:    63,
+			wantStats: []model.PostStat{weekly, daily},
+		},
+		{
+			testName:  "range before all buckets",
+			startDay:  0,
+			endDay:    27,
+			wantStats: []model.PostStat{},
+		},
+	}
+	for _, tc := range testCases {
+		res := FilterPostStats(stats, tc.startDay, tc.endDay)
+		if !assert.Equal(t, tc.wantStats, res) {
+			t.Errorf("%s: diff stats, got %v, want %v", tc.testName, res, tc.wantStats)
+		}
+	}
+}
@@ -2,16 +2,20 @@ package post
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(CreatePostMsg{}, "lino/createPost", nil)
-	cdc.RegisterConcrete(UpdatePostMsg{}, "lino/updatePost", nil)
-	cdc.RegisterConcrete(DeletePostMsg{}, "lino/deletePost", nil)
-	cdc.RegisterConcrete(DonateMsg{}, "lino/donate", nil)
-	cdc.RegisterConcrete(ViewMsg{}, "lino/view", nil)
-	cdc.RegisterConcrete(ReportOrUpvoteMsg{}, "lino/reportOrUpvote", nil)
+	types.RegisterMsg(cdc, CreatePostMsg{}, "lino/createPost")
+	types.RegisterMsg(cdc, UpdatePostMsg{}, "lino/updatePost")
+	types.RegisterMsg(cdc, DeletePostMsg{}, "lino/deletePost")
+	types.RegisterMsg(cdc, DonateMsg{}, "lino/donate")
+	types.RegisterMsg(cdc, ViewMsg{}, "lino/view")
+	types.RegisterMsg(cdc, ReportOrUpvoteMsg{}, "lino/reportOrUpvote")
+	types.RegisterMsg(cdc, UpdateReplyBlocklistMsg{}, "lino/updateReplyBlocklist")
+	types.RegisterMsg(cdc, UnlockPostMsg{}, "lino/unlockPost")
+	types.RegisterMsg(cdc, BatchDonateMsg{}, "lino/batchDonate")
 }
 
 var msgCdc = wire.NewCodec()
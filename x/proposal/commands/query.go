@@ -6,13 +6,29 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/lino-network/lino/client"
+	"github.com/lino-network/lino/client/core"
+	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal"
 	"github.com/lino-network/lino/x/proposal/model"
+	valmodel "github.com/lino-network/lino/x/validator/model"
+	votemodel "github.com/lino-network/lino/x/vote/model"
 )
 
+// proposalCdc - app codec doesn't register proposal types, decode proposals with this one
+var proposalCdc = newProposalCodec()
+
+func newProposalCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	model.RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	return cdc
+}
+
 // GetProposalCmd returns a specific ongoing proposal
 func GetOngoingProposalCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmdr := commander{
@@ -39,6 +55,52 @@ func GetExpiredProposalCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 }
 
+// GetTextProposalsCmd returns all ongoing and expired text proposals
+func GetTextProposalsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "query-text-proposals",
+		Short: "Query all ongoing and expired text proposals",
+		RunE:  cmdr.getTextProposalsCmd,
+	}
+}
+
+// ListProposalsCmd returns proposals filtered by status, type and creator page by page
+func ListProposalsCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	cmd := &cobra.Command{
+		Use:   "list-proposals",
+		Short: "List proposals filtered by status, type and creator",
+		RunE:  cmdr.listProposalsCmd,
+	}
+	cmd.Flags().String(client.FlagStatus, "all", "proposal status: ongoing, expired or all")
+	cmd.Flags().String(client.FlagProposalType, "",
+		"proposal type: changeParam, contentCensorship, protocolUpgrade, text or contentRestore")
+	cmd.Flags().String(client.FlagCreator, "", "creator of proposals")
+	cmd.Flags().Int(client.FlagPage, 1, "page number, starts from 1")
+	cmd.Flags().Int(client.FlagPageSize, 20, "number of proposals per page")
+	return cmd
+}
+
+// GetProposalTallyCmd returns current tally of an ongoing proposal
+func GetProposalTallyCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmdr := commander{
+		storeName,
+		cdc,
+	}
+	return &cobra.Command{
+		Use:   "proposal-tally <proposal-id>",
+		Short: "Query tally, pass progress and validators not voted of an ongoing proposal",
+		RunE:  cmdr.getProposalTallyCmd,
+	}
+}
+
 type commander struct {
 	storeName string
 	cdc       *wire.Codec
@@ -57,7 +119,7 @@ func (c commander) getOngoingProposalCmd(cmd *cobra.Command, args []string) erro
 		return err
 	}
 	proposal := new(model.Proposal)
-	if err := c.cdc.UnmarshalJSON(res, proposal); err != nil {
+	if err := proposalCdc.UnmarshalJSON(res, proposal); err != nil {
 		return err
 	}
 
@@ -83,7 +145,7 @@ func (c commander) getExpiredProposalCmd(cmd *cobra.Command, args []string) erro
 		return err
 	}
 	proposal := new(model.Proposal)
-	if err := c.cdc.UnmarshalJSON(res, proposal); err != nil {
+	if err := proposalCdc.UnmarshalJSON(res, proposal); err != nil {
 		return err
 	}
 
@@ -95,3 +157,184 @@ func (c commander) getExpiredProposalCmd(cmd *cobra.Command, args []string) erro
 	fmt.Println(string(output))
 	return nil
 }
+
+func (c commander) getTextProposalsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+
+	ongoing, err := c.getTextProposals(ctx, model.GetOngoingProposalPrefix())
+	if err != nil {
+		return err
+	}
+	expired, err := c.getTextProposals(ctx, model.GetExpiredProposalPrefix())
+	if err != nil {
+		return err
+	}
+
+	// print out proposals
+	output, err := json.MarshalIndent(struct {
+		Ongoing []*model.TextProposal `json:"ongoing"`
+		Expired []*model.TextProposal `json:"expired"`
+	}{ongoing, expired}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func (c commander) getTextProposals(ctx core.CoreContext, prefix []byte) ([]*model.TextProposal, error) {
+	resKVs, err := ctx.QuerySubspace(c.cdc, prefix, c.storeName)
+	if err != nil {
+		return nil, err
+	}
+	proposals := []*model.TextProposal{}
+	for _, KV := range resKVs {
+		var proposal model.Proposal
+		if err := proposalCdc.UnmarshalJSON(KV.Value, &proposal); err != nil {
+			return nil, err
+		}
+		if p, ok := proposal.(*model.TextProposal); ok {
+			proposals = append(proposals, p)
+		}
+	}
+	return proposals, nil
+}
+
+func (c commander) listProposalsCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+
+	var prefixes [][]byte
+	switch status := viper.GetString(client.FlagStatus); status {
+	case "ongoing":
+		prefixes = [][]byte{model.GetOngoingProposalPrefix()}
+	case "expired":
+		prefixes = [][]byte{model.GetExpiredProposalPrefix()}
+	case "all":
+		prefixes = [][]byte{model.GetOngoingProposalPrefix(), model.GetExpiredProposalPrefix()}
+	default:
+		return errors.Errorf("invalid proposal status: %s", status)
+	}
+
+	filter := proposal.ProposalFilter{
+		Creator: types.AccountKey(viper.GetString(client.FlagCreator)),
+	}
+	if typeName := viper.GetString(client.FlagProposalType); typeName != "" {
+		proposalType, err := proposal.GetProposalTypeByName(typeName)
+		if err != nil {
+			return err
+		}
+		filter.ProposalTypes = []types.ProposalType{proposalType}
+	}
+
+	proposals := []model.Proposal{}
+	for _, prefix := range prefixes {
+		res, err := c.getProposals(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		proposals = append(proposals, res...)
+	}
+	proposals = proposal.PaginateProposals(
+		proposal.FilterProposals(proposals, filter),
+		viper.GetInt(client.FlagPage), viper.GetInt(client.FlagPageSize))
+
+	// print out proposals
+	output, err := proposalCdc.MarshalJSONIndent(proposals, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func (c commander) getProposalTallyCmd(cmd *cobra.Command, args []string) error {
+	ctx := client.NewCoreContextFromViper()
+	if len(args) != 1 {
+		return errors.New("You must provide proposal ID")
+	}
+
+	proposalID := types.ProposalKey(args[0])
+
+	res, err := ctx.Query(model.GetOngoingProposalKey(proposalID), c.storeName)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return errors.Errorf("ongoing proposal %s not found", proposalID)
+	}
+	var ongoingProposal model.Proposal
+	if err := proposalCdc.UnmarshalJSON(res, &ongoingProposal); err != nil {
+		return err
+	}
+
+	res, err = ctx.Query(param.GetProposalParamKey(), types.ParamKVStoreKey)
+	if err != nil {
+		return err
+	}
+	proposalParam := new(param.ProposalParam)
+	if err := c.cdc.UnmarshalJSON(res, proposalParam); err != nil {
+		return err
+	}
+
+	res, err = ctx.Query(valmodel.GetValidatorListKey(), types.ValidatorKVStoreKey)
+	if err != nil {
+		return err
+	}
+	validatorList := new(valmodel.ValidatorList)
+	if err := c.cdc.UnmarshalJSON(res, validatorList); err != nil {
+		return err
+	}
+
+	resKVs, err := ctx.QuerySubspace(c.cdc, votemodel.GetVotePrefix(proposalID), types.VoteKVStoreKey)
+	if err != nil {
+		return err
+	}
+	votes := []votemodel.Vote{}
+	for _, KV := range resKVs {
+		var vote votemodel.Vote
+		if err := c.cdc.UnmarshalJSON(KV.Value, &vote); err != nil {
+			return err
+		}
+		votes = append(votes, vote)
+	}
+
+	node, err := ctx.GetNode()
+	if err != nil {
+		return err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return err
+	}
+
+	tally, sdkErr := proposal.NewProposalTally(
+		ongoingProposal, proposalParam, validatorList.OncallValidators, votes,
+		status.SyncInfo.LatestBlockTime.Unix())
+	if sdkErr != nil {
+		return sdkErr
+	}
+
+	// print out tally
+	output, err := json.MarshalIndent(tally, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func (c commander) getProposals(ctx core.CoreContext, prefix []byte) ([]model.Proposal, error) {
+	resKVs, err := ctx.QuerySubspace(c.cdc, prefix, c.storeName)
+	if err != nil {
+		return nil, err
+	}
+	proposals := []model.Proposal{}
+	for _, KV := range resKVs {
+		var p model.Proposal
+		if err := proposalCdc.UnmarshalJSON(KV.Value, &p); err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, p)
+	}
+	return proposals, nil
+}
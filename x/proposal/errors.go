@@ -71,3 +71,28 @@ func ErrCensorshipPostNotFound() sdk.Error {
 func ErrIllegalParameter() sdk.Error {
 	return types.NewError(types.CodeIllegalParameter, fmt.Sprintf("invalid parameter"))
 }
+
+// ErrInvalidProposalTitle - error if text proposal title is empty or too long
+func ErrInvalidProposalTitle() sdk.Error {
+	return types.NewError(types.CodeInvalidProposalTitle, fmt.Sprintf("invalid proposal title"))
+}
+
+// ErrProposalDescriptionTooLong - error if text proposal description is too long
+func ErrProposalDescriptionTooLong() sdk.Error {
+	return types.NewError(types.CodeProposalDescriptionTooLong, fmt.Sprintf("proposal description is too long"))
+}
+
+// ErrDiscussionPostNotFound - error if discussion post of text proposal is not found
+func ErrDiscussionPostNotFound() sdk.Error {
+	return types.NewError(types.CodeDiscussionPostNotFound, fmt.Sprintf("discussion post not found"))
+}
+
+// ErrRestorePostNotCensored - error if post to restore is not censored
+func ErrRestorePostNotCensored(permlink types.Permlink) sdk.Error {
+	return types.NewError(types.CodeRestorePostNotCensored, fmt.Sprintf("post %v is not censored", permlink))
+}
+
+// ErrRestorePostIsDeleted - error if post to restore is deleted by its author
+func ErrRestorePostIsDeleted(permlink types.Permlink) sdk.Error {
+	return types.NewError(types.CodeRestorePostIsDeleted, fmt.Sprintf("post %v is deleted by author", permlink))
+}
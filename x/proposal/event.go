@@ -70,13 +70,20 @@ func (dpe DecideProposalEvent) Execute(
 			return err
 		}
 	case types.ContentCensorship:
-		if err := dpe.ExecuteContentCensorship(ctx, dpe.ProposalID, proposalManager, postManager); err != nil {
+		if err := dpe.ExecuteContentCensorship(
+			ctx, dpe.ProposalID, proposalManager, postManager, am, gm); err != nil {
 			return err
 		}
 	case types.ProtocolUpgrade:
 		if err := dpe.ExecuteProtocolUpgrade(ctx, dpe.ProposalID, proposalManager); err != nil {
 			return err
 		}
+	case types.ContentRestore:
+		if err := dpe.ExecuteContentRestore(ctx, dpe.ProposalID, proposalManager, postManager); err != nil {
+			return err
+		}
+	case types.TextProposal:
+		// text proposal is non-binding, result is recorded in expired proposal
 	}
 	return nil
 }
@@ -95,20 +102,73 @@ func (dpe DecideProposalEvent) ExecuteChangeParam(
 	return nil
 }
 
-// ExecuteContentCensorship - delete target post
+// ExecuteContentCensorship - delete target post and cancel its reward events
+// still in the consumption freezing period
 func (dpe DecideProposalEvent) ExecuteContentCensorship(
 	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager,
-	postManager post.PostManager) sdk.Error {
+	postManager post.PostManager, am acc.AccountManager, gm global.GlobalManager) sdk.Error {
 	permlink, err := proposalManager.GetPermlink(ctx, curID)
 	if err != nil {
 		return err
 	}
 
-	// TODO add content censorship logic
+	reason, err := proposalManager.GetCensorshipReason(ctx, curID)
+	if err != nil {
+		return err
+	}
+
 	if exist := postManager.DoesPostExist(ctx, permlink); !exist {
 		return ErrCensorshipPostNotFound()
 	}
-	if err := postManager.DeletePost(ctx, permlink); err != nil {
+	// post has been deleted by author before censorship takes effect
+	isDeleted, err := postManager.IsDeletedByAuthor(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	if isDeleted {
+		return nil
+	}
+	if err := postManager.CensorPost(ctx, permlink, curID, reason); err != nil {
+		return err
+	}
+	// decide event may be executed in a block later than its time, reward events
+	// registered between the decide event and current block time are cancelled as well
+	decideTime, err := proposalManager.GetDecideTime(ctx, curID)
+	if err != nil {
+		return err
+	}
+	if decideTime > ctx.BlockHeader().Time.Unix() {
+		decideTime = ctx.BlockHeader().Time.Unix()
+	}
+	if err := post.CancelPendingRewardEvents(
+		ctx, permlink, decideTime, postManager, am, gm); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ExecuteContentRestore - restore censored post from tombstone
+func (dpe DecideProposalEvent) ExecuteContentRestore(
+	ctx sdk.Context, curID types.ProposalKey, proposalManager ProposalManager,
+	postManager post.PostManager) sdk.Error {
+	permlink, err := proposalManager.GetPermlink(ctx, curID)
+	if err != nil {
+		return err
+	}
+
+	// post has been restored by other proposal
+	if !postManager.IsCensored(ctx, permlink) {
+		return nil
+	}
+	// post has been deleted by author after censorship, content stays deleted
+	isDeleted, err := postManager.IsDeletedByAuthor(ctx, permlink)
+	if err != nil {
+		return err
+	}
+	if isDeleted {
+		return nil
+	}
+	if err := postManager.RestorePost(ctx, permlink, curID); err != nil {
 		return err
 	}
 	return nil
@@ -8,8 +8,54 @@ import (
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/proposal/model"
 	"github.com/stretchr/testify/assert"
+
+	postmodel "github.com/lino-network/lino/x/post/model"
 )
 
+func TestExecuteContentCensorshipAndRestore(t *testing.T) {
+	ctx, am, pm, postManager, _, _, gm := setupTest(t, 0)
+	pm.InitGenesis(ctx)
+
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID", types.NewCoinFromInt64(0), am, postManager, "0")
+	permlink := types.GetPermlink(user1, postID1)
+	censorship := pm.CreateContentCensorshipProposal(ctx, permlink, "reason")
+	censorshipID, _ := pm.AddProposal(ctx, user1, censorship, 10)
+	restore := pm.CreateContentRestoreProposal(ctx, permlink, "appeal")
+	restoreID, _ := pm.AddProposal(ctx, user1, restore, 10)
+	assert.Nil(t, pm.storage.SetExpiredProposal(ctx, censorshipID, censorship))
+	assert.Nil(t, pm.storage.SetExpiredProposal(ctx, restoreID, restore))
+
+	e1 := DecideProposalEvent{ProposalType: types.ContentCensorship, ProposalID: censorshipID}
+	err := e1.ExecuteContentCensorship(ctx, censorshipID, pm, postManager, am, gm)
+	assert.Nil(t, err)
+	isDeleted, _ := postManager.IsDeleted(ctx, permlink)
+	assert.True(t, isDeleted)
+	assert.True(t, postManager.IsCensored(ctx, permlink))
+
+	e2 := DecideProposalEvent{ProposalType: types.ContentRestore, ProposalID: restoreID}
+	err = e2.ExecuteContentRestore(ctx, restoreID, pm, postManager)
+	assert.Nil(t, err)
+	isDeleted, _ = postManager.IsDeleted(ctx, permlink)
+	assert.False(t, isDeleted)
+	assert.False(t, postManager.IsCensored(ctx, permlink))
+
+	// restore again is no-op
+	err = e2.ExecuteContentRestore(ctx, restoreID, pm, postManager)
+	assert.Nil(t, err)
+
+	history, err := postManager.GetCensorshipHistory(ctx, permlink)
+	assert.Nil(t, err)
+	assert.Equal(t, []postmodel.CensorshipRecord{
+		{
+			ProposalID:        censorshipID,
+			Reason:            "reason",
+			CensoredAt:        ctx.BlockHeader().Time.Unix(),
+			RestoreProposalID: restoreID,
+			RestoredAt:        ctx.BlockHeader().Time.Unix(),
+		},
+	}, history)
+}
+
 func TestDecideProposal(t *testing.T) {
 	ctx, am, pm, postManager, voteManager, valManager, gm := setupTest(t, 0)
 	voteManager.InitGenesis(ctx)
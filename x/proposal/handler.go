@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/global"
 	"github.com/lino-network/lino/x/post"
@@ -21,10 +22,14 @@ func NewHandler(
 		switch msg := msg.(type) {
 		case ChangeParamMsg:
 			return handleChangeParamMsg(ctx, am, proposalManager, gm, msg)
+		case RestorePostContentMsg:
+			return handleRestorePostContentMsg(ctx, am, proposalManager, postManager, gm, msg)
 		case ContentCensorshipMsg:
 			return handleContentCensorshipMsg(ctx, am, proposalManager, postManager, gm, msg)
 		case ProtocolUpgradeMsg:
 			return handleProtocolUpgradeMsg(ctx, am, proposalManager, gm, msg)
+		case SubmitTextProposalMsg:
+			return handleSubmitTextProposalMsg(ctx, am, proposalManager, postManager, gm, msg)
 		case VoteProposalMsg:
 			return handleVoteProposalMsg(ctx, proposalManager, vm, msg)
 		default:
@@ -41,6 +46,12 @@ func handleChangeParamMsg(
 		return ErrAccountNotFound().Result()
 	}
 
+	if patch, ok := msg.GetParameter().(param.ParamFieldsPatch); ok {
+		if err := pm.ValidateParamFieldsPatch(ctx, msg.GetCreator(), patch, msg.GetReason()); err != nil {
+			return err.Result()
+		}
+	}
+
 	param, err := pm.paramHolder.GetProposalParam(ctx)
 	if err != nil {
 		return err.Result()
@@ -162,6 +173,101 @@ func handleContentCensorshipMsg(
 	return sdk.Result{}
 }
 
+func handleRestorePostContentMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager,
+	postManager post.PostManager, gm global.GlobalManager, msg RestorePostContentMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Creator) {
+		return ErrAccountNotFound().Result()
+	}
+
+	if !postManager.DoesPostExist(ctx, msg.Permlink) {
+		return ErrPostNotFound().Result()
+	}
+
+	if !postManager.IsCensored(ctx, msg.Permlink) {
+		return ErrRestorePostNotCensored(msg.Permlink).Result()
+	}
+
+	if isDeleted, err := postManager.IsDeletedByAuthor(ctx, msg.Permlink); isDeleted || err != nil {
+		return ErrRestorePostIsDeleted(msg.Permlink).Result()
+	}
+
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposal := pm.CreateContentRestoreProposal(ctx, msg.Permlink, msg.Reason)
+	proposalID, err := pm.AddProposal(ctx, msg.Creator, proposal, param.ContentCensorshipDecideSec)
+	if err != nil {
+		return err.Result()
+	}
+	//  set a time event to decide the proposal
+	event := pm.CreateDecideProposalEvent(ctx, types.ContentRestore, proposalID)
+
+	if err := gm.RegisterProposalDecideEvent(ctx, param.ContentCensorshipDecideSec, event); err != nil {
+		return err.Result()
+	}
+
+	// minus coin from account and return when deciding the proposal
+	if err = am.MinusSavingCoin(
+		ctx, msg.Creator, param.ContentCensorshipMinDeposit,
+		"", string(proposalID), types.ProposalDeposit); err != nil {
+		return err.Result()
+	}
+
+	if err := returnCoinTo(
+		ctx, msg.Creator, gm, am, int64(1),
+		param.ContentCensorshipDecideSec, param.ContentCensorshipMinDeposit); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+func handleSubmitTextProposalMsg(
+	ctx sdk.Context, am acc.AccountManager, pm ProposalManager,
+	postManager post.PostManager, gm global.GlobalManager, msg SubmitTextProposalMsg) sdk.Result {
+	if !am.DoesAccountExist(ctx, msg.Creator) {
+		return ErrAccountNotFound().Result()
+	}
+
+	// discussion happens in comments of the linked post
+	if len(msg.Permlink) != 0 && !postManager.DoesPostExist(ctx, msg.Permlink) {
+		return ErrDiscussionPostNotFound().Result()
+	}
+
+	param, err := pm.paramHolder.GetProposalParam(ctx)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposal := pm.CreateTextProposal(ctx, msg.Title, msg.Description, msg.Permlink, msg.Reason)
+	proposalID, err := pm.AddProposal(ctx, msg.Creator, proposal, param.TextProposalDecideSec)
+	if err != nil {
+		return err.Result()
+	}
+	//  set a time event to decide the proposal
+	event := pm.CreateDecideProposalEvent(ctx, types.TextProposal, proposalID)
+
+	if err := gm.RegisterProposalDecideEvent(ctx, param.TextProposalDecideSec, event); err != nil {
+		return err.Result()
+	}
+
+	// minus coin from account and return when deciding the proposal
+	if err = am.MinusSavingCoin(
+		ctx, msg.Creator, param.TextProposalMinDeposit,
+		"", string(proposalID), types.ProposalDeposit); err != nil {
+		return err.Result()
+	}
+
+	if err := returnCoinTo(
+		ctx, msg.Creator, gm, am, int64(1),
+		param.TextProposalDecideSec, param.TextProposalMinDeposit); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
 func handleVoteProposalMsg(ctx sdk.Context, proposalManager ProposalManager, vm vote.VoteManager, msg VoteProposalMsg) sdk.Result {
 	if !vm.DoesVoterExist(ctx, msg.Voter) {
 		return ErrVoterNotFound().Result()
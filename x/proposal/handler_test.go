@@ -124,6 +124,140 @@ func TestChangeParamProposal(t *testing.T) {
 	}
 }
 
+func TestChangeParamFieldsProposal(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
+	handler := NewHandler(am, proposalManager, postManager, gm, vm)
+	proposalManager.InitGenesis(ctx)
+
+	user1 := createTestAccount(ctx, am, "user1", c460000)
+	proposalID1 := types.ProposalKey(strconv.FormatInt(int64(1), 10))
+
+	testCases := []struct {
+		testName string
+		msg      ChangeParamFieldsMsg
+		wantRes  sdk.Result
+	}{
+		{
+			testName: "allocation doesn't sum up to one after patch",
+			msg: NewChangeParamFieldsMsg(
+				string(user1), param.GlobalAllocationParamName,
+				map[string]string{"infra_allocation": "0.5"}, ""),
+			wantRes: ErrIllegalParameter().Result(),
+		},
+		{
+			testName: "unknown param",
+			msg: NewChangeParamFieldsMsg(
+				string(user1), "unknown_param", map[string]string{"infra_allocation": "0.5"}, ""),
+			wantRes: param.ErrParamNameNotFound("unknown_param").Result(),
+		},
+		{
+			testName: "user1 creates change param fields msg successfully",
+			msg: NewChangeParamFieldsMsg(
+				string(user1), param.PostParamName,
+				map[string]string{"post_interval_sec": "100"}, ""),
+			wantRes: sdk.Result{},
+		},
+	}
+	for _, tc := range testCases {
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.wantRes, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantRes)
+		}
+	}
+
+	proposal, err := proposalManager.storage.GetOngoingProposal(ctx, proposalID1)
+	assert.Nil(t, err)
+	changeParamProposal, ok := proposal.(*model.ChangeParamProposal)
+	assert.True(t, ok)
+	assert.Equal(t, param.ParamFieldsPatch{
+		ParamName: param.PostParamName,
+		Fields:    []param.ParamField{{Name: "post_interval_sec", Value: "100"}},
+	}, changeParamProposal.Param)
+}
+
+func TestTextProposal(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
+	handler := NewHandler(am, proposalManager, postManager, gm, vm)
+	curTime := ctx.BlockHeader().Time.Unix()
+	proposalParam, _ := proposalManager.paramHolder.GetProposalParam(ctx)
+	proposalManager.InitGenesis(ctx)
+
+	proposalID1 := types.ProposalKey(strconv.FormatInt(int64(1), 10))
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID", c4600, am, postManager, "0")
+	user2 := createTestAccount(
+		ctx, am, "user2", proposalParam.TextProposalMinDeposit.Minus(types.NewCoinFromInt64(1)))
+	discussion := types.GetPermlink(user1, postID1)
+
+	proposal1 := &model.TextProposal{
+		ProposalInfo: model.ProposalInfo{
+			Creator:       user1,
+			ProposalID:    proposalID1,
+			AgreeVotes:    types.NewCoinFromInt64(0),
+			DisagreeVotes: types.NewCoinFromInt64(0),
+			Result:        types.ProposalNotPass,
+			CreatedAt:     curTime,
+			ExpiredAt:     curTime + proposalParam.TextProposalDecideSec,
+		},
+		Title:       "title",
+		Description: "description",
+		Permlink:    discussion,
+		Reason:      "reason",
+	}
+
+	testCases := []struct {
+		testName           string
+		msg                SubmitTextProposalMsg
+		wantRes            sdk.Result
+		wantCreatorBalance types.Coin
+		wantProposal       model.Proposal
+	}{
+		{
+			testName: "discussion post doesn't exist",
+			msg: NewSubmitTextProposalMsg(
+				string(user1), "title", "description", types.GetPermlink(user1, "invalid"), "reason"),
+			wantRes: ErrDiscussionPostNotFound().Result(),
+		},
+		{
+			testName: "creator doesn't exist",
+			msg:      NewSubmitTextProposalMsg("invalid", "title", "description", discussion, "reason"),
+			wantRes:  ErrAccountNotFound().Result(),
+		},
+		{
+			testName:           "user1 creates text proposal successfully",
+			msg:                NewSubmitTextProposalMsg(string(user1), "title", "description", discussion, "reason"),
+			wantRes:            sdk.Result{},
+			wantCreatorBalance: c4600.Minus(proposalParam.TextProposalMinDeposit),
+			wantProposal:       proposal1,
+		},
+		{
+			testName: "user2 doesn't have enough money to create proposal",
+			msg:      NewSubmitTextProposalMsg(string(user2), "title", "description", discussion, "reason"),
+			wantRes:  acc.ErrAccountSavingCoinNotEnough().Result(),
+		},
+	}
+	for _, tc := range testCases {
+		result := handler(ctx, tc.msg)
+		if !assert.Equal(t, tc.wantRes, result) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.wantRes)
+		}
+		if !result.IsOK() {
+			continue
+		}
+
+		creatorBalance, _ := am.GetSavingFromBank(ctx, tc.msg.Creator)
+		if !creatorBalance.IsEqual(tc.wantCreatorBalance) {
+			t.Errorf("%s: diff bank balance: got %v, want %v", tc.testName, creatorBalance, tc.wantCreatorBalance)
+		}
+		proposal, err := proposalManager.storage.GetOngoingProposal(ctx, proposalID1)
+		if err != nil {
+			t.Errorf("%s: failed to get proposal, get err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantProposal, proposal) {
+			t.Errorf("%s: diff proposal, got %v, want %v", tc.testName, proposal, tc.wantProposal)
+		}
+	}
+}
+
 func TestContentCensorshipProposal(t *testing.T) {
 	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
 	handler := NewHandler(am, proposalManager, postManager, gm, vm)
@@ -260,6 +394,55 @@ func TestContentCensorshipProposal(t *testing.T) {
 	}
 }
 
+func TestContentRestoreProposal(t *testing.T) {
+	ctx, am, proposalManager, postManager, vm, _, gm := setupTest(t, 0)
+	handler := NewHandler(am, proposalManager, postManager, gm, vm)
+	curTime := ctx.BlockHeader().Time.Unix()
+	proposalParam, _ := proposalManager.paramHolder.GetProposalParam(ctx)
+	proposalManager.InitGenesis(ctx)
+
+	proposalID1 := types.ProposalKey(strconv.FormatInt(int64(1), 10))
+	user1, postID1 := createTestPost(t, ctx, "user1", "postID", c4600, am, postManager, "0")
+	permlink := types.GetPermlink(user1, postID1)
+
+	proposal1 := &model.ContentRestoreProposal{
+		ProposalInfo: model.ProposalInfo{
+			Creator:       user1,
+			ProposalID:    proposalID1,
+			AgreeVotes:    types.NewCoinFromInt64(0),
+			DisagreeVotes: types.NewCoinFromInt64(0),
+			Result:        types.ProposalNotPass,
+			CreatedAt:     curTime,
+			ExpiredAt:     curTime + proposalParam.ContentCensorshipDecideSec,
+		},
+		Permlink: permlink,
+		Reason:   "appeal",
+	}
+
+	result := handler(ctx, NewRestorePostContentMsg(string(user1), types.GetPermlink(user1, "invalid"), "appeal"))
+	assert.Equal(t, ErrPostNotFound().Result(), result)
+
+	result = handler(ctx, NewRestorePostContentMsg(string(user1), permlink, "appeal"))
+	assert.Equal(t, ErrRestorePostNotCensored(permlink).Result(), result)
+
+	err := postManager.CensorPost(ctx, permlink, types.ProposalKey("0"), "reason")
+	assert.Nil(t, err)
+	result = handler(ctx, NewRestorePostContentMsg(string(user1), permlink, "appeal"))
+	assert.Equal(t, sdk.Result{}, result)
+
+	creatorBalance, _ := am.GetSavingFromBank(ctx, user1)
+	assert.Equal(t, c4600.Minus(proposalParam.ContentCensorshipMinDeposit), creatorBalance)
+	proposal, err := proposalManager.storage.GetOngoingProposal(ctx, proposalID1)
+	assert.Nil(t, err)
+	assert.Equal(t, proposal1, proposal)
+
+	// censored post deleted by author can't be restored
+	err = postManager.DeletePost(ctx, permlink)
+	assert.Nil(t, err)
+	result = handler(ctx, NewRestorePostContentMsg(string(user1), permlink, "appeal"))
+	assert.Equal(t, ErrRestorePostIsDeleted(permlink).Result(), result)
+}
+
 func TestAddFrozenMoney(t *testing.T) {
 	ctx, am, proposalManager, _, _, _, gm := setupTest(t, 0)
 	proposalManager.InitGenesis(ctx)
@@ -1,6 +1,7 @@
 package proposal
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/lino-network/lino/param"
@@ -52,6 +53,15 @@ func (pm ProposalManager) CreateContentCensorshipProposal(
 	}
 }
 
+// CreateContentRestoreProposal - create a content restore proposal
+func (pm ProposalManager) CreateContentRestoreProposal(
+	ctx sdk.Context, permlink types.Permlink, reason string) model.Proposal {
+	return &model.ContentRestoreProposal{
+		Permlink: permlink,
+		Reason:   reason,
+	}
+}
+
 // CreateProtocolUpgradeProposal - create a protocol upgrade proposal
 func (pm ProposalManager) CreateProtocolUpgradeProposal(ctx sdk.Context, link string, reason string) model.Proposal {
 	return &model.ProtocolUpgradeProposal{
@@ -69,6 +79,31 @@ func (pm ProposalManager) CreateChangeParamProposal(
 	}
 }
 
+// CreateTextProposal - create a text proposal
+func (pm ProposalManager) CreateTextProposal(
+	ctx sdk.Context, title, description string, permlink types.Permlink, reason string) model.Proposal {
+	return &model.TextProposal{
+		Title:       title,
+		Description: description,
+		Permlink:    permlink,
+		Reason:      reason,
+	}
+}
+
+// ValidateParamFieldsPatch - apply patch to current parameter and check the result
+func (pm ProposalManager) ValidateParamFieldsPatch(
+	ctx sdk.Context, creator types.AccountKey, patch param.ParamFieldsPatch, reason string) sdk.Error {
+	current, err := pm.paramHolder.GetParam(ctx, patch.ParamName)
+	if err != nil {
+		return err
+	}
+	patched, err := param.ApplyParamFields(current, patch.Fields)
+	if err != nil {
+		return err
+	}
+	return validateParameter(creator, patched, reason)
+}
+
 // GetNextProposalID - get next proposal ID from KV store
 func (pm ProposalManager) GetNextProposalID(ctx sdk.Context) (types.ProposalKey, sdk.Error) {
 	nextProposalID, err := pm.storage.GetNextProposalID(ctx)
@@ -131,13 +166,20 @@ func (pm ProposalManager) GetProposalPassParam(
 	if err != nil {
 		return sdk.NewRat(1, 1), types.NewCoinFromInt64(0), err
 	}
+	return getProposalPassParam(param, proposalType)
+}
+
+func getProposalPassParam(
+	proposalParam *param.ProposalParam, proposalType types.ProposalType) (sdk.Rat, types.Coin, sdk.Error) {
 	switch proposalType {
 	case types.ChangeParam:
-		return param.ChangeParamPassRatio, param.ChangeParamPassVotes, nil
-	case types.ContentCensorship:
-		return param.ContentCensorshipPassRatio, param.ContentCensorshipPassVotes, nil
+		return proposalParam.ChangeParamPassRatio, proposalParam.ChangeParamPassVotes, nil
+	case types.ContentCensorship, types.ContentRestore:
+		return proposalParam.ContentCensorshipPassRatio, proposalParam.ContentCensorshipPassVotes, nil
 	case types.ProtocolUpgrade:
-		return param.ProtocolUpgradePassRatio, param.ProtocolUpgradePassVotes, nil
+		return proposalParam.ProtocolUpgradePassRatio, proposalParam.ProtocolUpgradePassVotes, nil
+	case types.TextProposal:
+		return proposalParam.TextProposalPassRatio, proposalParam.TextProposalPassVotes, nil
 	default:
 		return sdk.NewRat(1, 1), types.NewCoinFromInt64(0), ErrIncorrectProposalType()
 	}
@@ -228,11 +270,28 @@ func (pm ProposalManager) CreateParamChangeEvent(
 	}
 
 	event := param.ChangeParamEvent{
-		Param: p.Param,
+		Param:      p.Param,
+		ProposalID: proposalID,
 	}
 	return event, nil
 }
 
+// SetParamChangeFailure - record why the passed parameter change proposal
+// was rejected when its change param event executed
+func (pm ProposalManager) SetParamChangeFailure(
+	ctx sdk.Context, proposalID types.ProposalKey, failure sdk.Error) sdk.Error {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	p, ok := proposal.(*model.ChangeParamProposal)
+	if !ok {
+		return ErrIncorrectProposalType()
+	}
+	p.ApplyFailure = fmt.Sprintf("%v", failure.Data())
+	return pm.storage.SetExpiredProposal(ctx, proposalID, p)
+}
+
 // GetPermlink - get permlink from expired proposal list
 func (pm ProposalManager) GetPermlink(ctx sdk.Context, proposalID types.ProposalKey) (types.Permlink, sdk.Error) {
 	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
@@ -240,11 +299,37 @@ func (pm ProposalManager) GetPermlink(ctx sdk.Context, proposalID types.Proposal
 		return types.Permlink(""), err
 	}
 
+	switch p := proposal.(type) {
+	case *model.ContentCensorshipProposal:
+		return p.Permlink, nil
+	case *model.ContentRestoreProposal:
+		return p.Permlink, nil
+	default:
+		return types.Permlink(""), ErrIncorrectProposalType()
+	}
+}
+
+// GetDecideTime - get the time decide event of the expired proposal is registered at
+func (pm ProposalManager) GetDecideTime(ctx sdk.Context, proposalID types.ProposalKey) (int64, sdk.Error) {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return 0, err
+	}
+	return proposal.GetProposalInfo().ExpiredAt, nil
+}
+
+// GetCensorshipReason - get censorship reason from expired content censorship proposal
+func (pm ProposalManager) GetCensorshipReason(ctx sdk.Context, proposalID types.ProposalKey) (string, sdk.Error) {
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID)
+	if err != nil {
+		return "", err
+	}
+
 	p, ok := proposal.(*model.ContentCensorshipProposal)
 	if !ok {
-		return types.Permlink(""), ErrIncorrectProposalType()
+		return "", ErrIncorrectProposalType()
 	}
-	return p.Permlink, nil
+	return p.Reason, nil
 }
 
 // GetOngoingProposalList - get ongoing proposal list
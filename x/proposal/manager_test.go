@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lino-network/lino/param"
 	"github.com/lino-network/lino/types"
 	"github.com/lino-network/lino/x/proposal/model"
 	"github.com/stretchr/testify/assert"
@@ -264,6 +265,14 @@ func TestGetProposalPassParam(t *testing.T) {
 			wantPassVotes: proposalParam.ProtocolUpgradePassVotes,
 		},
 
+		{
+			testName:      "test pass param for textProposal",
+			proposalType:  types.TextProposal,
+			wantError:     nil,
+			wantPassRatio: proposalParam.TextProposalPassRatio,
+			wantPassVotes: proposalParam.TextProposalPassVotes,
+		},
+
 		{
 			testName:      "test wrong proposal type",
 			proposalType:  23,
@@ -290,3 +299,32 @@ func TestGetProposalPassParam(t *testing.T) {
 	}
 
 }
+
+func TestParamChangeFailure(t *testing.T) {
+	ctx, _, pm, _, _, _, _ := setupTest(t, 0)
+	user1 := types.AccountKey("user1")
+	pm.InitGenesis(ctx)
+	patch := param.NewParamFieldsPatch(param.PostParamName, map[string]string{"post_interval_sec": "300"})
+	proposalID1, err := pm.AddProposal(ctx, user1, &model.ChangeParamProposal{Param: patch}, 100)
+	assert.Nil(t, err)
+	proposalID2, err := pm.AddProposal(ctx, user1, &model.ContentCensorshipProposal{Permlink: "permlink"}, 100)
+	assert.Nil(t, err)
+	for _, proposalID := range []types.ProposalKey{proposalID1, proposalID2} {
+		proposal, err := pm.storage.GetOngoingProposal(ctx, proposalID)
+		assert.Nil(t, err)
+		assert.Nil(t, pm.storage.SetExpiredProposal(ctx, proposalID, proposal))
+	}
+
+	event, err := pm.CreateParamChangeEvent(ctx, proposalID1)
+	assert.Nil(t, err)
+	assert.Equal(t, param.ChangeParamEvent{Param: patch, ProposalID: proposalID1}, event)
+
+	err = pm.SetParamChangeFailure(ctx, proposalID1, param.ErrParamPatchRejected(param.PostParamName, ErrIllegalParameter()))
+	assert.Nil(t, err)
+	proposal, err := pm.storage.GetExpiredProposal(ctx, proposalID1)
+	assert.Nil(t, err)
+	assert.Equal(t, "patch on param post_param rejected: invalid parameter", proposal.(*model.ChangeParamProposal).ApplyFailure)
+
+	err = pm.SetParamChangeFailure(ctx, proposalID2, param.ErrParamPatchRejected(param.PostParamName, ErrIllegalParameter()))
+	assert.Equal(t, ErrIncorrectProposalType(), err)
+}
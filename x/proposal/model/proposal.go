@@ -5,10 +5,12 @@ import (
 	types "github.com/lino-network/lino/types"
 )
 
-// Proposal - there are three proposal types
+// Proposal - there are five proposal types
 // 1) change parameter proposal
 // 2) content censorship proposal
 // 3) protocol upgrade proposal
+// 4) text proposal
+// 5) content restore proposal
 type Proposal interface {
 	GetProposalInfo() ProposalInfo
 	SetProposalInfo(ProposalInfo)
@@ -31,6 +33,8 @@ type ChangeParamProposal struct {
 	ProposalInfo
 	Param  param.Parameter `json:"param"`
 	Reason string          `json:"reason"`
+	// ApplyFailure - why the passed parameter change was rejected at execution time
+	ApplyFailure string `json:"apply_failure"`
 }
 
 // GetProposalInfo - implements Proposal
@@ -65,6 +69,34 @@ func (p *ProtocolUpgradeProposal) GetProposalInfo() ProposalInfo { return p.Prop
 // SetProposalInfo - implements Proposal
 func (p *ProtocolUpgradeProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
 
+// ContentRestoreProposal - restore a censored post
+type ContentRestoreProposal struct {
+	ProposalInfo
+	Permlink types.Permlink `json:"permlink"`
+	Reason   string         `json:"reason"`
+}
+
+// GetProposalInfo - implements Proposal
+func (p *ContentRestoreProposal) GetProposalInfo() ProposalInfo { return p.ProposalInfo }
+
+// SetProposalInfo - implements Proposal
+func (p *ContentRestoreProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
+
+// TextProposal - non-binding text proposal, only the result is recorded
+type TextProposal struct {
+	ProposalInfo
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Permlink    types.Permlink `json:"permlink"`
+	Reason      string         `json:"reason"`
+}
+
+// GetProposalInfo - implements Proposal
+func (p *TextProposal) GetProposalInfo() ProposalInfo { return p.ProposalInfo }
+
+// SetProposalInfo - implements Proposal
+func (p *TextProposal) SetProposalInfo(info ProposalInfo) { p.ProposalInfo = info }
+
 // NextProposalID - store next proposal ID to KVStore
 type NextProposalID struct {
 	NextProposalID int64 `json:"next_proposal_id"`
@@ -21,11 +21,25 @@ type ProposalStorage struct {
 
 func NewProposalStorage(key sdk.StoreKey) ProposalStorage {
 	cdc := wire.NewCodec()
+	RegisterWire(cdc)
 
+	wire.RegisterCrypto(cdc)
+	vs := ProposalStorage{
+		key: key,
+		cdc: cdc,
+	}
+	return vs
+}
+
+// RegisterWire - register proposal and parameter types on given codec,
+// clients use it to decode proposals read from the proposal KVStore
+func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&ChangeParamProposal{}, "changeParam", nil)
 	cdc.RegisterConcrete(&ProtocolUpgradeProposal{}, "upgrade", nil)
 	cdc.RegisterConcrete(&ContentCensorshipProposal{}, "censorship", nil)
+	cdc.RegisterConcrete(&TextProposal{}, "text", nil)
+	cdc.RegisterConcrete(&ContentRestoreProposal{}, "restore", nil)
 
 	cdc.RegisterInterface((*param.Parameter)(nil), nil)
 	cdc.RegisterConcrete(param.GlobalAllocationParam{}, "allocation", nil)
@@ -39,13 +53,8 @@ func NewProposalStorage(key sdk.StoreKey) ProposalStorage {
 	cdc.RegisterConcrete(param.BandwidthParam{}, "bandwidthParam", nil)
 	cdc.RegisterConcrete(param.AccountParam{}, "accountParam", nil)
 	cdc.RegisterConcrete(param.PostParam{}, "postParam", nil)
-
-	wire.RegisterCrypto(cdc)
-	vs := ProposalStorage{
-		key: key,
-		cdc: cdc,
-	}
-	return vs
+	cdc.RegisterConcrete(param.ReputationParam{}, "reputationParam", nil)
+	cdc.RegisterConcrete(param.ParamFieldsPatch{}, "paramFieldsPatch", nil)
 }
 
 // InitGenesis - initialize proposal storage
@@ -204,6 +213,16 @@ func GetExpiredProposalKey(proposalID types.ProposalKey) []byte {
 	return append(expiredProposalSubStore, proposalID...)
 }
 
+// GetOngoingProposalPrefix - "ongoing proposal substore"
+func GetOngoingProposalPrefix() []byte {
+	return ongoingProposalSubStore
+}
+
+// GetExpiredProposalPrefix - "expired proposal substore"
+func GetExpiredProposalPrefix() []byte {
+	return expiredProposalSubStore
+}
+
 func getNextProposalIDKey() []byte {
 	return nextProposalIDSubstore
 }
@@ -11,6 +11,7 @@ import (
 )
 
 var _ types.Msg = DeletePostContentMsg{}
+var _ types.Msg = RestorePostContentMsg{}
 var _ types.Msg = UpgradeProtocolMsg{}
 var _ types.Msg = ChangeGlobalAllocationParamMsg{}
 var _ types.Msg = ChangeEvaluateOfContentValueParamMsg{}
@@ -22,8 +23,15 @@ var _ types.Msg = ChangeValidatorParamMsg{}
 var _ types.Msg = ChangeBandwidthParamMsg{}
 var _ types.Msg = ChangeAccountParamMsg{}
 var _ types.Msg = ChangePostParamMsg{}
+var _ types.Msg = ChangeCoinDayParamMsg{}
+var _ types.Msg = ChangeReputationParamMsg{}
+var _ types.Msg = ChangeParamFieldsMsg{}
+var _ types.Msg = SubmitTextProposalMsg{}
 var _ types.Msg = VoteProposalMsg{}
 
+// patchValidationCreator - placeholder creator to run change param msg checks on a patched parameter
+const patchValidationCreator = types.AccountKey("lino")
+
 var _ ChangeParamMsg = ChangeGlobalAllocationParamMsg{}
 var _ ChangeParamMsg = ChangeEvaluateOfContentValueParamMsg{}
 var _ ChangeParamMsg = ChangeInfraInternalAllocationParamMsg{}
@@ -34,6 +42,9 @@ var _ ChangeParamMsg = ChangeValidatorParamMsg{}
 var _ ChangeParamMsg = ChangeBandwidthParamMsg{}
 var _ ChangeParamMsg = ChangeAccountParamMsg{}
 var _ ChangeParamMsg = ChangePostParamMsg{}
+var _ ChangeParamMsg = ChangeCoinDayParamMsg{}
+var _ ChangeParamMsg = ChangeReputationParamMsg{}
+var _ ChangeParamMsg = ChangeParamFieldsMsg{}
 
 var _ ContentCensorshipMsg = DeletePostContentMsg{}
 
@@ -144,6 +155,44 @@ type ChangePostParamMsg struct {
 	Reason    string           `json:"reason"`
 }
 
+// ChangeCoinDayParamMsg - implement of change parameter msg
+type ChangeCoinDayParamMsg struct {
+	Creator   types.AccountKey   `json:"creator"`
+	Parameter param.CoinDayParam `json:"parameter"`
+	Reason    string             `json:"reason"`
+}
+
+// ChangeReputationParamMsg - implement of change parameter msg
+type ChangeReputationParamMsg struct {
+	Creator   types.AccountKey      `json:"creator"`
+	Parameter param.ReputationParam `json:"parameter"`
+	Reason    string                `json:"reason"`
+}
+
+// ChangeParamFieldsMsg - change only listed fields of a parameter, fields are keyed by json name
+type ChangeParamFieldsMsg struct {
+	Creator   types.AccountKey   `json:"creator"`
+	ParamName string             `json:"param_name"`
+	Fields    []param.ParamField `json:"fields"`
+	Reason    string             `json:"reason"`
+}
+
+// SubmitTextProposalMsg - implement of text proposal msg
+type SubmitTextProposalMsg struct {
+	Creator     types.AccountKey `json:"creator"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Permlink    types.Permlink   `json:"permlink"`
+	Reason      string           `json:"reason"`
+}
+
+// RestorePostContentMsg - implement of content restore msg
+type RestorePostContentMsg struct {
+	Creator  types.AccountKey `json:"creator"`
+	Permlink types.Permlink   `json:"permlink"`
+	Reason   string           `json:"reason"`
+}
+
 // VoteProposalMsg - implement of change parameter msg
 type VoteProposalMsg struct {
 	Voter      types.AccountKey  `json:"voter"`
@@ -620,7 +669,8 @@ func (msg ChangeProposalParamMsg) ValidateBasic() sdk.Error {
 	if msg.Parameter.ContentCensorshipDecideSec <= 0 ||
 		msg.Parameter.ChangeParamExecutionSec <= 0 ||
 		msg.Parameter.ChangeParamDecideSec <= 0 ||
-		msg.Parameter.ProtocolUpgradeDecideSec <= 0 {
+		msg.Parameter.ProtocolUpgradeDecideSec <= 0 ||
+		msg.Parameter.TextProposalDecideSec <= 0 {
 		return ErrIllegalParameter()
 	}
 
@@ -629,7 +679,9 @@ func (msg ChangeProposalParamMsg) ValidateBasic() sdk.Error {
 		!msg.Parameter.ChangeParamMinDeposit.IsPositive() ||
 		!msg.Parameter.ChangeParamPassVotes.IsPositive() ||
 		!msg.Parameter.ProtocolUpgradePassVotes.IsPositive() ||
-		!msg.Parameter.ProtocolUpgradeMinDeposit.IsPositive() {
+		!msg.Parameter.ProtocolUpgradeMinDeposit.IsPositive() ||
+		!msg.Parameter.TextProposalMinDeposit.IsPositive() ||
+		!msg.Parameter.TextProposalPassVotes.IsPositive() {
 		return ErrIllegalParameter()
 	}
 
@@ -638,7 +690,9 @@ func (msg ChangeProposalParamMsg) ValidateBasic() sdk.Error {
 		!msg.Parameter.ProtocolUpgradePassRatio.GT(sdk.ZeroRat()) ||
 		msg.Parameter.ProtocolUpgradePassRatio.GT(sdk.NewRat(1, 1)) ||
 		msg.Parameter.ChangeParamPassRatio.GT(sdk.NewRat(1, 1)) ||
-		msg.Parameter.ContentCensorshipPassRatio.GT(sdk.NewRat(1, 1)) {
+		msg.Parameter.ContentCensorshipPassRatio.GT(sdk.NewRat(1, 1)) ||
+		!msg.Parameter.TextProposalPassRatio.GT(sdk.ZeroRat()) ||
+		msg.Parameter.TextProposalPassRatio.GT(sdk.NewRat(1, 1)) {
 		return ErrIllegalParameter()
 	}
 
@@ -867,6 +921,18 @@ func (msg ChangeAccountParamMsg) ValidateBasic() sdk.Error {
 		msg.Parameter.MaxNumFrozenMoney <= 0 {
 		return ErrIllegalParameter()
 	}
+	// referral bonus must be below register fee so registrations add to developer inflation pool
+	if !msg.Parameter.ReferralBonus.IsNotNegative() ||
+		(msg.Parameter.ReferralBonus.IsPositive() &&
+			!msg.Parameter.RegisterFee.IsGT(msg.Parameter.ReferralBonus)) ||
+		msg.Parameter.ReferralRewardRate.LT(sdk.ZeroRat()) ||
+		msg.Parameter.ReferralRewardRate.GT(sdk.OneRat()) ||
+		msg.Parameter.ReferralRewardPeriodSec < 0 {
+		return ErrIllegalParameter()
+	}
+	if msg.Parameter.SocialRecoveryDelaySec < types.MinimumSocialRecoveryDelaySec {
+		return ErrIllegalParameter()
+	}
 	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
 		return ErrReasonTooLong()
 	}
@@ -935,7 +1001,8 @@ func (msg ChangePostParamMsg) ValidateBasic() sdk.Error {
 	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
 		return ErrReasonTooLong()
 	}
-	if msg.Parameter.PostIntervalSec < 0 || msg.Parameter.ReportOrUpvoteIntervalSec < 0 {
+	if msg.Parameter.PostIntervalSec < 0 || msg.Parameter.ReportOrUpvoteIntervalSec < 0 ||
+		msg.Parameter.MaxNumOfRevisions < 0 {
 		return ErrIllegalParameter()
 	}
 	return nil
@@ -1043,6 +1110,394 @@ func (msg ChangeBandwidthParamMsg) GetConsumeAmount() types.Coin {
 	return types.NewCoinFromInt64(0)
 }
 
+//----------------------------------------
+// ChangeCoinDayParamMsg Msg Implementations
+
+func NewChangeCoinDayParamMsg(
+	creator string, parameter param.CoinDayParam, reason string) ChangeCoinDayParamMsg {
+	return ChangeCoinDayParamMsg{
+		Creator:   types.AccountKey(creator),
+		Parameter: parameter,
+		Reason:    reason,
+	}
+}
+
+// GetParameter - implement ChangeParamMsg
+func (msg ChangeCoinDayParamMsg) GetParameter() param.Parameter { return msg.Parameter }
+
+// GetCreator - implement ChangeParamMsg
+func (msg ChangeCoinDayParamMsg) GetCreator() types.AccountKey { return msg.Creator }
+
+// GetReason - implement ChangeParamMsg
+func (msg ChangeCoinDayParamMsg) GetReason() string { return msg.Reason }
+
+// Type - implement sdk.Msg
+func (msg ChangeCoinDayParamMsg) Type() string { return types.ProposalRouterName }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ChangeCoinDayParamMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if msg.Parameter.SecondsToRecoverCoinDay <= 0 {
+		return ErrIllegalParameter()
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg ChangeCoinDayParamMsg) String() string {
+	return fmt.Sprintf("ChangeCoinDayParamMsg{Creator:%v}", msg.Creator)
+}
+
+// GetPermission - implement types.Msg
+func (msg ChangeCoinDayParamMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ChangeCoinDayParamMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ChangeCoinDayParamMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ChangeCoinDayParamMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// ChangeReputationParamMsg Msg Implementations
+
+func NewChangeReputationParamMsg(
+	creator string, parameter param.ReputationParam, reason string) ChangeReputationParamMsg {
+	return ChangeReputationParamMsg{
+		Creator:   types.AccountKey(creator),
+		Parameter: parameter,
+		Reason:    reason,
+	}
+}
+
+// GetParameter - implement ChangeParamMsg
+func (msg ChangeReputationParamMsg) GetParameter() param.Parameter { return msg.Parameter }
+
+// GetCreator - implement ChangeParamMsg
+func (msg ChangeReputationParamMsg) GetCreator() types.AccountKey { return msg.Creator }
+
+// GetReason - implement ChangeParamMsg
+func (msg ChangeReputationParamMsg) GetReason() string { return msg.Reason }
+
+// Type - implement sdk.Msg
+func (msg ChangeReputationParamMsg) Type() string { return types.ProposalRouterName }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ChangeReputationParamMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if msg.Parameter.BestContentIndexN <= 0 {
+		return ErrIllegalParameter()
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg ChangeReputationParamMsg) String() string {
+	return fmt.Sprintf("ChangeReputationParamMsg{Creator:%v}", msg.Creator)
+}
+
+// GetPermission - implement types.Msg
+func (msg ChangeReputationParamMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ChangeReputationParamMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ChangeReputationParamMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ChangeReputationParamMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// ChangeParamFieldsMsg Msg Implementations
+
+func NewChangeParamFieldsMsg(
+	creator string, paramName string, fields map[string]string, reason string) ChangeParamFieldsMsg {
+	return ChangeParamFieldsMsg{
+		Creator:   types.AccountKey(creator),
+		ParamName: paramName,
+		Fields:    param.NewParamFieldsPatch(paramName, fields).Fields,
+		Reason:    reason,
+	}
+}
+
+// GetParameter - implement ChangeParamMsg
+func (msg ChangeParamFieldsMsg) GetParameter() param.Parameter {
+	return param.ParamFieldsPatch{ParamName: msg.ParamName, Fields: msg.Fields}
+}
+
+// GetCreator - implement ChangeParamMsg
+func (msg ChangeParamFieldsMsg) GetCreator() types.AccountKey { return msg.Creator }
+
+// GetReason - implement ChangeParamMsg
+func (msg ChangeParamFieldsMsg) GetReason() string { return msg.Reason }
+
+// Type - implement sdk.Msg
+func (msg ChangeParamFieldsMsg) Type() string { return types.ProposalRouterName }
+
+// ValidateBasic - implement sdk.Msg
+func (msg ChangeParamFieldsMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+
+	if len(msg.Fields) == 0 {
+		return ErrIllegalParameter()
+	}
+	seen := map[string]bool{}
+	for _, field := range msg.Fields {
+		if seen[field.Name] {
+			return ErrIllegalParameter()
+		}
+		seen[field.Name] = true
+	}
+
+	// check param and fields exist and values can be parsed,
+	// full parameter rules are checked against current parameter in handler
+	emptyParam, err := param.GetEmptyParam(msg.ParamName)
+	if err != nil {
+		return err
+	}
+	if _, err := param.ApplyParamFields(emptyParam, msg.Fields); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg ChangeParamFieldsMsg) String() string {
+	return fmt.Sprintf(
+		"ChangeParamFieldsMsg{Creator:%v, ParamName:%v, Fields:%v}", msg.Creator, msg.ParamName, msg.Fields)
+}
+
+// GetPermission - implement types.Msg
+func (msg ChangeParamFieldsMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg ChangeParamFieldsMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg ChangeParamFieldsMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg ChangeParamFieldsMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// RestorePostContentMsg Msg Implementations
+
+func NewRestorePostContentMsg(
+	creator string, permlink types.Permlink, reason string) RestorePostContentMsg {
+	return RestorePostContentMsg{
+		Creator:  types.AccountKey(creator),
+		Permlink: permlink,
+		Reason:   reason,
+	}
+}
+
+// Type - implement sdk.Msg
+func (msg RestorePostContentMsg) Type() string { return types.ProposalRouterName }
+
+// ValidateBasic - implement sdk.Msg
+func (msg RestorePostContentMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if len(msg.Permlink) == 0 {
+		return ErrInvalidPermlink()
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg RestorePostContentMsg) String() string {
+	return fmt.Sprintf("RestorePostContentMsg{Creator:%v, post:%v}", msg.Creator, msg.Permlink)
+}
+
+// GetPermission - implement types.Msg
+func (msg RestorePostContentMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg RestorePostContentMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg RestorePostContentMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg RestorePostContentMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+//----------------------------------------
+// SubmitTextProposalMsg Msg Implementations
+
+func NewSubmitTextProposalMsg(
+	creator, title, description string, permlink types.Permlink, reason string) SubmitTextProposalMsg {
+	return SubmitTextProposalMsg{
+		Creator:     types.AccountKey(creator),
+		Title:       title,
+		Description: description,
+		Permlink:    permlink,
+		Reason:      reason,
+	}
+}
+
+// Type - implement sdk.Msg
+func (msg SubmitTextProposalMsg) Type() string { return types.ProposalRouterName }
+
+// ValidateBasic - implement sdk.Msg
+func (msg SubmitTextProposalMsg) ValidateBasic() sdk.Error {
+	if len(msg.Creator) < types.MinimumUsernameLength ||
+		len(msg.Creator) > types.MaximumUsernameLength {
+		return ErrInvalidUsername()
+	}
+	if len(msg.Title) == 0 ||
+		utf8.RuneCountInString(msg.Title) > types.MaximumLengthOfProposalTitle {
+		return ErrInvalidProposalTitle()
+	}
+	if utf8.RuneCountInString(msg.Description) > types.MaximumLengthOfProposalDescription {
+		return ErrProposalDescriptionTooLong()
+	}
+	if utf8.RuneCountInString(msg.Reason) > types.MaximumLengthOfProposalReason {
+		return ErrReasonTooLong()
+	}
+	return nil
+}
+
+func (msg SubmitTextProposalMsg) String() string {
+	return fmt.Sprintf(
+		"SubmitTextProposalMsg{Creator:%v, Title:%v, Permlink:%v}", msg.Creator, msg.Title, msg.Permlink)
+}
+
+// GetPermission - implement types.Msg
+func (msg SubmitTextProposalMsg) GetPermission() types.Permission {
+	return types.TransactionPermission
+}
+
+// GetSignBytes - implement sdk.Msg
+func (msg SubmitTextProposalMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg) // XXX: ensure some canonical form
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// GetSigners - implement sdk.Msg
+func (msg SubmitTextProposalMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Creator)}
+}
+
+// GetConsumeAmount - implement types.Msg
+func (msg SubmitTextProposalMsg) GetConsumeAmount() types.Coin {
+	return types.NewCoinFromInt64(0)
+}
+
+// ValidatePatchedParameter - check a parameter patched at execution time with rules of
+// its change param msg, creator and reason are already checked when proposal is created
+func ValidatePatchedParameter(parameter param.Parameter) sdk.Error {
+	return validateParameter(patchValidationCreator, parameter, "")
+}
+
+// validateParameter - check a complete parameter with rules of its change param msg
+func validateParameter(creator types.AccountKey, parameter param.Parameter, reason string) sdk.Error {
+	var msg sdk.Msg
+	switch parameter := parameter.(type) {
+	case param.GlobalAllocationParam:
+		msg = NewChangeGlobalAllocationParamMsg(string(creator), parameter, reason)
+	case param.EvaluateOfContentValueParam:
+		msg = NewChangeEvaluateOfContentValueParamMsg(string(creator), parameter, reason)
+	case param.InfraInternalAllocationParam:
+		msg = NewChangeInfraInternalAllocationParamMsg(string(creator), parameter, reason)
+	case param.VoteParam:
+		msg = NewChangeVoteParamMsg(string(creator), parameter, reason)
+	case param.ProposalParam:
+		msg = NewChangeProposalParamMsg(string(creator), parameter, reason)
+	case param.DeveloperParam:
+		msg = NewChangeDeveloperParamMsg(string(creator), parameter, reason)
+	case param.ValidatorParam:
+		msg = NewChangeValidatorParamMsg(string(creator), parameter, reason)
+	case param.BandwidthParam:
+		msg = NewChangeBandwidthParamMsg(string(creator), parameter, reason)
+	case param.AccountParam:
+		msg = NewChangeAccountParamMsg(string(creator), parameter, reason)
+	case param.PostParam:
+		msg = NewChangePostParamMsg(string(creator), parameter, reason)
+	case param.CoinDayParam:
+		msg = NewChangeCoinDayParamMsg(string(creator), parameter, reason)
+	case param.ReputationParam:
+		msg = NewChangeReputationParamMsg(string(creator), parameter, reason)
+	default:
+		return ErrIllegalParameter()
+	}
+	return msg.ValidateBasic()
+}
+
 //----------------------------------------
 // VoteProposalMsg Msg Implementations
 func NewVoteProposalMsg(voter string, proposalID int64, result bool) VoteProposalMsg {
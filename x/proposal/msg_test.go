@@ -497,6 +497,11 @@ func TestChangeProposalParamMsg(t *testing.T) {
 		ProtocolUpgradePassRatio:  sdk.NewRat(80, 100),
 		ProtocolUpgradePassVotes:  types.NewCoinFromInt64(10000000 * types.Decimals),
 		ProtocolUpgradeMinDeposit: types.NewCoinFromInt64(1000000 * types.Decimals),
+
+		TextProposalDecideSec:  int64(7 * 24 * 3600),
+		TextProposalPassRatio:  sdk.NewRat(50, 100),
+		TextProposalPassVotes:  types.NewCoinFromInt64(10000 * types.Decimals),
+		TextProposalMinDeposit: types.NewCoinFromInt64(100 * types.Decimals),
 	}
 
 	p2 := p1
@@ -535,6 +540,15 @@ func TestChangeProposalParamMsg(t *testing.T) {
 	p13 := p1
 	p13.ProtocolUpgradeMinDeposit = types.NewCoinFromInt64(-1000000 * types.Decimals)
 
+	p14 := p1
+	p14.TextProposalDecideSec = int64(0)
+
+	p15 := p1
+	p15.TextProposalPassRatio = sdk.NewRat(101, 100)
+
+	p16 := p1
+	p16.TextProposalMinDeposit = types.NewCoinFromInt64(0)
+
 	testCases := []struct {
 		testName               string
 		ChangeProposalParamMsg ChangeProposalParamMsg
@@ -610,6 +624,21 @@ func TestChangeProposalParamMsg(t *testing.T) {
 			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p13, ""),
 			expectedError:          ErrIllegalParameter(),
 		},
+		{
+			testName:               "zero TextProposalDecideSec is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p14, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "TextProposalPassRatio larger than one is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p15, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
+		{
+			testName:               "zero TextProposalMinDeposit is illegal",
+			ChangeProposalParamMsg: NewChangeProposalParamMsg("user1", p16, ""),
+			expectedError:          ErrIllegalParameter(),
+		},
 		{
 			testName: "reason is too long",
 			ChangeProposalParamMsg: NewChangeProposalParamMsg(
@@ -638,6 +667,10 @@ func TestChangeAccountParamMsg(t *testing.T) {
 		RegisterFee:                  types.NewCoinFromInt64(1 * types.Decimals),
 		FirstDepositFullCoinDayLimit: types.NewCoinFromInt64(1 * types.Decimals),
 		MaxNumFrozenMoney:            10,
+		ReferralBonus:                types.NewCoinFromInt64(0),
+		ReferralRewardRate:           sdk.NewRat(5, 100),
+		ReferralRewardPeriodSec:      90 * 24 * 3600,
+		SocialRecoveryDelaySec:       3 * 24 * 3600,
 	}
 
 	p2 := p1
@@ -655,6 +688,30 @@ func TestChangeAccountParamMsg(t *testing.T) {
 	p6 := p1
 	p6.MaxNumFrozenMoney = -1
 
+	p7 := p1
+	p7.ReferralBonus = types.NewCoinFromInt64(-1)
+
+	p8 := p1
+	p8.ReferralRewardRate = sdk.NewRat(101, 100)
+
+	p9 := p1
+	p9.ReferralRewardPeriodSec = -1
+
+	p10 := p1
+	p10.SocialRecoveryDelaySec = -1
+
+	p11 := p1
+	p11.ReferralBonus = p1.RegisterFee
+
+	p12 := p1
+	p12.ReferralBonus = types.NewCoinFromInt64(1)
+
+	p13 := p1
+	p13.SocialRecoveryDelaySec = 0
+
+	p14 := p1
+	p14.SocialRecoveryDelaySec = types.MinimumSocialRecoveryDelaySec - 1
+
 	testCases := []struct {
 		testName              string
 		changeAccountParamMsg ChangeAccountParamMsg
@@ -700,6 +757,46 @@ func TestChangeAccountParamMsg(t *testing.T) {
 			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p6, ""),
 			expectedError:         ErrIllegalParameter(),
 		},
+		{
+			testName:              "negative ReferralBonus is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p7, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "ReferralRewardRate larger than 1 is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p8, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "negative ReferralRewardPeriodSec is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p9, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "negative SocialRecoveryDelaySec is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p10, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "ReferralBonus not less than RegisterFee is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p11, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "ReferralBonus less than RegisterFee is valid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p12, ""),
+			expectedError:         nil,
+		},
+		{
+			testName:              "zero SocialRecoveryDelaySec is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p13, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "SocialRecoveryDelaySec less than minimum is invalid",
+			changeAccountParamMsg: NewChangeAccountParamMsg("user1", p14, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
 		{
 			testName: "reason is too long",
 			changeAccountParamMsg: NewChangeAccountParamMsg(
@@ -807,6 +904,9 @@ func TestChangePostParamMsg(t *testing.T) {
 	p3 := p1
 	p3.PostIntervalSec = int64(-1)
 
+	p4 := p1
+	p4.MaxNumOfRevisions = int64(-1)
+
 	testCases := []struct {
 		testName           string
 		changePostParamMsg ChangePostParamMsg
@@ -827,6 +927,11 @@ func TestChangePostParamMsg(t *testing.T) {
 			changePostParamMsg: NewChangePostParamMsg("user1", p3, ""),
 			expectedError:      ErrIllegalParameter(),
 		},
+		{
+			testName:           "illegal max number of revisions",
+			changePostParamMsg: NewChangePostParamMsg("user1", p4, ""),
+			expectedError:      ErrIllegalParameter(),
+		},
 		{
 			testName:           "username too short",
 			changePostParamMsg: NewChangePostParamMsg("us", p1, ""),
@@ -847,6 +952,295 @@ func TestChangePostParamMsg(t *testing.T) {
 	}
 }
 
+func TestChangeCoinDayParamMsg(t *testing.T) {
+	p1 := param.CoinDayParam{
+		SecondsToRecoverCoinDay: 7 * 24 * 3600,
+	}
+
+	p2 := p1
+	p2.SecondsToRecoverCoinDay = 0
+
+	p3 := p1
+	p3.SecondsToRecoverCoinDay = -1
+
+	testCases := []struct {
+		testName              string
+		changeCoinDayParamMsg ChangeCoinDayParamMsg
+		expectedError         sdk.Error
+	}{
+		{
+			testName:              "normal case",
+			changeCoinDayParamMsg: NewChangeCoinDayParamMsg("user1", p1, ""),
+			expectedError:         nil,
+		},
+		{
+			testName:              "zero seconds to recover coin day is illegal",
+			changeCoinDayParamMsg: NewChangeCoinDayParamMsg("user1", p2, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "negative seconds to recover coin day is illegal",
+			changeCoinDayParamMsg: NewChangeCoinDayParamMsg("user1", p3, ""),
+			expectedError:         ErrIllegalParameter(),
+		},
+		{
+			testName:              "username too short",
+			changeCoinDayParamMsg: NewChangeCoinDayParamMsg("us", p1, ""),
+			expectedError:         ErrInvalidUsername(),
+		},
+		{
+			testName:              "username too long",
+			changeCoinDayParamMsg: NewChangeCoinDayParamMsg("user1user1user1user1user1", p1, ""),
+			expectedError:         ErrInvalidUsername(),
+		},
+		{
+			testName: "utf8 reason is too long",
+			changeCoinDayParamMsg: NewChangeCoinDayParamMsg(
+				"user1", p1, tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.changeCoinDayParamMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestChangeReputationParamMsg(t *testing.T) {
+	p1 := param.ReputationParam{
+		BestContentIndexN: 10,
+	}
+
+	p2 := p1
+	p2.BestContentIndexN = 0
+
+	testCases := []struct {
+		testName                 string
+		changeReputationParamMsg ChangeReputationParamMsg
+		expectedError            sdk.Error
+	}{
+		{
+			testName:                 "normal case",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p1, ""),
+			expectedError:            nil,
+		},
+		{
+			testName:                 "zero best content index is illegal",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1", p2, ""),
+			expectedError:            ErrIllegalParameter(),
+		},
+		{
+			testName:                 "username too short",
+			changeReputationParamMsg: NewChangeReputationParamMsg("us", p1, ""),
+			expectedError:            ErrInvalidUsername(),
+		},
+		{
+			testName:                 "username too long",
+			changeReputationParamMsg: NewChangeReputationParamMsg("user1user1user1user1user1", p1, ""),
+			expectedError:            ErrInvalidUsername(),
+		},
+		{
+			testName: "utf8 reason is too long",
+			changeReputationParamMsg: NewChangeReputationParamMsg(
+				"user1", p1, tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.changeReputationParamMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestChangeParamFieldsMsg(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		changeParamFieldsMsg ChangeParamFieldsMsg
+		expectedError        sdk.Error
+	}{
+		{
+			testName: "normal case",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", param.PostParamName, map[string]string{"post_interval_sec": "600"}, ""),
+			expectedError: nil,
+		},
+		{
+			testName: "multiple fields with different types",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", param.ProposalParamName, map[string]string{
+					"change_param_decide_second":    "100",
+					"change_param_min_deposit":      "0.5",
+					"change_param_pass_ratio":       "0.8",
+					"content_censorship_pass_votes": "10000",
+				}, ""),
+			expectedError: nil,
+		},
+		{
+			testName: "unknown param",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", "unknown_param", map[string]string{"post_interval_sec": "600"}, ""),
+			expectedError: param.ErrParamNameNotFound("unknown_param"),
+		},
+		{
+			testName: "unknown field",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", param.PostParamName, map[string]string{"unknown": "600"}, ""),
+			expectedError: param.ErrParamFieldNotFound("unknown"),
+		},
+		{
+			testName: "invalid int value",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", param.PostParamName, map[string]string{"post_interval_sec": "1.5"}, ""),
+			expectedError: param.ErrInvalidParamFieldValue("post_interval_sec", "1.5"),
+		},
+		{
+			testName: "negative coin value",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", param.PostParamName, map[string]string{"max_report_reputation": "-1"}, ""),
+			expectedError: param.ErrInvalidParamFieldValue("max_report_reputation", "-1"),
+		},
+		{
+			testName:             "empty fields",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg("user1", param.PostParamName, map[string]string{}, ""),
+			expectedError:        ErrIllegalParameter(),
+		},
+		{
+			testName: "duplicate fields",
+			changeParamFieldsMsg: ChangeParamFieldsMsg{
+				Creator:   "user1",
+				ParamName: param.PostParamName,
+				Fields: []param.ParamField{
+					{Name: "post_interval_sec", Value: "1"},
+					{Name: "post_interval_sec", Value: "2"},
+				},
+			},
+			expectedError: ErrIllegalParameter(),
+		},
+		{
+			testName: "username too short",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"us", param.PostParamName, map[string]string{"post_interval_sec": "600"}, ""),
+			expectedError: ErrInvalidUsername(),
+		},
+		{
+			testName: "utf8 reason is too long",
+			changeParamFieldsMsg: NewChangeParamFieldsMsg(
+				"user1", param.PostParamName, map[string]string{"post_interval_sec": "600"}, tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.changeParamFieldsMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
+func TestValidatePatchedParameter(t *testing.T) {
+	validAllocation := param.GlobalAllocationParam{
+		GlobalGrowthRate:         sdk.NewRat(98, 1000),
+		InfraAllocation:          sdk.NewRat(20, 100),
+		ContentCreatorAllocation: sdk.NewRat(55, 100),
+		DeveloperAllocation:      sdk.NewRat(20, 100),
+		ValidatorAllocation:      sdk.NewRat(5, 100),
+	}
+	// two patches pass separately but sum of allocation is no longer 1 together
+	invalidAllocation := validAllocation
+	invalidAllocation.InfraAllocation = sdk.NewRat(25, 100)
+	invalidAllocation.DeveloperAllocation = sdk.NewRat(25, 100)
+
+	testCases := []struct {
+		testName      string
+		parameter     param.Parameter
+		expectedError sdk.Error
+	}{
+		{
+			testName:      "valid patched parameter",
+			parameter:     validAllocation,
+			expectedError: nil,
+		},
+		{
+			testName:      "allocation doesn't sum to 1",
+			parameter:     invalidAllocation,
+			expectedError: ErrIllegalParameter(),
+		},
+		{
+			testName:      "unknown parameter",
+			parameter:     param.ParamFieldsPatch{},
+			expectedError: ErrIllegalParameter(),
+		},
+	}
+	for _, tc := range testCases {
+		err := ValidatePatchedParameter(tc.parameter)
+		if !assert.Equal(t, tc.expectedError, err) {
+			t.Errorf("%s: diff err, got %v, want %v", tc.testName, err, tc.expectedError)
+		}
+	}
+}
+
+func TestSubmitTextProposalMsg(t *testing.T) {
+	testCases := []struct {
+		testName              string
+		submitTextProposalMsg SubmitTextProposalMsg
+		expectedError         sdk.Error
+	}{
+		{
+			testName: "normal case",
+			submitTextProposalMsg: NewSubmitTextProposalMsg(
+				"user1", "title", "description", types.GetPermlink("user1", "post"), "reason"),
+			expectedError: nil,
+		},
+		{
+			testName:              "discussion post is optional",
+			submitTextProposalMsg: NewSubmitTextProposalMsg("user1", "title", "", "", ""),
+			expectedError:         nil,
+		},
+		{
+			testName:              "username too short",
+			submitTextProposalMsg: NewSubmitTextProposalMsg("us", "title", "", "", ""),
+			expectedError:         ErrInvalidUsername(),
+		},
+		{
+			testName:              "empty title",
+			submitTextProposalMsg: NewSubmitTextProposalMsg("user1", "", "", "", ""),
+			expectedError:         ErrInvalidProposalTitle(),
+		},
+		{
+			testName: "title is too long",
+			submitTextProposalMsg: NewSubmitTextProposalMsg(
+				"user1", string(make([]byte, types.MaximumLengthOfProposalTitle+1)), "", "", ""),
+			expectedError: ErrInvalidProposalTitle(),
+		},
+		{
+			testName: "description is too long",
+			submitTextProposalMsg: NewSubmitTextProposalMsg(
+				"user1", "title", string(make([]byte, types.MaximumLengthOfProposalDescription+1)), "", ""),
+			expectedError: ErrProposalDescriptionTooLong(),
+		},
+		{
+			testName: "utf8 reason is too long",
+			submitTextProposalMsg: NewSubmitTextProposalMsg(
+				"user1", "title", "", "", tooLongOfUTF8Reason),
+			expectedError: ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.submitTextProposalMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
 func TestChangeEvaluateOfContentValueParamMsg(t *testing.T) {
 	p1 := param.EvaluateOfContentValueParam{
 		ConsumptionTimeAdjustBase:      3153600,
@@ -963,6 +1357,42 @@ func TestDeletePostContentMsg(t *testing.T) {
 	}
 }
 
+func TestRestorePostContentMsg(t *testing.T) {
+	testCases := []struct {
+		testName              string
+		restorePostContentMsg RestorePostContentMsg
+		expectedError         sdk.Error
+	}{
+		{
+			testName:              "normal case",
+			restorePostContentMsg: NewRestorePostContentMsg("user1", "permlink", "reason"),
+			expectedError:         nil,
+		},
+		{
+			testName:              "empty permlink",
+			restorePostContentMsg: NewRestorePostContentMsg("user1", "", "reason"),
+			expectedError:         ErrInvalidPermlink(),
+		},
+		{
+			testName:              "username too short",
+			restorePostContentMsg: NewRestorePostContentMsg("us", "permlink", "reason"),
+			expectedError:         ErrInvalidUsername(),
+		},
+		{
+			testName:              "utf8 reason is too long",
+			restorePostContentMsg: NewRestorePostContentMsg("user1", "permlink", tooLongOfUTF8Reason),
+			expectedError:         ErrReasonTooLong(),
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.restorePostContentMsg.ValidateBasic()
+		if !assert.Equal(t, result, tc.expectedError) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, result, tc.expectedError)
+		}
+	}
+}
+
 func TestUpgradeProtocolMsg(t *testing.T) {
 	testCases := []struct {
 		testName           string
@@ -1026,6 +1456,22 @@ func TestMsgPermission(t *testing.T) {
 			msg:              NewUpgradeProtocolMsg("creator", "link", ""),
 			expectPermission: types.TransactionPermission,
 		},
+		{
+			testName: "change param fields msg",
+			msg: NewChangeParamFieldsMsg(
+				"creator", param.PostParamName, map[string]string{"post_interval_sec": "1"}, ""),
+			expectPermission: types.TransactionPermission,
+		},
+		{
+			testName:         "submit text proposal msg",
+			msg:              NewSubmitTextProposalMsg("creator", "title", "description", "permlink", ""),
+			expectPermission: types.TransactionPermission,
+		},
+		{
+			testName:         "restore post content msg",
+			msg:              NewRestorePostContentMsg("creator", "permlink", "reason"),
+			expectPermission: types.TransactionPermission,
+		},
 		{
 			testName: "change global allocaiton param msg",
 			msg: NewChangeGlobalAllocationParamMsg(
@@ -1086,6 +1532,18 @@ func TestMsgPermission(t *testing.T) {
 				"creator", param.PostParam{}, ""),
 			expectPermission: types.TransactionPermission,
 		},
+		{
+			testName: "change coin day param msg",
+			msg: NewChangeCoinDayParamMsg(
+				"creator", param.CoinDayParam{}, ""),
+			expectPermission: types.TransactionPermission,
+		},
+		{
+			testName: "change reputation param msg",
+			msg: NewChangeReputationParamMsg(
+				"creator", param.ReputationParam{}, ""),
+			expectPermission: types.TransactionPermission,
+		},
 		{
 			testName:         "vote proposal msg",
 			msg:              NewVoteProposalMsg("voter", 1, true),
@@ -1116,6 +1574,19 @@ func TestGetSignBytes(t *testing.T) {
 			testName: "upgrade protocol msg",
 			msg:      NewUpgradeProtocolMsg("creator", "link", ""),
 		},
+		{
+			testName: "change param fields msg",
+			msg: NewChangeParamFieldsMsg(
+				"creator", param.PostParamName, map[string]string{"post_interval_sec": "1"}, ""),
+		},
+		{
+			testName: "submit text proposal msg",
+			msg:      NewSubmitTextProposalMsg("creator", "title", "description", "permlink", ""),
+		},
+		{
+			testName: "restore post content msg",
+			msg:      NewRestorePostContentMsg("creator", "permlink", "reason"),
+		},
 		{
 			testName: "change global allocaiton param msg",
 			msg: NewChangeGlobalAllocationParamMsg(
@@ -1166,6 +1637,16 @@ func TestGetSignBytes(t *testing.T) {
 			msg: NewChangePostParamMsg(
 				"creator", param.PostParam{}, ""),
 		},
+		{
+			testName: "change coin day param msg",
+			msg: NewChangeCoinDayParamMsg(
+				"creator", param.CoinDayParam{}, ""),
+		},
+		{
+			testName: "change reputation param msg",
+			msg: NewChangeReputationParamMsg(
+				"creator", param.ReputationParam{}, ""),
+		},
 		{
 			testName: "vote proposal msg",
 			msg:      NewVoteProposalMsg("voter", 1, true),
@@ -1194,6 +1675,22 @@ func TestGetSigners(t *testing.T) {
 			msg:           NewUpgradeProtocolMsg("creator", "link", ""),
 			expectSigners: []types.AccountKey{"creator"},
 		},
+		{
+			testName: "change param fields msg",
+			msg: NewChangeParamFieldsMsg(
+				"creator", param.PostParamName, map[string]string{"post_interval_sec": "1"}, ""),
+			expectSigners: []types.AccountKey{"creator"},
+		},
+		{
+			testName:      "submit text proposal msg",
+			msg:           NewSubmitTextProposalMsg("creator", "title", "description", "permlink", ""),
+			expectSigners: []types.AccountKey{"creator"},
+		},
+		{
+			testName:      "restore post content msg",
+			msg:           NewRestorePostContentMsg("creator", "permlink", "reason"),
+			expectSigners: []types.AccountKey{"creator"},
+		},
 		{
 			testName: "change global allocaiton param msg",
 			msg: NewChangeGlobalAllocationParamMsg(
@@ -1254,6 +1751,18 @@ func TestGetSigners(t *testing.T) {
 				"creator", param.PostParam{}, ""),
 			expectSigners: []types.AccountKey{"creator"},
 		},
+		{
+			testName: "change coin day param msg",
+			msg: NewChangeCoinDayParamMsg(
+				"creator", param.CoinDayParam{}, ""),
+			expectSigners: []types.AccountKey{"creator"},
+		},
+		{
+			testName: "change reputation param msg",
+			msg: NewChangeReputationParamMsg(
+				"creator", param.ReputationParam{}, ""),
+			expectSigners: []types.AccountKey{"creator"},
+		},
 		{
 			testName:      "vote proposal msg",
 			msg:           NewVoteProposalMsg("voter", 1, true),
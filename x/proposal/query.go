@@ -0,0 +1,184 @@
+package proposal
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/lino-network/lino/param"
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal/model"
+	"github.com/lino-network/lino/x/vote"
+	votemodel "github.com/lino-network/lino/x/vote/model"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// proposalTypeNames - names used by clients to filter proposals by type
+var proposalTypeNames = map[string]types.ProposalType{
+	"changeParam":       types.ChangeParam,
+	"contentCensorship": types.ContentCensorship,
+	"protocolUpgrade":   types.ProtocolUpgrade,
+	"text":              types.TextProposal,
+	"contentRestore":    types.ContentRestore,
+}
+
+// ProposalFilter - filter used by proposal listing, empty field matches all proposals
+type ProposalFilter struct {
+	ProposalTypes []types.ProposalType `json:"proposal_types"`
+	Creator       types.AccountKey     `json:"creator"`
+}
+
+// ProposalTally - current tally of a proposal against its pass requirement
+type ProposalTally struct {
+	ProposalID         types.ProposalKey  `json:"proposal_id"`
+	ProposalType       types.ProposalType `json:"proposal_type"`
+	AgreeVotes         types.Coin         `json:"agree_votes"`
+	DisagreeVotes      types.Coin         `json:"disagree_votes"`
+	TotalVotes         types.Coin         `json:"total_votes"`
+	PassVotes          types.Coin         `json:"pass_votes"`
+	QuorumReached      bool               `json:"quorum_reached"`
+	AgreeRatio         sdk.Rat            `json:"agree_ratio"`
+	PassRatio          sdk.Rat            `json:"pass_ratio"`
+	RatioReached       bool               `json:"ratio_reached"`
+	SecondsToExpire    int64              `json:"seconds_to_expire"`
+	NotVotedValidators []types.AccountKey `json:"not_voted_validators"`
+	MissVotePenalty    bool               `json:"miss_vote_penalty"`
+}
+
+// GetProposalTypeByName - get proposal type from name used by clients
+func GetProposalTypeByName(name string) (types.ProposalType, sdk.Error) {
+	proposalType, ok := proposalTypeNames[name]
+	if !ok {
+		return types.ChangeParam, ErrIncorrectProposalType()
+	}
+	return proposalType, nil
+}
+
+// GetProposalType - get proposal type of given proposal
+func GetProposalType(proposal model.Proposal) (types.ProposalType, sdk.Error) {
+	switch proposal.(type) {
+	case *model.ChangeParamProposal:
+		return types.ChangeParam, nil
+	case *model.ContentCensorshipProposal:
+		return types.ContentCensorship, nil
+	case *model.ProtocolUpgradeProposal:
+		return types.ProtocolUpgrade, nil
+	case *model.TextProposal:
+		return types.TextProposal, nil
+	case *model.ContentRestoreProposal:
+		return types.ContentRestore, nil
+	default:
+		return types.ChangeParam, ErrIncorrectProposalType()
+	}
+}
+
+// FilterProposals - return proposals match the filter
+func FilterProposals(proposals []model.Proposal, filter ProposalFilter) []model.Proposal {
+	res := []model.Proposal{}
+	for _, proposal := range proposals {
+		if filter.Creator != "" && proposal.GetProposalInfo().Creator != filter.Creator {
+			continue
+		}
+		if len(filter.ProposalTypes) != 0 {
+			proposalType, err := GetProposalType(proposal)
+			if err != nil || !containsProposalType(filter.ProposalTypes, proposalType) {
+				continue
+			}
+		}
+		res = append(res, proposal)
+	}
+	return res
+}
+
+func containsProposalType(proposalTypes []types.ProposalType, proposalType types.ProposalType) bool {
+	for _, t := range proposalTypes {
+		if t == proposalType {
+			return true
+		}
+	}
+	return false
+}
+
+// PaginateProposals - sort proposals by proposal ID and return the given page,
+// page starts from 1
+func PaginateProposals(proposals []model.Proposal, page, pageSize int) []model.Proposal {
+	sorted := make([]model.Proposal, len(proposals))
+	copy(sorted, proposals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return proposalIDToInt64(sorted[i]) < proposalIDToInt64(sorted[j])
+	})
+	if page < 1 || pageSize < 1 {
+		return []model.Proposal{}
+	}
+	start := (page - 1) * pageSize
+	if start >= len(sorted) {
+		return []model.Proposal{}
+	}
+	end := start + pageSize
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+	return sorted[start:end]
+}
+
+func proposalIDToInt64(proposal model.Proposal) int64 {
+	id, err := strconv.ParseInt(string(proposal.GetProposalInfo().ProposalID), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// NewProposalTally - calculate tally of a proposal at the given unix time, the pass
+// condition is the same as UpdateProposalPassStatus and validators not voted yet are
+// the ones will be put into penalty list if the proposal expires now
+func NewProposalTally(
+	proposal model.Proposal, proposalParam *param.ProposalParam, oncallValidators []types.AccountKey,
+	votes []votemodel.Vote, now int64) (*ProposalTally, sdk.Error) {
+	proposalType, err := GetProposalType(proposal)
+	if err != nil {
+		return nil, err
+	}
+	passRatio, passVotes, err := getProposalPassParam(proposalParam, proposalType)
+	if err != nil {
+		return nil, err
+	}
+
+	info := proposal.GetProposalInfo()
+	totalVotes := info.AgreeVotes.Plus(info.DisagreeVotes)
+	agreeRatio := sdk.ZeroRat()
+	if totalVotes.IsPositive() {
+		agreeRatio = info.AgreeVotes.ToRat().Quo(totalVotes.ToRat()).Round(types.PrecisionFactor)
+	}
+	secondsToExpire := info.ExpiredAt - now
+	if secondsToExpire < 0 {
+		secondsToExpire = 0
+	}
+
+	voted := map[types.AccountKey]bool{}
+	for _, v := range votes {
+		voted[v.Voter] = true
+	}
+	notVoted := []types.AccountKey{}
+	for _, validator := range oncallValidators {
+		if !voted[validator] {
+			notVoted = append(notVoted, validator)
+		}
+	}
+
+	return &ProposalTally{
+		ProposalID:         info.ProposalID,
+		ProposalType:       proposalType,
+		AgreeVotes:         info.AgreeVotes,
+		DisagreeVotes:      info.DisagreeVotes,
+		TotalVotes:         totalVotes,
+		PassVotes:          passVotes,
+		QuorumReached:      totalVotes.IsGT(passVotes),
+		AgreeRatio:         agreeRatio,
+		PassRatio:          passRatio,
+		RatioReached:       passRatio.LT(agreeRatio),
+		SecondsToExpire:    secondsToExpire,
+		NotVotedValidators: notVoted,
+		MissVotePenalty:    vote.IsPenaltyProposalType(proposalType),
+	}, nil
+}
@@ -0,0 +1,237 @@
+package proposal
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lino-network/lino/types"
+	"github.com/lino-network/lino/x/proposal/model"
+	votemodel "github.com/lino-network/lino/x/vote/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAndPaginateProposals(t *testing.T) {
+	user1 := types.AccountKey("user1")
+	user2 := types.AccountKey("user2")
+	censorship := &model.ContentCensorshipProposal{
+		ProposalInfo: model.ProposalInfo{Creator: user1, ProposalID: types.ProposalKey("10")},
+	}
+	upgrade := &model.ProtocolUpgradeProposal{
+		ProposalInfo: model.ProposalInfo{Creator: user2, ProposalID: types.ProposalKey("2")},
+	}
+	changeParam := &model.ChangeParamProposal{
+		ProposalInfo: model.ProposalInfo{Creator: user1, ProposalID: types.ProposalKey("1")},
+	}
+	proposals := []model.Proposal{censorship, upgrade, changeParam}
+
+	testCases := []struct {
+		testName      string
+		filter        ProposalFilter
+		page          int
+		pageSize      int
+		wantProposals []model.Proposal
+	}{
+		{
+			testName:      "no filter sorted by proposal ID",
+			filter:        ProposalFilter{},
+			page:          1,
+			pageSize:      10,
+			wantProposals: []model.Proposal{changeParam, upgrade, censorship},
+		},
+		{
+			testName:      "second page",
+			filter:        ProposalFilter{},
+			page:          2,
+			pageSize:      2,
+			wantProposals: []model.Proposal{censorship},
+		},
+		{
+			testName:      "page out of range",
+			filter:        ProposalFilter{},
+			page:          3,
+			pageSize:      2,
+			wantProposals: []model.Proposal{},
+		},
+		{
+			testName:      "invalid page",
+			filter:        ProposalFilter{},
+			page:          0,
+			pageSize:      2,
+			wantProposals: []model.Proposal{},
+		},
+		{
+			testName:      "filter by creator",
+			filter:        ProposalFilter{Creator: user1},
+			page:          1,
+			pageSize:      10,
+			wantProposals: []model.Proposal{changeParam, censorship},
+		},
+		{
+			testName:      "filter by type",
+			filter:        ProposalFilter{ProposalTypes: []types.ProposalType{types.ProtocolUpgrade}},
+			page:          1,
+			pageSize:      10,
+			wantProposals: []model.Proposal{upgrade},
+		},
+		{
+			testName: "filter by type and creator",
+			filter: ProposalFilter{
+				ProposalTypes: []types.ProposalType{types.ProtocolUpgrade},
+				Creator:       user1,
+			},
+			page:          1,
+			pageSize:      10,
+			wantProposals: []model.Proposal{},
+		},
+	}
+	for _, tc := range testCases {
+		res := PaginateProposals(FilterProposals(proposals, tc.filter), tc.page, tc.pageSize)
+		if !assert.Equal(t, tc.wantProposals, res) {
+			t.Errorf("%s: diff result, got %v, want %v", tc.testName, res, tc.wantProposals)
+		}
+	}
+}
+
+func TestGetProposalTypeByName(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		name             string
+		wantProposalType types.ProposalType
+		wantErr          sdk.Error
+	}{
+		{
+			testName:         "change param",
+			name:             "changeParam",
+			wantProposalType: types.ChangeParam,
+			wantErr:          nil,
+		},
+		{
+			testName:         "content restore",
+			name:             "contentRestore",
+			wantProposalType: types.ContentRestore,
+			wantErr:          nil,
+		},
+		{
+			testName:         "unknown type",
+			name:             "unknown",
+			wantProposalType: types.ChangeParam,
+			wantErr:          ErrIncorrectProposalType(),
+		},
+	}
+	for _, tc := range testCases {
+		proposalType, err := GetProposalTypeByName(tc.name)
+		if !assert.Equal(t, tc.wantErr, err) {
+			t.Errorf("%s: diff err, got %v, want %v", tc.testName, err, tc.wantErr)
+		}
+		if proposalType != tc.wantProposalType {
+			t.Errorf("%s: diff proposal type, got %v, want %v", tc.testName, proposalType, tc.wantProposalType)
+		}
+	}
+}
+
+func TestNewProposalTally(t *testing.T) {
+	ctx, _, pm, _, _, _, _ := setupTest(t, 0)
+	proposalParam, _ := pm.paramHolder.GetProposalParam(ctx)
+	validator1 := types.AccountKey("validator1")
+	validator2 := types.AccountKey("validator2")
+	validator3 := types.AccountKey("validator3")
+	oncallValidators := []types.AccountKey{validator1, validator2, validator3}
+	votes := []votemodel.Vote{
+		{Voter: validator2, VotingPower: types.NewCoinFromInt64(1), Result: true},
+	}
+	curTime := int64(1000)
+
+	testCases := []struct {
+		testName  string
+		proposal  model.Proposal
+		wantTally *ProposalTally
+	}{
+		{
+			testName: "change param proposal without quorum",
+			proposal: &model.ChangeParamProposal{
+				ProposalInfo: model.ProposalInfo{
+					ProposalID:    types.ProposalKey("1"),
+					AgreeVotes:    types.NewCoinFromInt64(3),
+					DisagreeVotes: types.NewCoinFromInt64(1),
+					ExpiredAt:     curTime + 100,
+				},
+			},
+			wantTally: &ProposalTally{
+				ProposalID:         types.ProposalKey("1"),
+				ProposalType:       types.ChangeParam,
+				AgreeVotes:         types.NewCoinFromInt64(3),
+				DisagreeVotes:      types.NewCoinFromInt64(1),
+				TotalVotes:         types.NewCoinFromInt64(4),
+				PassVotes:          proposalParam.ChangeParamPassVotes,
+				QuorumReached:      false,
+				AgreeRatio:         sdk.NewRat(3, 4),
+				PassRatio:          proposalParam.ChangeParamPassRatio,
+				RatioReached:       true,
+				SecondsToExpire:    100,
+				NotVotedValidators: []types.AccountKey{validator1, validator3},
+				MissVotePenalty:    true,
+			},
+		},
+		{
+			testName: "expired censorship proposal reaches quorum",
+			proposal: &model.ContentCensorshipProposal{
+				ProposalInfo: model.ProposalInfo{
+					ProposalID:    types.ProposalKey("2"),
+					AgreeVotes:    proposalParam.ContentCensorshipPassVotes,
+					DisagreeVotes: proposalParam.ContentCensorshipPassVotes,
+					ExpiredAt:     curTime - 1,
+				},
+			},
+			wantTally: &ProposalTally{
+				ProposalID:         types.ProposalKey("2"),
+				ProposalType:       types.ContentCensorship,
+				AgreeVotes:         proposalParam.ContentCensorshipPassVotes,
+				DisagreeVotes:      proposalParam.ContentCensorshipPassVotes,
+				TotalVotes:         proposalParam.ContentCensorshipPassVotes.Plus(proposalParam.ContentCensorshipPassVotes),
+				PassVotes:          proposalParam.ContentCensorshipPassVotes,
+				QuorumReached:      true,
+				AgreeRatio:         sdk.NewRat(1, 2),
+				PassRatio:          proposalParam.ContentCensorshipPassRatio,
+				RatioReached:       false,
+				SecondsToExpire:    0,
+				NotVotedValidators: []types.AccountKey{validator1, validator3},
+				MissVotePenalty:    false,
+			},
+		},
+		{
+			testName: "text proposal without votes",
+			proposal: &model.TextProposal{
+				ProposalInfo: model.ProposalInfo{
+					ProposalID:    types.ProposalKey("3"),
+					AgreeVotes:    types.NewCoinFromInt64(0),
+					DisagreeVotes: types.NewCoinFromInt64(0),
+					ExpiredAt:     curTime,
+				},
+			},
+			wantTally: &ProposalTally{
+				ProposalID:         types.ProposalKey("3"),
+				ProposalType:       types.TextProposal,
+				AgreeVotes:         types.NewCoinFromInt64(0),
+				DisagreeVotes:      types.NewCoinFromInt64(0),
+				TotalVotes:         types.NewCoinFromInt64(0),
+				PassVotes:          proposalParam.TextProposalPassVotes,
+				QuorumReached:      false,
+				AgreeRatio:         sdk.ZeroRat(),
+				PassRatio:          proposalParam.TextProposalPassRatio,
+				RatioReached:       false,
+				SecondsToExpire:    0,
+				NotVotedValidators: []types.AccountKey{validator1, validator3},
+				MissVotePenalty:    false,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tally, err := NewProposalTally(tc.proposal, proposalParam, oncallValidators, votes, curTime)
+		if err != nil {
+			t.Errorf("%s: failed to get tally, got err %v", tc.testName, err)
+		}
+		if !assert.Equal(t, tc.wantTally, tally) {
+			t.Errorf("%s: diff tally, got %v, want %v", tc.testName, tally, tc.wantTally)
+		}
+	}
+}
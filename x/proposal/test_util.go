@@ -54,6 +54,7 @@ func setupTest(t *testing.T, height int64) (
 	cdc.RegisterConcrete(acc.ReturnCoinEvent{}, "1", nil)
 	cdc.RegisterConcrete(param.ChangeParamEvent{}, "2", nil)
 	cdc.RegisterConcrete(DecideProposalEvent{}, "3", nil)
+	cdc.RegisterConcrete(post.RewardEvent{}, "4", nil)
 
 	err := initGlobalManager(ctx, globalManager)
 	assert.Nil(t, err)
@@ -2,23 +2,29 @@ package proposal
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(VoteProposalMsg{}, "lino/voteProposal", nil)
-	cdc.RegisterConcrete(DeletePostContentMsg{}, "lino/deletePostContent", nil)
-	cdc.RegisterConcrete(UpgradeProtocolMsg{}, "lino/upgradeProtocol", nil)
-	cdc.RegisterConcrete(ChangeGlobalAllocationParamMsg{}, "lino/changeGlobalAllocation", nil)
-	cdc.RegisterConcrete(ChangeEvaluateOfContentValueParamMsg{}, "lino/changeEvaluation", nil)
-	cdc.RegisterConcrete(ChangeInfraInternalAllocationParamMsg{}, "lino/changeInfraAllocation", nil)
-	cdc.RegisterConcrete(ChangeVoteParamMsg{}, "lino/changeVoteParam", nil)
-	cdc.RegisterConcrete(ChangeProposalParamMsg{}, "lino/changeProposalParam", nil)
-	cdc.RegisterConcrete(ChangeDeveloperParamMsg{}, "lino/changeDeveloperParam", nil)
-	cdc.RegisterConcrete(ChangeValidatorParamMsg{}, "lino/changeValidatorParam", nil)
-	cdc.RegisterConcrete(ChangeBandwidthParamMsg{}, "lino/changeBandwidthParam", nil)
-	cdc.RegisterConcrete(ChangeAccountParamMsg{}, "lino/changeAccountParam", nil)
-	cdc.RegisterConcrete(ChangePostParamMsg{}, "lino/changePostParam", nil)
+	types.RegisterMsg(cdc, VoteProposalMsg{}, "lino/voteProposal")
+	types.RegisterMsg(cdc, DeletePostContentMsg{}, "lino/deletePostContent")
+	types.RegisterMsg(cdc, UpgradeProtocolMsg{}, "lino/upgradeProtocol")
+	types.RegisterMsg(cdc, ChangeGlobalAllocationParamMsg{}, "lino/changeGlobalAllocation")
+	types.RegisterMsg(cdc, ChangeEvaluateOfContentValueParamMsg{}, "lino/changeEvaluation")
+	types.RegisterMsg(cdc, ChangeInfraInternalAllocationParamMsg{}, "lino/changeInfraAllocation")
+	types.RegisterMsg(cdc, ChangeVoteParamMsg{}, "lino/changeVoteParam")
+	types.RegisterMsg(cdc, ChangeProposalParamMsg{}, "lino/changeProposalParam")
+	types.RegisterMsg(cdc, ChangeDeveloperParamMsg{}, "lino/changeDeveloperParam")
+	types.RegisterMsg(cdc, ChangeValidatorParamMsg{}, "lino/changeValidatorParam")
+	types.RegisterMsg(cdc, ChangeBandwidthParamMsg{}, "lino/changeBandwidthParam")
+	types.RegisterMsg(cdc, ChangeAccountParamMsg{}, "lino/changeAccountParam")
+	types.RegisterMsg(cdc, ChangePostParamMsg{}, "lino/changePostParam")
+	types.RegisterMsg(cdc, ChangeCoinDayParamMsg{}, "lino/changeCoinDayParam")
+	types.RegisterMsg(cdc, ChangeReputationParamMsg{}, "lino/changeReputationParam")
+	types.RegisterMsg(cdc, ChangeParamFieldsMsg{}, "lino/changeParamFields")
+	types.RegisterMsg(cdc, SubmitTextProposalMsg{}, "lino/submitTextProposal")
+	types.RegisterMsg(cdc, RestorePostContentMsg{}, "lino/restorePostContent")
 }
 
 var msgCdc = wire.NewCodec()
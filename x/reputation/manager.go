@@ -178,3 +178,22 @@ func (rep ReputationManager) GetCurrentRound(ctx sdk.Context) (int64, sdk.Error)
 	_, ts := handler.GetCurrentRound()
 	return ts, nil
 }
+
+// KVReader - read access to raw reputation store, e.g. through ABCI queries
+type KVReader interface {
+	Get(key []byte) []byte
+}
+
+// readOnlyStore - reading reputation never writes, Set is a no-op to satisfy the store interface
+type readOnlyStore struct {
+	KVReader
+}
+
+func (store readOnlyStore) Set(key []byte, val []byte) {}
+
+// GetReputationFromStore - compute reputation of user from raw reputation store outside of
+// block execution, the result is the same as ReputationManager.GetReputation
+func GetReputationFromStore(reader KVReader, username types.AccountKey) types.Coin {
+	handler := model.NewReputation(model.NewReputationStoreDefaultN(readOnlyStore{reader}))
+	return types.NewCoinFromBigInt(handler.GetReputation(string(username)))
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"fmt"
 	"os/user"
 
 	"github.com/spf13/cobra"
@@ -61,14 +60,7 @@ func sendDepositValidatorTx(cdc *wire.Codec) client.CommandTxCallback {
 		msg := validator.NewValidatorDepositMsg(
 			name, types.LNO(viper.GetString(client.FlagAmount)), pubKey, viper.GetString(client.FlagLink))
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, err := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
@@ -2,13 +2,14 @@ package validator
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(ValidatorDepositMsg{}, "lino/valDeposit", nil)
-	cdc.RegisterConcrete(ValidatorWithdrawMsg{}, "lino/valWithdraw", nil)
-	cdc.RegisterConcrete(ValidatorRevokeMsg{}, "lino/valRevoke", nil)
+	types.RegisterMsg(cdc, ValidatorDepositMsg{}, "lino/valDeposit")
+	types.RegisterMsg(cdc, ValidatorWithdrawMsg{}, "lino/valWithdraw")
+	types.RegisterMsg(cdc, ValidatorRevokeMsg{}, "lino/valRevoke")
 }
 
 var msgCdc = wire.NewCodec()
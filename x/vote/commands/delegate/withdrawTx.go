@@ -1,8 +1,6 @@
 package delegate
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -34,14 +32,7 @@ func sendWithdrawDelegateTx(cdc *wire.Codec) client.CommandTxCallback {
 		// create the message
 		msg := vote.NewDelegatorWithdrawMsg(user, voter, viper.GetString(client.FlagAmount))
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if signErr != nil {
-			return signErr
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
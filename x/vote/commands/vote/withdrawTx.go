@@ -1,8 +1,6 @@
 package vote
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -32,14 +30,7 @@ func sendWithdrawVoterTx(cdc *wire.Codec) client.CommandTxCallback {
 		// create the message
 		msg := vote.NewStakeOutMsg(user, viper.GetString(client.FlagAmount))
 
-		// build and sign the transaction, then broadcast to Tendermint
-		res, signErr := ctx.SignBuildBroadcast([]sdk.Msg{msg}, cdc)
-
-		if signErr != nil {
-			return signErr
-		}
-
-		fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
-		return nil
+		// build and sign the transaction, then broadcast to Tendermint or simulate it
+		return client.SendTx(ctx, []sdk.Msg{msg}, cdc)
 	}
 }
@@ -336,12 +336,18 @@ func (vm VoteManager) GetPenaltyList(
 	}
 
 	// put all validators who didn't vote on these two types proposal into penalty list
-	if proposalType == types.ChangeParam || proposalType == types.ProtocolUpgrade {
+	if IsPenaltyProposalType(proposalType) {
 		penaltyList.PenaltyList = oncallValidators
 	}
 	return penaltyList, nil
 }
 
+// IsPenaltyProposalType - check if oncall validators who miss the vote of
+// this proposal type will be punished
+func IsPenaltyProposalType(proposalType types.ProposalType) bool {
+	return proposalType == types.ChangeParam || proposalType == types.ProtocolUpgrade
+}
+
 // GetLinoStake - get lino stake
 func (vm VoteManager) GetLinoStake(ctx sdk.Context, accKey types.AccountKey) (types.Coin, sdk.Error) {
 	voter, err := vm.storage.GetVoter(ctx, accKey)
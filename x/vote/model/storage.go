@@ -181,7 +181,7 @@ func (vs VoteStorage) GetAllDelegators(ctx sdk.Context, voterName types.AccountK
 // GetAllVotes - get all votes of a proposal from KVStore
 func (vs VoteStorage) GetAllVotes(ctx sdk.Context, proposalID types.ProposalKey) ([]Vote, sdk.Error) {
 	store := ctx.KVStore(vs.key)
-	iterator := store.Iterator(subspace(getVotePrefix(proposalID)))
+	iterator := store.Iterator(subspace(GetVotePrefix(proposalID)))
 
 	var votes []Vote
 
@@ -232,13 +232,14 @@ func GetDelegationKey(me types.AccountKey, myDelegator types.AccountKey) []byte
 	return append(getDelegationPrefix(me), myDelegator...)
 }
 
-func getVotePrefix(id types.ProposalKey) []byte {
+// GetVotePrefix - "vote substore" + "proposalID"
+func GetVotePrefix(id types.ProposalKey) []byte {
 	return append(append(voteSubstore, id...), types.KeySeparator...)
 }
 
 // GetVoteKey - "vote substore" + "proposalID" + "voter"
 func GetVoteKey(proposalID types.ProposalKey, voter types.AccountKey) []byte {
-	return append(getVotePrefix(proposalID), voter...)
+	return append(GetVotePrefix(proposalID), voter...)
 }
 
 // GetVoterKey - "voter substore" + "voter"
@@ -2,15 +2,16 @@ package vote
 
 import (
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/lino-network/lino/types"
 )
 
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
-	cdc.RegisterConcrete(StakeInMsg{}, "lino/stakeIn", nil)
-	cdc.RegisterConcrete(StakeOutMsg{}, "lino/stakeOut", nil)
-	cdc.RegisterConcrete(DelegateMsg{}, "lino/delegate", nil)
-	cdc.RegisterConcrete(DelegatorWithdrawMsg{}, "lino/delegateWithdraw", nil)
-	cdc.RegisterConcrete(ClaimInterestMsg{}, "lino/claimInterest", nil)
+	types.RegisterMsg(cdc, StakeInMsg{}, "lino/stakeIn")
+	types.RegisterMsg(cdc, StakeOutMsg{}, "lino/stakeOut")
+	types.RegisterMsg(cdc, DelegateMsg{}, "lino/delegate")
+	types.RegisterMsg(cdc, DelegatorWithdrawMsg{}, "lino/delegateWithdraw")
+	types.RegisterMsg(cdc, ClaimInterestMsg{}, "lino/claimInterest")
 }
 
 var msgCdc = wire.NewCodec()